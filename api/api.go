@@ -1,6 +1,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	"github.com/enbility/spine-go/model"
 )
 
@@ -10,6 +13,208 @@ type EventHandlerInterface interface {
 	HandleEvent(EventPayload)
 }
 
+/* Events */
+
+// Dispatches events of a local device to the subscribed event handlers
+type EventsInterface interface {
+	// Subscribe to events and handle them in the EventHandlerInterface implementation
+	Subscribe(handler EventHandlerInterface) error
+	// Subscribe to events matching the filter of the options and handle them in the
+	// EventHandlerInterface implementation
+	//
+	// The events are delivered in the order they were published, using a bounded queue
+	// and the overflow policy defined in the options
+	SubscribeWithOptions(handler EventHandlerInterface, options EventSubscriptionOptions) error
+	// Subscribe to events matching the filter and receive them in the order they were published
+	// via the returned channel
+	//
+	// The channel is closed once the context is done, a nil context keeps the subscription
+	// like context.Background. If the channel is not read fast enough, new events are dropped
+	SubscribeChannel(ctx context.Context, filter EventFilter) <-chan EventPayload
+	// Unsubscribe from getting events
+	Unsubscribe(handler EventHandlerInterface) error
+	// Publish an event to all subscribers
+	Publish(payload EventPayload)
+}
+
+/* Authorization */
+
+// Decides if an incoming subscription or binding request of a remote client feature
+// for a local server feature is accepted
+//
+// Return an error to reject the request, the remote receives a CommandRejected result
+type AuthorizationCallbackFunc func(ski string, clientFeature FeatureRemoteInterface, serverFeature FeatureLocalInterface) error
+
+/* Access Control */
+
+type AccessOperationType uint
+
+const (
+	AccessOperationRead      AccessOperationType = iota // read a function of a local feature
+	AccessOperationWrite                                // write a function of a local feature
+	AccessOperationSubscribe                            // subscribe to a local feature and receive notifies of a function
+	AccessOperationForward                              // forward a datagram with a function to another device, used by routers
+	AccessOperationCall                                 // call a function of a local feature, e.g. to start a process
+)
+
+// Decides which remote features may access the functions of local features
+type AccessControlInterface interface {
+	// Check if the remote feature may perform the operation on the function of the local feature
+	//
+	// function is empty if the operation is requested for the whole local feature,
+	// which is the case for subscription requests.
+	// localFeature is nil for AccessOperationForward, as the datagram is addressed to another device
+	IsAccessAllowed(
+		remoteFeature FeatureRemoteInterface,
+		localFeature FeatureLocalInterface,
+		function model.FunctionType,
+		operation AccessOperationType) bool
+}
+
+/* Notify Rate Limiting */
+
+// Limits how often notifies of a function of a local feature are sent to a subscriber
+type NotifyRateLimit struct {
+	// Time to wait after a change, so further changes are sent within the same notify
+	CoalescingWindow time.Duration
+	// Minimum time between two notifies of a function to the same subscriber
+	MinInterval time.Duration
+}
+
+/* Capture */
+
+type DatagramDirectionType string
+
+const (
+	DatagramDirectionIncoming DatagramDirectionType = "in"  // received from a remote device
+	DatagramDirectionOutgoing DatagramDirectionType = "out" // sent to a remote device
+)
+
+// Records the SPINE datagrams exchanged with remote devices
+type DatagramRecorderInterface interface {
+	// Record a datagram, message contains the SHIP payload with the datagram
+	RecordDatagram(direction DatagramDirectionType, ski string, message []byte)
+}
+
+/* Interceptors */
+
+type InterceptorActionType uint
+
+const (
+	InterceptorActionContinue InterceptorActionType = iota // continue with the next interceptor and process or send the datagram
+	InterceptorActionStop                                  // stop silently, the datagram is neither processed nor sent, sending a request fails with ErrDatagramStopped
+	InterceptorActionReject                                // stop and reject the datagram, incoming datagrams are answered with a result error
+)
+
+// Intercepts a datagram received from or sent to the remote device with the SKI
+//
+// The datagram may be modified. The error is only used for rejected datagrams,
+// if it is nil, ErrorNumberTypeCommandRejected is used
+type InterceptorFunc func(ski string, datagram *model.DatagramType) (InterceptorActionType, *model.ErrorType)
+
+/* Inbound Queue */
+
+// Metrics of the inbound queue of a remote device
+type InboundQueueMetrics struct {
+	Capacity  uint   // maximum number of queued datagrams, 0 if no queue is used
+	Depth     uint   // number of datagrams waiting to be processed
+	MaxDepth  uint   // highest number of datagrams waiting at the same time
+	Processed uint64 // number of processed datagrams
+	Blocked   uint64 // number of datagrams which had to wait for space in the full queue
+}
+
+/* Outbound Entries */
+
+type OutboundEntryType uint
+
+const (
+	OutboundEntryTypeSubscription OutboundEntryType = iota // A subscription of a local client feature to a remote feature
+	OutboundEntryTypeBinding                               // A binding of a local client feature to a remote feature
+)
+
+// A subscription or binding of a local client feature to a remote feature
+//
+// The remote feature is identified by its entity address, entity type, feature type and role,
+// as its feature address may change after a reconnect
+type OutboundEntry struct {
+	Type          OutboundEntryType
+	LocalFeature  *model.FeatureAddressType
+	EntityAddress []model.AddressEntityType // the address of the remote entity the entry was established with
+	EntityType    model.EntityTypeType
+	FeatureType   model.FeatureTypeType
+	Role          model.RoleType
+}
+
+// The lifecycle state of a subscription or binding of a local client feature to a remote feature
+type OutboundStateType uint
+
+const (
+	OutboundStatePending  OutboundStateType = iota // The request was sent, the result was not received yet
+	OutboundStateActive                            // The remote accepted the request
+	OutboundStateRejected                          // The remote answered the request with an error result, or did not answer in time
+	OutboundStateRemoved                           // The subscription or binding was removed, or the remote device or entity disconnected
+)
+
+// A change of the state of a subscription or binding, published as data of EventTypeOutboundStateChange
+type OutboundStateChange struct {
+	Type          OutboundEntryType
+	State         OutboundStateType
+	RemoteAddress *model.FeatureAddressType
+	Error         *model.ErrorType // the error result of a rejected request
+}
+
+// An outbound entry which could not be re-established after a reconnect
+type OutboundEntryRestoreFailure struct {
+	Entry OutboundEntry
+	Error *model.ErrorType
+}
+
+/* Quirks */
+
+// A workaround for non-conformant remote devices
+//
+// Applications may define their own quirks and check them using QuirksRegistryInterface.HasQuirk
+type QuirkType string
+
+const (
+	// Send heartbeats 2 seconds before the heartbeat timeout, as some devices (like Elli Connect/Pro)
+	// expect a heartbeat within the timeout instead of within twice the timeout
+	QuirkHeartbeatEarly QuirkType = "heartbeatEarly"
+	// Support the functions of all feature types on Generic features, as some devices (like Vaillant Arotherm,
+	// SMA HM 2.0 or Elli) use Generic features, e.g. for heartbeats instead of DeviceDiagnosis
+	QuirkGenericFeatureFunctions QuirkType = "genericFeatureFunctions"
+	// Accept detailed discovery notifies with full data instead of partial data, as sent by some devices (like Porsche)
+	QuirkDetailedDiscoveryFullNotify QuirkType = "detailedDiscoveryFullNotify"
+)
+
+// Enables quirks for the remote devices matching all set fields
+type QuirkRule struct {
+	// The SKI of the remote device
+	Ski string
+	// The brand or vendor name of the DeviceClassificationManufacturerData of the remote device, case insensitive
+	BrandName string
+	// The device name of the DeviceClassificationManufacturerData of the remote device, case insensitive
+	DeviceModel string
+
+	Quirks []QuirkType
+}
+
+// Decides which quirks are used for which remote device
+type QuirksRegistryInterface interface {
+	// Add a rule enabling quirks for matching remote devices
+	AddRule(rule QuirkRule)
+	// Set the quirks enabled for all remote devices, by default all built-in quirks are enabled
+	//
+	// Rules for the known devices requiring the built-in quirks still apply after disabling them
+	SetDefaultQuirks(quirks ...QuirkType)
+	// Check if a quirk is enabled for a remote device
+	//
+	// Manufacturer data is only available if the DeviceClassificationManufacturerData of the remote device was read,
+	// so rules using it do not apply to the processing of the initial detailed discovery data.
+	// Quirks are evaluated whenever they are used, so rules apply once the manufacturer data is known
+	HasQuirk(remoteDevice DeviceRemoteInterface, quirk QuirkType) bool
+}
+
 /* Binding Manager */
 
 // implemented by BindingManagerImpl
@@ -21,6 +226,8 @@ type BindingManagerInterface interface {
 	Bindings(remoteDevice DeviceRemoteInterface) []*BindingEntry
 	BindingsOnFeature(featureAddress model.FeatureAddressType) []*BindingEntry
 	HasLocalFeatureRemoteBinding(localAddress, remoteAddress *model.FeatureAddressType) bool
+	// Add a callback which has to accept every new binding
+	AddAuthorizationCallback(callback AuthorizationCallbackFunc)
 }
 
 /* Subscription Manager */
@@ -32,6 +239,36 @@ type SubscriptionManagerInterface interface {
 	RemoveSubscriptionsForEntity(remoteEntity EntityRemoteInterface)
 	Subscriptions(remoteDevice DeviceRemoteInterface) []*SubscriptionEntry
 	SubscriptionsOnFeature(featureAddress model.FeatureAddressType) []*SubscriptionEntry
+	// Add a callback which has to accept every new subscription
+	AddAuthorizationCallback(callback AuthorizationCallbackFunc)
+}
+
+/* Storage */
+
+// Persists subscriptions, bindings and the last used msgCounter of remote devices,
+// so they are available after a restart
+//
+// All entries are stored per remote device SKI
+type StorageInterface interface {
+	// Save a subscription, saving an existing entry again has no effect
+	SaveSubscription(ski string, entry StorageEntry) error
+	// Remove a subscription
+	RemoveSubscription(ski string, entry StorageEntry) error
+	// Get all subscriptions
+	Subscriptions(ski string) ([]StorageEntry, error)
+
+	// Save a binding, saving an existing entry again has no effect
+	SaveBinding(ski string, entry StorageEntry) error
+	// Remove a binding
+	RemoveBinding(ski string, entry StorageEntry) error
+	// Get all bindings
+	Bindings(ski string) ([]StorageEntry, error)
+
+	// Save the last msgCounter reserved for sending messages,
+	// the msgCounters up to it may already be used
+	SaveMsgCounter(ski string, msgCounter model.MsgCounterType) error
+	// Get the last msgCounter reserved for sending messages, 0 if none is stored
+	MsgCounter(ski string) (model.MsgCounterType, error)
 }
 
 /* Heartbeats */
@@ -43,6 +280,22 @@ type HeartbeatManagerInterface interface {
 	StopHeartbeat()
 }
 
+// Monitors the heartbeats of a remote DeviceDiagnosis server feature
+//
+// Changes of the heartbeat state are published as EventTypeHeartbeatChange events
+type HeartbeatMonitorInterface interface {
+	// Subscribe to the remote feature and start monitoring its heartbeats
+	Start() error
+	// Stop monitoring the heartbeats
+	Stop()
+	// Returns true if the monitoring is running
+	IsRunning() bool
+	// Returns true if no heartbeat was received within the heartbeat timeout
+	IsHeartbeatMissing() bool
+	// Get the last received heartbeat data and the local time it was received
+	LastHeartbeat() (*model.DeviceDiagnosisHeartbeatDataType, time.Time)
+}
+
 type OperationsInterface interface {
 	Write() bool
 	WritePartial() bool
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"context"
+
 	shipapi "github.com/enbility/ship-go/api"
 	"github.com/enbility/spine-go/model"
 )
@@ -65,6 +67,9 @@ type DeviceLocalInterface interface {
 	// Process incoming SPINE datagram
 	ProcessCmd(datagram model.DatagramType, remoteDevice DeviceRemoteInterface) error
 
+	// Get the event dispatcher of this device
+	Events() EventsInterface
+
 	// Get the node management
 	NodeManagement() NodeManagementInterface
 
@@ -73,8 +78,72 @@ type DeviceLocalInterface interface {
 
 	// Get the subscription manager
 	SubscriptionManager() SubscriptionManagerInterface
-	// Send a notify message to remote device subscribing to a specific feature
-	NotifySubscribers(featureAddress *model.FeatureAddressType, cmd model.CmdType)
+
+	// Set the storage used to persist subscriptions, bindings and msgCounters
+	//
+	// Has to be set before any remote device is added
+	SetStorage(storage StorageInterface)
+	// Get the storage, nil if none is set
+	Storage() StorageInterface
+
+	// Set the access control used to check read, write and subscribe requests of remote devices
+	//
+	// If none is set, all requests are allowed
+	SetAccessControl(accessControl AccessControlInterface)
+	// Get the access control, nil if none is set
+	AccessControl() AccessControlInterface
+
+	// Set the recorder capturing all datagrams sent to and received from remote devices
+	//
+	// Can be set or replaced at any time, it is used for all following datagrams
+	SetDatagramRecorder(recorder DatagramRecorderInterface)
+	// Get the datagram recorder, nil if none is set
+	DatagramRecorder() DatagramRecorderInterface
+
+	// Get the registry of the quirks used for non-conformant remote devices
+	Quirks() QuirksRegistryInterface
+
+	// Add an interceptor for all datagrams received from remote devices
+	//
+	// Interceptors run in the order they were added, after a datagram is unmarshalled
+	AddInboundInterceptor(interceptor InterceptorFunc)
+	// Add an interceptor for all datagrams sent to remote devices
+	//
+	// Interceptors run in the order they were added, before a datagram is marshalled.
+	// If a request is stopped, sending it fails with ErrDatagramStopped
+	AddOutboundInterceptor(interceptor InterceptorFunc)
+
+	// Set the rate limit for notifies of a local feature,
+	// or the default rate limit of all local features if featureAddress is nil
+	//
+	// Updates within the rate limit are merged into a single notify per subscriber.
+	// The default is no rate limit, which sends every notify immediately
+	SetNotifyRateLimit(featureAddress *model.FeatureAddressType, limit NotifyRateLimit)
+	// Set the functions whose notifies are always sent immediately, ignoring any rate limit
+	//
+	// The defaults are LoadControlLimitListData and DeviceDiagnosisHeartbeatData
+	SetNotifyPriorityFunctions(functions ...model.FunctionType)
+
+	// Set the size of the inbound queue of each remote device, 0 disables the queues (default)
+	//
+	// With a queue, the datagrams of a remote device are processed in order by a worker of
+	// the remote device instead of the goroutine of the SHIP connection. If the queue is full,
+	// receiving a datagram blocks until there is space again.
+	// Has to be set before any remote device is added
+	SetInboundQueueSize(size uint)
+
+	// Stop the device gracefully
+	//
+	// Stops processing inbound datagrams, requests received afterwards are rejected. Sends all
+	// rate limited notifies, stops all heartbeats, fails all pending response callbacks and write
+	// approvals, unsubscribes and unbinds from all remote features, removes all entities except the
+	// device information entity, which notifies the remote devices, and waits for the running
+	// restores of subscriptions and bindings and the running application event handlers.
+	// Returns the error of the context, if it is done before they finished
+	Shutdown(ctx context.Context) error
+
+	// Send a notify message with one or more cmds to remote devices subscribing to a specific feature
+	NotifySubscribers(featureAddress *model.FeatureAddressType, cmd ...model.CmdType)
 
 	// Get the SPINE data structure for NodeManagementDetailDiscoveryData messages for this device
 	Information() *model.NodeManagementDetailedDiscoveryDeviceInformationType
@@ -104,6 +173,8 @@ type DeviceRemoteInterface interface {
 
 	// Process incoming data payload
 	HandleSpineMesssage(message []byte) (*model.MsgCounterType, error)
+	// Get the metrics of the inbound queue, which are empty if no queue is used
+	InboundQueueMetrics() InboundQueueMetrics
 
 	// Get the SenderInterface implementation
 	Sender() SenderInterface
@@ -20,11 +20,24 @@ const (
 type EventType uint16
 
 const (
-	EventTypeDeviceChange       EventType = iota // Sent after successful response of NodeManagementDetailedDiscovery
-	EventTypeEntityChange                        // Sent after successful response of NodeManagementDetailedDiscovery
-	EventTypeSubscriptionChange                  // Sent after successful subscription request from remote
-	EventTypeBindingChange                       // Sent after successful binding request from remote
-	EventTypeDataChange                          // Sent after remote provided new data items for a function
+	EventTypeDeviceChange          EventType = iota // Sent after successful response of NodeManagementDetailedDiscovery
+	EventTypeEntityChange                           // Sent after successful response of NodeManagementDetailedDiscovery
+	EventTypeSubscriptionChange                     // Sent after successful subscription request from remote
+	EventTypeBindingChange                          // Sent after successful binding request from remote
+	EventTypeDataChange                             // Sent after remote provided new data items for a function
+	EventTypeHeartbeatChange                        // Sent by a heartbeat monitor if the heartbeat state of a remote changed, Data contains the HeartbeatStateType
+	EventTypeAccessDenied                           // Sent if a remote request was denied by the access control, Data contains the AccessOperationType
+	EventTypeOutboundRestoreFailed                  // Sent if subscriptions or bindings could not be re-established after a reconnect, Data contains []OutboundEntryRestoreFailure
+	EventTypeOutboundStateChange                    // Sent if the state of a subscription or binding of a local client feature changed, Data contains the OutboundStateChange
+)
+
+type HeartbeatStateType uint
+
+const (
+	HeartbeatStateMissing          HeartbeatStateType = iota // No heartbeat was received within the heartbeat timeout
+	HeartbeatStateRecovered                                  // A heartbeat was received after it was missing
+	HeartbeatStateCounterBackwards                           // The heartbeat counter is lower than the previous one, e.g. the remote restarted
+	HeartbeatStateTimestampStale                             // The heartbeat timestamp did not advance, the heartbeat does not reset the timeout
 )
 
 type EventPayload struct {
@@ -39,3 +52,34 @@ type EventPayload struct {
 	CmdClassifier *model.CmdClassifierType // optional, used together with EventType EventTypeDataChange
 	Data          any
 }
+
+// Filter for event subscriptions
+//
+// Only events matching all set fields are delivered, empty fields match every event
+type EventFilter struct {
+	Ski         string                    // SKI of the remote device
+	EventType   *EventType                // type of the event
+	FeatureType *model.FeatureTypeType    // type of the remote feature, or of the local feature if there is no remote feature
+	Function    *model.FunctionType       // function of the event data
+	Entity      []model.AddressEntityType // address of the remote entity
+}
+
+// Defines what happens if an event is published while the queue of a subscriber is full
+type EventOverflowPolicy uint
+
+const (
+	EventOverflowPolicyDropNewest EventOverflowPolicy = iota // The new event is dropped
+	EventOverflowPolicyDropOldest                            // The oldest queued event is dropped
+	EventOverflowPolicyBlock                                 // Publishing blocks until the subscriber processed an event
+)
+
+// Note: with EventOverflowPolicyBlock only the goroutine publishing the event is blocked,
+// e.g. the processing of incoming messages of the remote device the event belongs to.
+// Events published for other remote devices are not affected.
+
+// Options for a subscription with ordered event delivery
+type EventSubscriptionOptions struct {
+	Filter         EventFilter
+	QueueSize      uint                // maximum number of queued events, a default is used if 0
+	OverflowPolicy EventOverflowPolicy // default is EventOverflowPolicyDropNewest
+}
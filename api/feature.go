@@ -44,10 +44,27 @@ type FeatureLocalInterface interface {
 
 	// Add a function type with allowed operations
 	AddFunctionType(function model.FunctionType, read, write bool)
+	// Enable partial reads for a function type added with read support to a server feature
+	//
+	// Incoming read requests with selectors and elements are then answered with
+	// the matching data only, and the partial read operation is announced in the detailed discovery
+	//
+	// Returns an error if the feature is no server or the function is not readable
+	EnablePartialRead(function model.FunctionType) error
 	// Add a callback function to be invoked when SPINE message comes in with a given msgCounterReference value
 	//
-	// Returns an error if there is already a callback for the msgCounter set
+	// For requests sent via this feature, the callback is invoked with a timeout or
+	// destination unreachable error result, if no response will be received anymore
+	//
+	// Returns an error if the callback is nil
 	AddResponseCallback(msgCounterReference model.MsgCounterType, function func(msg ResponseMessage)) error
+	// Add a callback function like AddResponseCallback
+	//
+	// Returns a handle for removing the callback again, or an error if the callback is nil
+	AddResponseCallbackWithHandle(msgCounterReference model.MsgCounterType, function func(msg ResponseMessage)) (ResponseCallbackHandle, error)
+	// Remove a callback function added via AddResponseCallbackWithHandle,
+	// other callbacks for the same msgCounterReference value are kept
+	RemoveResponseCallback(handle ResponseCallbackHandle)
 	// Add a callback function to be invoked when a result message comes in for this feature
 	AddResultCallback(function func(msg ResponseMessage))
 
@@ -96,7 +113,12 @@ type FeatureLocalInterface interface {
 		maxDelay time.Duration) (*model.MsgCounterType, *model.ErrorType)
 
 	// Check if there already is a subscription to a given feature remote address
+	//
+	// Returns true for pending and active subscriptions
 	HasSubscriptionToRemote(remoteAddress *model.FeatureAddressType) bool
+	// Get the state of the subscription to a given feature remote address,
+	// returns false if no subscription was requested
+	SubscriptionToRemoteState(remoteAddress *model.FeatureAddressType) (OutboundStateType, bool)
 	// Trigger a subscription request to a given feature remote address
 	SubscribeToRemote(remoteAddress *model.FeatureAddressType) (*model.MsgCounterType, *model.ErrorType)
 	// Trigger a subscription removal request for a given feature remote address
@@ -105,7 +127,12 @@ type FeatureLocalInterface interface {
 	RemoveAllRemoteSubscriptions()
 
 	// Check if there already is a binding to a given feature remote address
+	//
+	// Returns true for pending and active bindings
 	HasBindingToRemote(remoteAddress *model.FeatureAddressType) bool
+	// Get the state of the binding to a given feature remote address,
+	// returns false if no binding was requested
+	BindingToRemoteState(remoteAddress *model.FeatureAddressType) (OutboundStateType, bool)
 	// Trigger a binding request to a given feature remote address
 	BindToRemote(remoteAddress *model.FeatureAddressType) (*model.MsgCounterType, *model.ErrorType)
 	// Trigger a binding removal request for a given feature remote address
@@ -125,6 +152,44 @@ type NodeManagementInterface interface {
 	FeatureLocalInterface
 }
 
+// Interface for the application handling the network processes of a local NetworkManagement feature
+//
+// The callbacks start a process and return immediately, the process is finished
+// via NetworkManagementInterface.FinishProcess.
+// Return an error to reject the call, the remote receives the error as result
+type NetworkManagementHandlerInterface interface {
+	// Start adding a node to the network behind the gateway
+	AddNode(ski string, data model.NetworkManagementAddNodeCallType) *model.ErrorType
+	// Start removing a node from the network behind the gateway
+	RemoveNode(ski string, data model.NetworkManagementRemoveNodeCallType) *model.ErrorType
+	// Start scanning the network behind the gateway for candidates,
+	// found candidates are reported via NetworkManagementInterface.ReportCandidate
+	ScanNetwork(ski string, data model.NetworkManagementScanNetworkCallType) *model.ErrorType
+	// Start discovering a node of the network behind the gateway, e.g. to update its description
+	Discover(ski string, data model.NetworkManagementDiscoverCallType) *model.ErrorType
+	// Abort the running process, it is finished with the state aborted afterwards
+	AbortProcess(process model.FunctionType)
+}
+
+// Interface for a local NetworkManagement feature, used by gateways to offer
+// the onboarding of devices of another network technology
+type NetworkManagementInterface interface {
+	FeatureLocalInterface
+
+	// Get the function of the running process, nil if no process is running
+	RunningProcess() *model.FunctionType
+	// Finish the running process and notify subscribers about its state
+	//
+	// Returns an error if no process is running
+	FinishProcess(state model.NetworkManagementProcessStateStateType, description string) error
+	// Report a candidate found by the running network scan to subscribers
+	//
+	// Returns an error if no network scan is running
+	ReportCandidate(candidate model.NetworkManagementReportCandidateDataType) error
+	// Set the joining mode of the network behind the gateway, which remote devices can read and subscribe to
+	SetJoiningMode(data model.NetworkManagementJoiningModeDataType)
+}
+
 // This interface defines all the required functions need to implement a remote feature
 type FeatureRemoteInterface interface {
 	FeatureInterface
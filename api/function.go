@@ -12,10 +12,22 @@ type FunctionDataCmdInterface interface {
 	ReadCmdType(partialSelector any, elements any) model.CmdType
 	// Get the CmdType data for a reply command
 	ReplyCmdType(partial bool) model.CmdType
+	// Get the CmdType data for a reply command to a partial read command
+	//
+	// Only the items matching the selector and the fields defined in the elements
+	// of the filter are included, the filter is added to the cmd
+	PartialReplyCmdType(filterPartial *model.FilterType) model.CmdType
 	// Get the CmdType data for a notify or write command
 	//
 	// Note: partialSelector and elements have to be pointers!
 	NotifyOrWriteCmdType(deleteSelector, partialSelector any, partialWithoutSelector bool, deleteElements any) model.CmdType
+	// Get the CmdTypes for notify commands describing the changes from the previous data to the current data
+	//
+	// All changes are sent in a single cmd, with a delete filter for a removed item and a partial
+	// filter for changed and added items, no cmds are returned if the data did not change.
+	// Returns false if the changes can not be described this way, e.g. if more than one item
+	// was removed, and a full notify is required
+	NotifyDiffCmdTypes(previousData any) ([]model.CmdType, bool)
 }
 
 type FunctionDataInterface interface {
@@ -11,6 +11,13 @@ type Message struct {
 	FeatureRemote FeatureRemoteInterface
 	EntityRemote  EntityRemoteInterface
 	DeviceRemote  DeviceRemoteInterface
+	// The position of the cmd in the payload of the datagram, as all cmds of a datagram share the same msgCounter
+	CmdIndex int
+
+	// Optional: if set, the processing result of a write cmd has to be reported via this function
+	// instead of sending a result message directly, as the datagram may contain multiple cmds
+	// and only one combined result is sent for all of them
+	ResultHandler func(err *model.ErrorType)
 }
 
 type ResponseMessage struct {
@@ -21,3 +28,9 @@ type ResponseMessage struct {
 	EntityRemote        EntityRemoteInterface  // required
 	DeviceRemote        DeviceRemoteInterface  // required
 }
+
+// Identifies a callback added via FeatureLocalInterface.AddResponseCallback, used to remove it again
+type ResponseCallbackHandle struct {
+	MsgCounterReference model.MsgCounterType
+	ID                  uint64
+}
@@ -28,10 +28,14 @@ type SenderInterface interface {
 	Bind(senderAddress, destinationAddress *model.FeatureAddressType, serverFeatureType model.FeatureTypeType) (*model.MsgCounterType, error)
 	// Sends a call cmd with a binding delte request
 	Unbind(senderAddress, destinationAddress *model.FeatureAddressType) (*model.MsgCounterType, error)
-	// Sends a notify cmd to indicate that a subscribed feature changed
-	Notify(senderAddress, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error)
-	// Sends a write cmd, setting properties of remote features
-	Write(senderAddress, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error)
+	// Sends a notify with one or more cmds in one datagram to indicate that a subscribed feature changed
+	Notify(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error)
+	// Sends a write with one or more cmds in one datagram, setting properties of remote features
+	Write(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error)
+	// Sends a datagram received from another device, used for routing datagrams
+	//
+	// The header of the datagram is not changed
+	Forward(datagram model.DatagramType) error
 	// return the datagram for a given msgCounter (only availbe for Notify messages!), error if not found
 	DatagramForMsgCounter(msgCounter model.MsgCounterType) (model.DatagramType, error)
 }
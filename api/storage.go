@@ -0,0 +1,10 @@
+package api
+
+import "github.com/enbility/spine-go/model"
+
+// A persisted subscription or binding of a remote client feature to a local server feature
+type StorageEntry struct {
+	ClientAddress     model.FeatureAddressType `json:"clientAddress"`
+	ServerAddress     model.FeatureAddressType `json:"serverAddress"`
+	ServerFeatureType model.FeatureTypeType    `json:"serverFeatureType"`
+}
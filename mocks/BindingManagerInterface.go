@@ -22,6 +22,39 @@ func (_m *BindingManagerInterface) EXPECT() *BindingManagerInterface_Expecter {
 	return &BindingManagerInterface_Expecter{mock: &_m.Mock}
 }
 
+// AddAuthorizationCallback provides a mock function with given fields: callback
+func (_m *BindingManagerInterface) AddAuthorizationCallback(callback api.AuthorizationCallbackFunc) {
+	_m.Called(callback)
+}
+
+// BindingManagerInterface_AddAuthorizationCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuthorizationCallback'
+type BindingManagerInterface_AddAuthorizationCallback_Call struct {
+	*mock.Call
+}
+
+// AddAuthorizationCallback is a helper method to define mock.On call
+//   - callback api.AuthorizationCallbackFunc
+func (_e *BindingManagerInterface_Expecter) AddAuthorizationCallback(callback interface{}) *BindingManagerInterface_AddAuthorizationCallback_Call {
+	return &BindingManagerInterface_AddAuthorizationCallback_Call{Call: _e.mock.On("AddAuthorizationCallback", callback)}
+}
+
+func (_c *BindingManagerInterface_AddAuthorizationCallback_Call) Run(run func(callback api.AuthorizationCallbackFunc)) *BindingManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.AuthorizationCallbackFunc))
+	})
+	return _c
+}
+
+func (_c *BindingManagerInterface_AddAuthorizationCallback_Call) Return() *BindingManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *BindingManagerInterface_AddAuthorizationCallback_Call) RunAndReturn(run func(api.AuthorizationCallbackFunc)) *BindingManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddBinding provides a mock function with given fields: remoteDevice, data
 func (_m *BindingManagerInterface) AddBinding(remoteDevice api.DeviceRemoteInterface, data model.BindingManagementRequestCallType) error {
 	ret := _m.Called(remoteDevice, data)
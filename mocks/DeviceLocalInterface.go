@@ -3,7 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	api "github.com/enbility/spine-go/api"
+
 	mock "github.com/stretchr/testify/mock"
 
 	model "github.com/enbility/spine-go/model"
@@ -24,6 +27,53 @@ func (_m *DeviceLocalInterface) EXPECT() *DeviceLocalInterface_Expecter {
 	return &DeviceLocalInterface_Expecter{mock: &_m.Mock}
 }
 
+// AccessControl provides a mock function with given fields:
+func (_m *DeviceLocalInterface) AccessControl() api.AccessControlInterface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AccessControl")
+	}
+
+	var r0 api.AccessControlInterface
+	if rf, ok := ret.Get(0).(func() api.AccessControlInterface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.AccessControlInterface)
+		}
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_AccessControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AccessControl'
+type DeviceLocalInterface_AccessControl_Call struct {
+	*mock.Call
+}
+
+// AccessControl is a helper method to define mock.On call
+func (_e *DeviceLocalInterface_Expecter) AccessControl() *DeviceLocalInterface_AccessControl_Call {
+	return &DeviceLocalInterface_AccessControl_Call{Call: _e.mock.On("AccessControl")}
+}
+
+func (_c *DeviceLocalInterface_AccessControl_Call) Run(run func()) *DeviceLocalInterface_AccessControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AccessControl_Call) Return(_a0 api.AccessControlInterface) *DeviceLocalInterface_AccessControl_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AccessControl_Call) RunAndReturn(run func() api.AccessControlInterface) *DeviceLocalInterface_AccessControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddEntity provides a mock function with given fields: entity
 func (_m *DeviceLocalInterface) AddEntity(entity api.EntityLocalInterface) {
 	_m.Called(entity)
@@ -57,6 +107,72 @@ func (_c *DeviceLocalInterface_AddEntity_Call) RunAndReturn(run func(api.EntityL
 	return _c
 }
 
+// AddInboundInterceptor provides a mock function with given fields: interceptor
+func (_m *DeviceLocalInterface) AddInboundInterceptor(interceptor api.InterceptorFunc) {
+	_m.Called(interceptor)
+}
+
+// DeviceLocalInterface_AddInboundInterceptor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddInboundInterceptor'
+type DeviceLocalInterface_AddInboundInterceptor_Call struct {
+	*mock.Call
+}
+
+// AddInboundInterceptor is a helper method to define mock.On call
+//   - interceptor api.InterceptorFunc
+func (_e *DeviceLocalInterface_Expecter) AddInboundInterceptor(interceptor interface{}) *DeviceLocalInterface_AddInboundInterceptor_Call {
+	return &DeviceLocalInterface_AddInboundInterceptor_Call{Call: _e.mock.On("AddInboundInterceptor", interceptor)}
+}
+
+func (_c *DeviceLocalInterface_AddInboundInterceptor_Call) Run(run func(interceptor api.InterceptorFunc)) *DeviceLocalInterface_AddInboundInterceptor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.InterceptorFunc))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AddInboundInterceptor_Call) Return() *DeviceLocalInterface_AddInboundInterceptor_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AddInboundInterceptor_Call) RunAndReturn(run func(api.InterceptorFunc)) *DeviceLocalInterface_AddInboundInterceptor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddOutboundInterceptor provides a mock function with given fields: interceptor
+func (_m *DeviceLocalInterface) AddOutboundInterceptor(interceptor api.InterceptorFunc) {
+	_m.Called(interceptor)
+}
+
+// DeviceLocalInterface_AddOutboundInterceptor_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddOutboundInterceptor'
+type DeviceLocalInterface_AddOutboundInterceptor_Call struct {
+	*mock.Call
+}
+
+// AddOutboundInterceptor is a helper method to define mock.On call
+//   - interceptor api.InterceptorFunc
+func (_e *DeviceLocalInterface_Expecter) AddOutboundInterceptor(interceptor interface{}) *DeviceLocalInterface_AddOutboundInterceptor_Call {
+	return &DeviceLocalInterface_AddOutboundInterceptor_Call{Call: _e.mock.On("AddOutboundInterceptor", interceptor)}
+}
+
+func (_c *DeviceLocalInterface_AddOutboundInterceptor_Call) Run(run func(interceptor api.InterceptorFunc)) *DeviceLocalInterface_AddOutboundInterceptor_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.InterceptorFunc))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AddOutboundInterceptor_Call) Return() *DeviceLocalInterface_AddOutboundInterceptor_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_AddOutboundInterceptor_Call) RunAndReturn(run func(api.InterceptorFunc)) *DeviceLocalInterface_AddOutboundInterceptor_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddRemoteDeviceForSki provides a mock function with given fields: ski, rDevice
 func (_m *DeviceLocalInterface) AddRemoteDeviceForSki(ski string, rDevice api.DeviceRemoteInterface) {
 	_m.Called(ski, rDevice)
@@ -218,6 +334,53 @@ func (_c *DeviceLocalInterface_CleanRemoteEntityCaches_Call) RunAndReturn(run fu
 	return _c
 }
 
+// DatagramRecorder provides a mock function with given fields:
+func (_m *DeviceLocalInterface) DatagramRecorder() api.DatagramRecorderInterface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DatagramRecorder")
+	}
+
+	var r0 api.DatagramRecorderInterface
+	if rf, ok := ret.Get(0).(func() api.DatagramRecorderInterface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.DatagramRecorderInterface)
+		}
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_DatagramRecorder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DatagramRecorder'
+type DeviceLocalInterface_DatagramRecorder_Call struct {
+	*mock.Call
+}
+
+// DatagramRecorder is a helper method to define mock.On call
+func (_e *DeviceLocalInterface_Expecter) DatagramRecorder() *DeviceLocalInterface_DatagramRecorder_Call {
+	return &DeviceLocalInterface_DatagramRecorder_Call{Call: _e.mock.On("DatagramRecorder")}
+}
+
+func (_c *DeviceLocalInterface_DatagramRecorder_Call) Run(run func()) *DeviceLocalInterface_DatagramRecorder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_DatagramRecorder_Call) Return(_a0 api.DatagramRecorderInterface) *DeviceLocalInterface_DatagramRecorder_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_DatagramRecorder_Call) RunAndReturn(run func() api.DatagramRecorderInterface) *DeviceLocalInterface_DatagramRecorder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DestinationData provides a mock function with given fields:
 func (_m *DeviceLocalInterface) DestinationData() model.NodeManagementDestinationDataType {
 	ret := _m.Called()
@@ -453,6 +616,53 @@ func (_c *DeviceLocalInterface_EntityForType_Call) RunAndReturn(run func(model.E
 	return _c
 }
 
+// Events provides a mock function with given fields:
+func (_m *DeviceLocalInterface) Events() api.EventsInterface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Events")
+	}
+
+	var r0 api.EventsInterface
+	if rf, ok := ret.Get(0).(func() api.EventsInterface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.EventsInterface)
+		}
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_Events_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Events'
+type DeviceLocalInterface_Events_Call struct {
+	*mock.Call
+}
+
+// Events is a helper method to define mock.On call
+func (_e *DeviceLocalInterface_Expecter) Events() *DeviceLocalInterface_Events_Call {
+	return &DeviceLocalInterface_Events_Call{Call: _e.mock.On("Events")}
+}
+
+func (_c *DeviceLocalInterface_Events_Call) Run(run func()) *DeviceLocalInterface_Events_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Events_Call) Return(_a0 api.EventsInterface) *DeviceLocalInterface_Events_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Events_Call) RunAndReturn(run func() api.EventsInterface) *DeviceLocalInterface_Events_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FeatureByAddress provides a mock function with given fields: address
 func (_m *DeviceLocalInterface) FeatureByAddress(address *model.FeatureAddressType) api.FeatureLocalInterface {
 	ret := _m.Called(address)
@@ -643,8 +853,15 @@ func (_c *DeviceLocalInterface_NodeManagement_Call) RunAndReturn(run func() api.
 }
 
 // NotifySubscribers provides a mock function with given fields: featureAddress, cmd
-func (_m *DeviceLocalInterface) NotifySubscribers(featureAddress *model.FeatureAddressType, cmd model.CmdType) {
-	_m.Called(featureAddress, cmd)
+func (_m *DeviceLocalInterface) NotifySubscribers(featureAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+	_va := make([]interface{}, len(cmd))
+	for _i := range cmd {
+		_va[_i] = cmd[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, featureAddress)
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
 }
 
 // DeviceLocalInterface_NotifySubscribers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifySubscribers'
@@ -654,14 +871,21 @@ type DeviceLocalInterface_NotifySubscribers_Call struct {
 
 // NotifySubscribers is a helper method to define mock.On call
 //   - featureAddress *model.FeatureAddressType
-//   - cmd model.CmdType
-func (_e *DeviceLocalInterface_Expecter) NotifySubscribers(featureAddress interface{}, cmd interface{}) *DeviceLocalInterface_NotifySubscribers_Call {
-	return &DeviceLocalInterface_NotifySubscribers_Call{Call: _e.mock.On("NotifySubscribers", featureAddress, cmd)}
+//   - cmd ...model.CmdType
+func (_e *DeviceLocalInterface_Expecter) NotifySubscribers(featureAddress interface{}, cmd ...interface{}) *DeviceLocalInterface_NotifySubscribers_Call {
+	return &DeviceLocalInterface_NotifySubscribers_Call{Call: _e.mock.On("NotifySubscribers",
+		append([]interface{}{featureAddress}, cmd...)...)}
 }
 
-func (_c *DeviceLocalInterface_NotifySubscribers_Call) Run(run func(featureAddress *model.FeatureAddressType, cmd model.CmdType)) *DeviceLocalInterface_NotifySubscribers_Call {
+func (_c *DeviceLocalInterface_NotifySubscribers_Call) Run(run func(featureAddress *model.FeatureAddressType, cmd ...model.CmdType)) *DeviceLocalInterface_NotifySubscribers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*model.FeatureAddressType), args[1].(model.CmdType))
+		variadicArgs := make([]model.CmdType, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(model.CmdType)
+			}
+		}
+		run(args[0].(*model.FeatureAddressType), variadicArgs...)
 	})
 	return _c
 }
@@ -671,7 +895,7 @@ func (_c *DeviceLocalInterface_NotifySubscribers_Call) Return() *DeviceLocalInte
 	return _c
 }
 
-func (_c *DeviceLocalInterface_NotifySubscribers_Call) RunAndReturn(run func(*model.FeatureAddressType, model.CmdType)) *DeviceLocalInterface_NotifySubscribers_Call {
+func (_c *DeviceLocalInterface_NotifySubscribers_Call) RunAndReturn(run func(*model.FeatureAddressType, ...model.CmdType)) *DeviceLocalInterface_NotifySubscribers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -723,6 +947,53 @@ func (_c *DeviceLocalInterface_ProcessCmd_Call) RunAndReturn(run func(model.Data
 	return _c
 }
 
+// Quirks provides a mock function with given fields:
+func (_m *DeviceLocalInterface) Quirks() api.QuirksRegistryInterface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quirks")
+	}
+
+	var r0 api.QuirksRegistryInterface
+	if rf, ok := ret.Get(0).(func() api.QuirksRegistryInterface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.QuirksRegistryInterface)
+		}
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_Quirks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Quirks'
+type DeviceLocalInterface_Quirks_Call struct {
+	*mock.Call
+}
+
+// Quirks is a helper method to define mock.On call
+func (_e *DeviceLocalInterface_Expecter) Quirks() *DeviceLocalInterface_Quirks_Call {
+	return &DeviceLocalInterface_Quirks_Call{Call: _e.mock.On("Quirks")}
+}
+
+func (_c *DeviceLocalInterface_Quirks_Call) Run(run func()) *DeviceLocalInterface_Quirks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Quirks_Call) Return(_a0 api.QuirksRegistryInterface) *DeviceLocalInterface_Quirks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Quirks_Call) RunAndReturn(run func() api.QuirksRegistryInterface) *DeviceLocalInterface_Quirks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoteDeviceForAddress provides a mock function with given fields: address
 func (_m *DeviceLocalInterface) RemoteDeviceForAddress(address model.AddressDeviceType) api.DeviceRemoteInterface {
 	ret := _m.Called(address)
@@ -1025,6 +1296,218 @@ func (_c *DeviceLocalInterface_RequestRemoteDetailedDiscoveryData_Call) RunAndRe
 	return _c
 }
 
+// SetAccessControl provides a mock function with given fields: accessControl
+func (_m *DeviceLocalInterface) SetAccessControl(accessControl api.AccessControlInterface) {
+	_m.Called(accessControl)
+}
+
+// DeviceLocalInterface_SetAccessControl_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccessControl'
+type DeviceLocalInterface_SetAccessControl_Call struct {
+	*mock.Call
+}
+
+// SetAccessControl is a helper method to define mock.On call
+//   - accessControl api.AccessControlInterface
+func (_e *DeviceLocalInterface_Expecter) SetAccessControl(accessControl interface{}) *DeviceLocalInterface_SetAccessControl_Call {
+	return &DeviceLocalInterface_SetAccessControl_Call{Call: _e.mock.On("SetAccessControl", accessControl)}
+}
+
+func (_c *DeviceLocalInterface_SetAccessControl_Call) Run(run func(accessControl api.AccessControlInterface)) *DeviceLocalInterface_SetAccessControl_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.AccessControlInterface))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetAccessControl_Call) Return() *DeviceLocalInterface_SetAccessControl_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetAccessControl_Call) RunAndReturn(run func(api.AccessControlInterface)) *DeviceLocalInterface_SetAccessControl_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDatagramRecorder provides a mock function with given fields: recorder
+func (_m *DeviceLocalInterface) SetDatagramRecorder(recorder api.DatagramRecorderInterface) {
+	_m.Called(recorder)
+}
+
+// DeviceLocalInterface_SetDatagramRecorder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDatagramRecorder'
+type DeviceLocalInterface_SetDatagramRecorder_Call struct {
+	*mock.Call
+}
+
+// SetDatagramRecorder is a helper method to define mock.On call
+//   - recorder api.DatagramRecorderInterface
+func (_e *DeviceLocalInterface_Expecter) SetDatagramRecorder(recorder interface{}) *DeviceLocalInterface_SetDatagramRecorder_Call {
+	return &DeviceLocalInterface_SetDatagramRecorder_Call{Call: _e.mock.On("SetDatagramRecorder", recorder)}
+}
+
+func (_c *DeviceLocalInterface_SetDatagramRecorder_Call) Run(run func(recorder api.DatagramRecorderInterface)) *DeviceLocalInterface_SetDatagramRecorder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.DatagramRecorderInterface))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetDatagramRecorder_Call) Return() *DeviceLocalInterface_SetDatagramRecorder_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetDatagramRecorder_Call) RunAndReturn(run func(api.DatagramRecorderInterface)) *DeviceLocalInterface_SetDatagramRecorder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetInboundQueueSize provides a mock function with given fields: size
+func (_m *DeviceLocalInterface) SetInboundQueueSize(size uint) {
+	_m.Called(size)
+}
+
+// DeviceLocalInterface_SetInboundQueueSize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInboundQueueSize'
+type DeviceLocalInterface_SetInboundQueueSize_Call struct {
+	*mock.Call
+}
+
+// SetInboundQueueSize is a helper method to define mock.On call
+//   - size uint
+func (_e *DeviceLocalInterface_Expecter) SetInboundQueueSize(size interface{}) *DeviceLocalInterface_SetInboundQueueSize_Call {
+	return &DeviceLocalInterface_SetInboundQueueSize_Call{Call: _e.mock.On("SetInboundQueueSize", size)}
+}
+
+func (_c *DeviceLocalInterface_SetInboundQueueSize_Call) Run(run func(size uint)) *DeviceLocalInterface_SetInboundQueueSize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(uint))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetInboundQueueSize_Call) Return() *DeviceLocalInterface_SetInboundQueueSize_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetInboundQueueSize_Call) RunAndReturn(run func(uint)) *DeviceLocalInterface_SetInboundQueueSize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNotifyPriorityFunctions provides a mock function with given fields: functions
+func (_m *DeviceLocalInterface) SetNotifyPriorityFunctions(functions ...model.FunctionType) {
+	_va := make([]interface{}, len(functions))
+	for _i := range functions {
+		_va[_i] = functions[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// DeviceLocalInterface_SetNotifyPriorityFunctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNotifyPriorityFunctions'
+type DeviceLocalInterface_SetNotifyPriorityFunctions_Call struct {
+	*mock.Call
+}
+
+// SetNotifyPriorityFunctions is a helper method to define mock.On call
+//   - functions ...model.FunctionType
+func (_e *DeviceLocalInterface_Expecter) SetNotifyPriorityFunctions(functions ...interface{}) *DeviceLocalInterface_SetNotifyPriorityFunctions_Call {
+	return &DeviceLocalInterface_SetNotifyPriorityFunctions_Call{Call: _e.mock.On("SetNotifyPriorityFunctions",
+		append([]interface{}{}, functions...)...)}
+}
+
+func (_c *DeviceLocalInterface_SetNotifyPriorityFunctions_Call) Run(run func(functions ...model.FunctionType)) *DeviceLocalInterface_SetNotifyPriorityFunctions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]model.FunctionType, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(model.FunctionType)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetNotifyPriorityFunctions_Call) Return() *DeviceLocalInterface_SetNotifyPriorityFunctions_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetNotifyPriorityFunctions_Call) RunAndReturn(run func(...model.FunctionType)) *DeviceLocalInterface_SetNotifyPriorityFunctions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNotifyRateLimit provides a mock function with given fields: featureAddress, limit
+func (_m *DeviceLocalInterface) SetNotifyRateLimit(featureAddress *model.FeatureAddressType, limit api.NotifyRateLimit) {
+	_m.Called(featureAddress, limit)
+}
+
+// DeviceLocalInterface_SetNotifyRateLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNotifyRateLimit'
+type DeviceLocalInterface_SetNotifyRateLimit_Call struct {
+	*mock.Call
+}
+
+// SetNotifyRateLimit is a helper method to define mock.On call
+//   - featureAddress *model.FeatureAddressType
+//   - limit api.NotifyRateLimit
+func (_e *DeviceLocalInterface_Expecter) SetNotifyRateLimit(featureAddress interface{}, limit interface{}) *DeviceLocalInterface_SetNotifyRateLimit_Call {
+	return &DeviceLocalInterface_SetNotifyRateLimit_Call{Call: _e.mock.On("SetNotifyRateLimit", featureAddress, limit)}
+}
+
+func (_c *DeviceLocalInterface_SetNotifyRateLimit_Call) Run(run func(featureAddress *model.FeatureAddressType, limit api.NotifyRateLimit)) *DeviceLocalInterface_SetNotifyRateLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.FeatureAddressType), args[1].(api.NotifyRateLimit))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetNotifyRateLimit_Call) Return() *DeviceLocalInterface_SetNotifyRateLimit_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetNotifyRateLimit_Call) RunAndReturn(run func(*model.FeatureAddressType, api.NotifyRateLimit)) *DeviceLocalInterface_SetNotifyRateLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStorage provides a mock function with given fields: storage
+func (_m *DeviceLocalInterface) SetStorage(storage api.StorageInterface) {
+	_m.Called(storage)
+}
+
+// DeviceLocalInterface_SetStorage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStorage'
+type DeviceLocalInterface_SetStorage_Call struct {
+	*mock.Call
+}
+
+// SetStorage is a helper method to define mock.On call
+//   - storage api.StorageInterface
+func (_e *DeviceLocalInterface_Expecter) SetStorage(storage interface{}) *DeviceLocalInterface_SetStorage_Call {
+	return &DeviceLocalInterface_SetStorage_Call{Call: _e.mock.On("SetStorage", storage)}
+}
+
+func (_c *DeviceLocalInterface_SetStorage_Call) Run(run func(storage api.StorageInterface)) *DeviceLocalInterface_SetStorage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.StorageInterface))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetStorage_Call) Return() *DeviceLocalInterface_SetStorage_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *DeviceLocalInterface_SetStorage_Call) RunAndReturn(run func(api.StorageInterface)) *DeviceLocalInterface_SetStorage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetupRemoteDevice provides a mock function with given fields: ski, writeI
 func (_m *DeviceLocalInterface) SetupRemoteDevice(ski string, writeI ship_goapi.ShipConnectionDataWriterInterface) ship_goapi.ShipConnectionDataReaderInterface {
 	ret := _m.Called(ski, writeI)
@@ -1074,6 +1557,99 @@ func (_c *DeviceLocalInterface_SetupRemoteDevice_Call) RunAndReturn(run func(str
 	return _c
 }
 
+// Shutdown provides a mock function with given fields: ctx
+func (_m *DeviceLocalInterface) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type DeviceLocalInterface_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DeviceLocalInterface_Expecter) Shutdown(ctx interface{}) *DeviceLocalInterface_Shutdown_Call {
+	return &DeviceLocalInterface_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *DeviceLocalInterface_Shutdown_Call) Run(run func(ctx context.Context)) *DeviceLocalInterface_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Shutdown_Call) Return(_a0 error) *DeviceLocalInterface_Shutdown_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Shutdown_Call) RunAndReturn(run func(context.Context) error) *DeviceLocalInterface_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Storage provides a mock function with given fields:
+func (_m *DeviceLocalInterface) Storage() api.StorageInterface {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Storage")
+	}
+
+	var r0 api.StorageInterface
+	if rf, ok := ret.Get(0).(func() api.StorageInterface); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(api.StorageInterface)
+		}
+	}
+
+	return r0
+}
+
+// DeviceLocalInterface_Storage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Storage'
+type DeviceLocalInterface_Storage_Call struct {
+	*mock.Call
+}
+
+// Storage is a helper method to define mock.On call
+func (_e *DeviceLocalInterface_Expecter) Storage() *DeviceLocalInterface_Storage_Call {
+	return &DeviceLocalInterface_Storage_Call{Call: _e.mock.On("Storage")}
+}
+
+func (_c *DeviceLocalInterface_Storage_Call) Run(run func()) *DeviceLocalInterface_Storage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Storage_Call) Return(_a0 api.StorageInterface) *DeviceLocalInterface_Storage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceLocalInterface_Storage_Call) RunAndReturn(run func() api.StorageInterface) *DeviceLocalInterface_Storage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SubscriptionManager provides a mock function with given fields:
 func (_m *DeviceLocalInterface) SubscriptionManager() api.SubscriptionManagerInterface {
 	ret := _m.Called()
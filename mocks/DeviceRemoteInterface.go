@@ -598,6 +598,51 @@ func (_c *DeviceRemoteInterface_HandleSpineMesssage_Call) RunAndReturn(run func(
 	return _c
 }
 
+// InboundQueueMetrics provides a mock function with given fields:
+func (_m *DeviceRemoteInterface) InboundQueueMetrics() api.InboundQueueMetrics {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for InboundQueueMetrics")
+	}
+
+	var r0 api.InboundQueueMetrics
+	if rf, ok := ret.Get(0).(func() api.InboundQueueMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(api.InboundQueueMetrics)
+	}
+
+	return r0
+}
+
+// DeviceRemoteInterface_InboundQueueMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InboundQueueMetrics'
+type DeviceRemoteInterface_InboundQueueMetrics_Call struct {
+	*mock.Call
+}
+
+// InboundQueueMetrics is a helper method to define mock.On call
+func (_e *DeviceRemoteInterface_Expecter) InboundQueueMetrics() *DeviceRemoteInterface_InboundQueueMetrics_Call {
+	return &DeviceRemoteInterface_InboundQueueMetrics_Call{Call: _e.mock.On("InboundQueueMetrics")}
+}
+
+func (_c *DeviceRemoteInterface_InboundQueueMetrics_Call) Run(run func()) *DeviceRemoteInterface_InboundQueueMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *DeviceRemoteInterface_InboundQueueMetrics_Call) Return(_a0 api.InboundQueueMetrics) *DeviceRemoteInterface_InboundQueueMetrics_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeviceRemoteInterface_InboundQueueMetrics_Call) RunAndReturn(run func() api.InboundQueueMetrics) *DeviceRemoteInterface_InboundQueueMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveEntityByAddress provides a mock function with given fields: addr
 func (_m *DeviceRemoteInterface) RemoveEntityByAddress(addr []model.AddressEntityType) api.EntityRemoteInterface {
 	ret := _m.Called(addr)
@@ -112,6 +112,64 @@ func (_c *FunctionDataCmdInterface_FunctionType_Call) RunAndReturn(run func() mo
 	return _c
 }
 
+// NotifyDiffCmdTypes provides a mock function with given fields: previousData
+func (_m *FunctionDataCmdInterface) NotifyDiffCmdTypes(previousData interface{}) ([]model.CmdType, bool) {
+	ret := _m.Called(previousData)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotifyDiffCmdTypes")
+	}
+
+	var r0 []model.CmdType
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(interface{}) ([]model.CmdType, bool)); ok {
+		return rf(previousData)
+	}
+	if rf, ok := ret.Get(0).(func(interface{}) []model.CmdType); ok {
+		r0 = rf(previousData)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CmdType)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(interface{}) bool); ok {
+		r1 = rf(previousData)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// FunctionDataCmdInterface_NotifyDiffCmdTypes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NotifyDiffCmdTypes'
+type FunctionDataCmdInterface_NotifyDiffCmdTypes_Call struct {
+	*mock.Call
+}
+
+// NotifyDiffCmdTypes is a helper method to define mock.On call
+//   - previousData interface{}
+func (_e *FunctionDataCmdInterface_Expecter) NotifyDiffCmdTypes(previousData interface{}) *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call {
+	return &FunctionDataCmdInterface_NotifyDiffCmdTypes_Call{Call: _e.mock.On("NotifyDiffCmdTypes", previousData)}
+}
+
+func (_c *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call) Run(run func(previousData interface{})) *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(interface{}))
+	})
+	return _c
+}
+
+func (_c *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call) Return(_a0 []model.CmdType, _a1 bool) *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call) RunAndReturn(run func(interface{}) ([]model.CmdType, bool)) *FunctionDataCmdInterface_NotifyDiffCmdTypes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NotifyOrWriteCmdType provides a mock function with given fields: deleteSelector, partialSelector, partialWithoutSelector, deleteElements
 func (_m *FunctionDataCmdInterface) NotifyOrWriteCmdType(deleteSelector interface{}, partialSelector interface{}, partialWithoutSelector bool, deleteElements interface{}) model.CmdType {
 	ret := _m.Called(deleteSelector, partialSelector, partialWithoutSelector, deleteElements)
@@ -161,6 +219,52 @@ func (_c *FunctionDataCmdInterface_NotifyOrWriteCmdType_Call) RunAndReturn(run f
 	return _c
 }
 
+// PartialReplyCmdType provides a mock function with given fields: filterPartial
+func (_m *FunctionDataCmdInterface) PartialReplyCmdType(filterPartial *model.FilterType) model.CmdType {
+	ret := _m.Called(filterPartial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PartialReplyCmdType")
+	}
+
+	var r0 model.CmdType
+	if rf, ok := ret.Get(0).(func(*model.FilterType) model.CmdType); ok {
+		r0 = rf(filterPartial)
+	} else {
+		r0 = ret.Get(0).(model.CmdType)
+	}
+
+	return r0
+}
+
+// FunctionDataCmdInterface_PartialReplyCmdType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PartialReplyCmdType'
+type FunctionDataCmdInterface_PartialReplyCmdType_Call struct {
+	*mock.Call
+}
+
+// PartialReplyCmdType is a helper method to define mock.On call
+//   - filterPartial *model.FilterType
+func (_e *FunctionDataCmdInterface_Expecter) PartialReplyCmdType(filterPartial interface{}) *FunctionDataCmdInterface_PartialReplyCmdType_Call {
+	return &FunctionDataCmdInterface_PartialReplyCmdType_Call{Call: _e.mock.On("PartialReplyCmdType", filterPartial)}
+}
+
+func (_c *FunctionDataCmdInterface_PartialReplyCmdType_Call) Run(run func(filterPartial *model.FilterType)) *FunctionDataCmdInterface_PartialReplyCmdType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.FilterType))
+	})
+	return _c
+}
+
+func (_c *FunctionDataCmdInterface_PartialReplyCmdType_Call) Return(_a0 model.CmdType) *FunctionDataCmdInterface_PartialReplyCmdType_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *FunctionDataCmdInterface_PartialReplyCmdType_Call) RunAndReturn(run func(*model.FilterType) model.CmdType) *FunctionDataCmdInterface_PartialReplyCmdType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReadCmdType provides a mock function with given fields: partialSelector, elements
 func (_m *FunctionDataCmdInterface) ReadCmdType(partialSelector interface{}, elements interface{}) model.CmdType {
 	ret := _m.Called(partialSelector, elements)
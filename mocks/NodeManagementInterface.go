@@ -106,6 +106,63 @@ func (_c *NodeManagementInterface_AddResponseCallback_Call) RunAndReturn(run fun
 	return _c
 }
 
+// AddResponseCallbackWithHandle provides a mock function with given fields: msgCounterReference, function
+func (_m *NodeManagementInterface) AddResponseCallbackWithHandle(msgCounterReference model.MsgCounterType, function func(api.ResponseMessage)) (api.ResponseCallbackHandle, error) {
+	ret := _m.Called(msgCounterReference, function)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddResponseCallbackWithHandle")
+	}
+
+	var r0 api.ResponseCallbackHandle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(model.MsgCounterType, func(api.ResponseMessage)) (api.ResponseCallbackHandle, error)); ok {
+		return rf(msgCounterReference, function)
+	}
+	if rf, ok := ret.Get(0).(func(model.MsgCounterType, func(api.ResponseMessage)) api.ResponseCallbackHandle); ok {
+		r0 = rf(msgCounterReference, function)
+	} else {
+		r0 = ret.Get(0).(api.ResponseCallbackHandle)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.MsgCounterType, func(api.ResponseMessage)) error); ok {
+		r1 = rf(msgCounterReference, function)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NodeManagementInterface_AddResponseCallbackWithHandle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddResponseCallbackWithHandle'
+type NodeManagementInterface_AddResponseCallbackWithHandle_Call struct {
+	*mock.Call
+}
+
+// AddResponseCallbackWithHandle is a helper method to define mock.On call
+//   - msgCounterReference model.MsgCounterType
+//   - function func(api.ResponseMessage)
+func (_e *NodeManagementInterface_Expecter) AddResponseCallbackWithHandle(msgCounterReference interface{}, function interface{}) *NodeManagementInterface_AddResponseCallbackWithHandle_Call {
+	return &NodeManagementInterface_AddResponseCallbackWithHandle_Call{Call: _e.mock.On("AddResponseCallbackWithHandle", msgCounterReference, function)}
+}
+
+func (_c *NodeManagementInterface_AddResponseCallbackWithHandle_Call) Run(run func(msgCounterReference model.MsgCounterType, function func(api.ResponseMessage))) *NodeManagementInterface_AddResponseCallbackWithHandle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.MsgCounterType), args[1].(func(api.ResponseMessage)))
+	})
+	return _c
+}
+
+func (_c *NodeManagementInterface_AddResponseCallbackWithHandle_Call) Return(_a0 api.ResponseCallbackHandle, _a1 error) *NodeManagementInterface_AddResponseCallbackWithHandle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NodeManagementInterface_AddResponseCallbackWithHandle_Call) RunAndReturn(run func(model.MsgCounterType, func(api.ResponseMessage)) (api.ResponseCallbackHandle, error)) *NodeManagementInterface_AddResponseCallbackWithHandle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddResultCallback provides a mock function with given fields: function
 func (_m *NodeManagementInterface) AddResultCallback(function func(api.ResponseMessage)) {
 	_m.Called(function)
@@ -326,6 +383,62 @@ func (_c *NodeManagementInterface_BindToRemote_Call) RunAndReturn(run func(*mode
 	return _c
 }
 
+// BindingToRemoteState provides a mock function with given fields: remoteAddress
+func (_m *NodeManagementInterface) BindingToRemoteState(remoteAddress *model.FeatureAddressType) (api.OutboundStateType, bool) {
+	ret := _m.Called(remoteAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BindingToRemoteState")
+	}
+
+	var r0 api.OutboundStateType
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType) (api.OutboundStateType, bool)); ok {
+		return rf(remoteAddress)
+	}
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType) api.OutboundStateType); ok {
+		r0 = rf(remoteAddress)
+	} else {
+		r0 = ret.Get(0).(api.OutboundStateType)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType) bool); ok {
+		r1 = rf(remoteAddress)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// NodeManagementInterface_BindingToRemoteState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BindingToRemoteState'
+type NodeManagementInterface_BindingToRemoteState_Call struct {
+	*mock.Call
+}
+
+// BindingToRemoteState is a helper method to define mock.On call
+//   - remoteAddress *model.FeatureAddressType
+func (_e *NodeManagementInterface_Expecter) BindingToRemoteState(remoteAddress interface{}) *NodeManagementInterface_BindingToRemoteState_Call {
+	return &NodeManagementInterface_BindingToRemoteState_Call{Call: _e.mock.On("BindingToRemoteState", remoteAddress)}
+}
+
+func (_c *NodeManagementInterface_BindingToRemoteState_Call) Run(run func(remoteAddress *model.FeatureAddressType)) *NodeManagementInterface_BindingToRemoteState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.FeatureAddressType))
+	})
+	return _c
+}
+
+func (_c *NodeManagementInterface_BindingToRemoteState_Call) Return(_a0 api.OutboundStateType, _a1 bool) *NodeManagementInterface_BindingToRemoteState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NodeManagementInterface_BindingToRemoteState_Call) RunAndReturn(run func(*model.FeatureAddressType) (api.OutboundStateType, bool)) *NodeManagementInterface_BindingToRemoteState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CleanRemoteDeviceCaches provides a mock function with given fields: remoteAddress
 func (_m *NodeManagementInterface) CleanRemoteDeviceCaches(remoteAddress *model.DeviceAddressType) {
 	_m.Called(remoteAddress)
@@ -567,6 +680,52 @@ func (_c *NodeManagementInterface_Device_Call) RunAndReturn(run func() api.Devic
 	return _c
 }
 
+// EnablePartialRead provides a mock function with given fields: function
+func (_m *NodeManagementInterface) EnablePartialRead(function model.FunctionType) error {
+	ret := _m.Called(function)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnablePartialRead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.FunctionType) error); ok {
+		r0 = rf(function)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NodeManagementInterface_EnablePartialRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnablePartialRead'
+type NodeManagementInterface_EnablePartialRead_Call struct {
+	*mock.Call
+}
+
+// EnablePartialRead is a helper method to define mock.On call
+//   - function model.FunctionType
+func (_e *NodeManagementInterface_Expecter) EnablePartialRead(function interface{}) *NodeManagementInterface_EnablePartialRead_Call {
+	return &NodeManagementInterface_EnablePartialRead_Call{Call: _e.mock.On("EnablePartialRead", function)}
+}
+
+func (_c *NodeManagementInterface_EnablePartialRead_Call) Run(run func(function model.FunctionType)) *NodeManagementInterface_EnablePartialRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.FunctionType))
+	})
+	return _c
+}
+
+func (_c *NodeManagementInterface_EnablePartialRead_Call) Return(_a0 error) *NodeManagementInterface_EnablePartialRead_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *NodeManagementInterface_EnablePartialRead_Call) RunAndReturn(run func(model.FunctionType) error) *NodeManagementInterface_EnablePartialRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Entity provides a mock function with given fields:
 func (_m *NodeManagementInterface) Entity() api.EntityLocalInterface {
 	ret := _m.Called()
@@ -1079,6 +1238,39 @@ func (_c *NodeManagementInterface_RemoveRemoteSubscription_Call) RunAndReturn(ru
 	return _c
 }
 
+// RemoveResponseCallback provides a mock function with given fields: handle
+func (_m *NodeManagementInterface) RemoveResponseCallback(handle api.ResponseCallbackHandle) {
+	_m.Called(handle)
+}
+
+// NodeManagementInterface_RemoveResponseCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveResponseCallback'
+type NodeManagementInterface_RemoveResponseCallback_Call struct {
+	*mock.Call
+}
+
+// RemoveResponseCallback is a helper method to define mock.On call
+//   - handle api.ResponseCallbackHandle
+func (_e *NodeManagementInterface_Expecter) RemoveResponseCallback(handle interface{}) *NodeManagementInterface_RemoveResponseCallback_Call {
+	return &NodeManagementInterface_RemoveResponseCallback_Call{Call: _e.mock.On("RemoveResponseCallback", handle)}
+}
+
+func (_c *NodeManagementInterface_RemoveResponseCallback_Call) Run(run func(handle api.ResponseCallbackHandle)) *NodeManagementInterface_RemoveResponseCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.ResponseCallbackHandle))
+	})
+	return _c
+}
+
+func (_c *NodeManagementInterface_RemoveResponseCallback_Call) Return() *NodeManagementInterface_RemoveResponseCallback_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *NodeManagementInterface_RemoveResponseCallback_Call) RunAndReturn(run func(api.ResponseCallbackHandle)) *NodeManagementInterface_RemoveResponseCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RequestRemoteData provides a mock function with given fields: function, selector, elements, destination
 func (_m *NodeManagementInterface) RequestRemoteData(function model.FunctionType, selector interface{}, elements interface{}, destination api.FeatureRemoteInterface) (*model.MsgCounterType, *model.ErrorType) {
 	ret := _m.Called(function, selector, elements, destination)
@@ -1489,6 +1681,62 @@ func (_c *NodeManagementInterface_SubscribeToRemote_Call) RunAndReturn(run func(
 	return _c
 }
 
+// SubscriptionToRemoteState provides a mock function with given fields: remoteAddress
+func (_m *NodeManagementInterface) SubscriptionToRemoteState(remoteAddress *model.FeatureAddressType) (api.OutboundStateType, bool) {
+	ret := _m.Called(remoteAddress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscriptionToRemoteState")
+	}
+
+	var r0 api.OutboundStateType
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType) (api.OutboundStateType, bool)); ok {
+		return rf(remoteAddress)
+	}
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType) api.OutboundStateType); ok {
+		r0 = rf(remoteAddress)
+	} else {
+		r0 = ret.Get(0).(api.OutboundStateType)
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType) bool); ok {
+		r1 = rf(remoteAddress)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// NodeManagementInterface_SubscriptionToRemoteState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscriptionToRemoteState'
+type NodeManagementInterface_SubscriptionToRemoteState_Call struct {
+	*mock.Call
+}
+
+// SubscriptionToRemoteState is a helper method to define mock.On call
+//   - remoteAddress *model.FeatureAddressType
+func (_e *NodeManagementInterface_Expecter) SubscriptionToRemoteState(remoteAddress interface{}) *NodeManagementInterface_SubscriptionToRemoteState_Call {
+	return &NodeManagementInterface_SubscriptionToRemoteState_Call{Call: _e.mock.On("SubscriptionToRemoteState", remoteAddress)}
+}
+
+func (_c *NodeManagementInterface_SubscriptionToRemoteState_Call) Run(run func(remoteAddress *model.FeatureAddressType)) *NodeManagementInterface_SubscriptionToRemoteState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*model.FeatureAddressType))
+	})
+	return _c
+}
+
+func (_c *NodeManagementInterface_SubscriptionToRemoteState_Call) Return(_a0 api.OutboundStateType, _a1 bool) *NodeManagementInterface_SubscriptionToRemoteState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *NodeManagementInterface_SubscriptionToRemoteState_Call) RunAndReturn(run func(*model.FeatureAddressType) (api.OutboundStateType, bool)) *NodeManagementInterface_SubscriptionToRemoteState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Type provides a mock function with given fields:
 func (_m *NodeManagementInterface) Type() model.FeatureTypeType {
 	ret := _m.Called()
@@ -136,9 +136,62 @@ func (_c *SenderInterface_DatagramForMsgCounter_Call) RunAndReturn(run func(mode
 	return _c
 }
 
+// Forward provides a mock function with given fields: datagram
+func (_m *SenderInterface) Forward(datagram model.DatagramType) error {
+	ret := _m.Called(datagram)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Forward")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(model.DatagramType) error); ok {
+		r0 = rf(datagram)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SenderInterface_Forward_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Forward'
+type SenderInterface_Forward_Call struct {
+	*mock.Call
+}
+
+// Forward is a helper method to define mock.On call
+//   - datagram model.DatagramType
+func (_e *SenderInterface_Expecter) Forward(datagram interface{}) *SenderInterface_Forward_Call {
+	return &SenderInterface_Forward_Call{Call: _e.mock.On("Forward", datagram)}
+}
+
+func (_c *SenderInterface_Forward_Call) Run(run func(datagram model.DatagramType)) *SenderInterface_Forward_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(model.DatagramType))
+	})
+	return _c
+}
+
+func (_c *SenderInterface_Forward_Call) Return(_a0 error) *SenderInterface_Forward_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SenderInterface_Forward_Call) RunAndReturn(run func(model.DatagramType) error) *SenderInterface_Forward_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Notify provides a mock function with given fields: senderAddress, destinationAddress, cmd
-func (_m *SenderInterface) Notify(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error) {
-	ret := _m.Called(senderAddress, destinationAddress, cmd)
+func (_m *SenderInterface) Notify(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error) {
+	_va := make([]interface{}, len(cmd))
+	for _i := range cmd {
+		_va[_i] = cmd[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, senderAddress, destinationAddress)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Notify")
@@ -146,19 +199,19 @@ func (_m *SenderInterface) Notify(senderAddress *model.FeatureAddressType, desti
 
 	var r0 *model.MsgCounterType
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) (*model.MsgCounterType, error)); ok {
-		return rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) (*model.MsgCounterType, error)); ok {
+		return rf(senderAddress, destinationAddress, cmd...)
 	}
-	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) *model.MsgCounterType); ok {
-		r0 = rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) *model.MsgCounterType); ok {
+		r0 = rf(senderAddress, destinationAddress, cmd...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MsgCounterType)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) error); ok {
-		r1 = rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) error); ok {
+		r1 = rf(senderAddress, destinationAddress, cmd...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -174,14 +227,21 @@ type SenderInterface_Notify_Call struct {
 // Notify is a helper method to define mock.On call
 //   - senderAddress *model.FeatureAddressType
 //   - destinationAddress *model.FeatureAddressType
-//   - cmd model.CmdType
-func (_e *SenderInterface_Expecter) Notify(senderAddress interface{}, destinationAddress interface{}, cmd interface{}) *SenderInterface_Notify_Call {
-	return &SenderInterface_Notify_Call{Call: _e.mock.On("Notify", senderAddress, destinationAddress, cmd)}
+//   - cmd ...model.CmdType
+func (_e *SenderInterface_Expecter) Notify(senderAddress interface{}, destinationAddress interface{}, cmd ...interface{}) *SenderInterface_Notify_Call {
+	return &SenderInterface_Notify_Call{Call: _e.mock.On("Notify",
+		append([]interface{}{senderAddress, destinationAddress}, cmd...)...)}
 }
 
-func (_c *SenderInterface_Notify_Call) Run(run func(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd model.CmdType)) *SenderInterface_Notify_Call {
+func (_c *SenderInterface_Notify_Call) Run(run func(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType)) *SenderInterface_Notify_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*model.FeatureAddressType), args[1].(*model.FeatureAddressType), args[2].(model.CmdType))
+		variadicArgs := make([]model.CmdType, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(model.CmdType)
+			}
+		}
+		run(args[0].(*model.FeatureAddressType), args[1].(*model.FeatureAddressType), variadicArgs...)
 	})
 	return _c
 }
@@ -191,7 +251,7 @@ func (_c *SenderInterface_Notify_Call) Return(_a0 *model.MsgCounterType, _a1 err
 	return _c
 }
 
-func (_c *SenderInterface_Notify_Call) RunAndReturn(run func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) (*model.MsgCounterType, error)) *SenderInterface_Notify_Call {
+func (_c *SenderInterface_Notify_Call) RunAndReturn(run func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) (*model.MsgCounterType, error)) *SenderInterface_Notify_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -613,8 +673,15 @@ func (_c *SenderInterface_Unsubscribe_Call) RunAndReturn(run func(*model.Feature
 }
 
 // Write provides a mock function with given fields: senderAddress, destinationAddress, cmd
-func (_m *SenderInterface) Write(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error) {
-	ret := _m.Called(senderAddress, destinationAddress, cmd)
+func (_m *SenderInterface) Write(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error) {
+	_va := make([]interface{}, len(cmd))
+	for _i := range cmd {
+		_va[_i] = cmd[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, senderAddress, destinationAddress)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Write")
@@ -622,19 +689,19 @@ func (_m *SenderInterface) Write(senderAddress *model.FeatureAddressType, destin
 
 	var r0 *model.MsgCounterType
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) (*model.MsgCounterType, error)); ok {
-		return rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) (*model.MsgCounterType, error)); ok {
+		return rf(senderAddress, destinationAddress, cmd...)
 	}
-	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) *model.MsgCounterType); ok {
-		r0 = rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(0).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) *model.MsgCounterType); ok {
+		r0 = rf(senderAddress, destinationAddress, cmd...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.MsgCounterType)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) error); ok {
-		r1 = rf(senderAddress, destinationAddress, cmd)
+	if rf, ok := ret.Get(1).(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) error); ok {
+		r1 = rf(senderAddress, destinationAddress, cmd...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -650,14 +717,21 @@ type SenderInterface_Write_Call struct {
 // Write is a helper method to define mock.On call
 //   - senderAddress *model.FeatureAddressType
 //   - destinationAddress *model.FeatureAddressType
-//   - cmd model.CmdType
-func (_e *SenderInterface_Expecter) Write(senderAddress interface{}, destinationAddress interface{}, cmd interface{}) *SenderInterface_Write_Call {
-	return &SenderInterface_Write_Call{Call: _e.mock.On("Write", senderAddress, destinationAddress, cmd)}
+//   - cmd ...model.CmdType
+func (_e *SenderInterface_Expecter) Write(senderAddress interface{}, destinationAddress interface{}, cmd ...interface{}) *SenderInterface_Write_Call {
+	return &SenderInterface_Write_Call{Call: _e.mock.On("Write",
+		append([]interface{}{senderAddress, destinationAddress}, cmd...)...)}
 }
 
-func (_c *SenderInterface_Write_Call) Run(run func(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd model.CmdType)) *SenderInterface_Write_Call {
+func (_c *SenderInterface_Write_Call) Run(run func(senderAddress *model.FeatureAddressType, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType)) *SenderInterface_Write_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*model.FeatureAddressType), args[1].(*model.FeatureAddressType), args[2].(model.CmdType))
+		variadicArgs := make([]model.CmdType, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(model.CmdType)
+			}
+		}
+		run(args[0].(*model.FeatureAddressType), args[1].(*model.FeatureAddressType), variadicArgs...)
 	})
 	return _c
 }
@@ -667,7 +741,7 @@ func (_c *SenderInterface_Write_Call) Return(_a0 *model.MsgCounterType, _a1 erro
 	return _c
 }
 
-func (_c *SenderInterface_Write_Call) RunAndReturn(run func(*model.FeatureAddressType, *model.FeatureAddressType, model.CmdType) (*model.MsgCounterType, error)) *SenderInterface_Write_Call {
+func (_c *SenderInterface_Write_Call) RunAndReturn(run func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) (*model.MsgCounterType, error)) *SenderInterface_Write_Call {
 	_c.Call.Return(run)
 	return _c
 }
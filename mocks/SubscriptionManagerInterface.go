@@ -22,6 +22,39 @@ func (_m *SubscriptionManagerInterface) EXPECT() *SubscriptionManagerInterface_E
 	return &SubscriptionManagerInterface_Expecter{mock: &_m.Mock}
 }
 
+// AddAuthorizationCallback provides a mock function with given fields: callback
+func (_m *SubscriptionManagerInterface) AddAuthorizationCallback(callback api.AuthorizationCallbackFunc) {
+	_m.Called(callback)
+}
+
+// SubscriptionManagerInterface_AddAuthorizationCallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuthorizationCallback'
+type SubscriptionManagerInterface_AddAuthorizationCallback_Call struct {
+	*mock.Call
+}
+
+// AddAuthorizationCallback is a helper method to define mock.On call
+//   - callback api.AuthorizationCallbackFunc
+func (_e *SubscriptionManagerInterface_Expecter) AddAuthorizationCallback(callback interface{}) *SubscriptionManagerInterface_AddAuthorizationCallback_Call {
+	return &SubscriptionManagerInterface_AddAuthorizationCallback_Call{Call: _e.mock.On("AddAuthorizationCallback", callback)}
+}
+
+func (_c *SubscriptionManagerInterface_AddAuthorizationCallback_Call) Run(run func(callback api.AuthorizationCallbackFunc)) *SubscriptionManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(api.AuthorizationCallbackFunc))
+	})
+	return _c
+}
+
+func (_c *SubscriptionManagerInterface_AddAuthorizationCallback_Call) Return() *SubscriptionManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *SubscriptionManagerInterface_AddAuthorizationCallback_Call) RunAndReturn(run func(api.AuthorizationCallbackFunc)) *SubscriptionManagerInterface_AddAuthorizationCallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AddSubscription provides a mock function with given fields: remoteDevice, data
 func (_m *SubscriptionManagerInterface) AddSubscription(remoteDevice api.DeviceRemoteInterface, data model.SubscriptionManagementRequestCallType) error {
 	ret := _m.Called(remoteDevice, data)
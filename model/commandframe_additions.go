@@ -50,6 +50,10 @@ func (f *FilterData) SelectorMatch(item any) bool {
 			continue
 		}
 
+		if itemF.Kind() != reflect.Ptr || itemF.IsNil() {
+			return false
+		}
+
 		itemValue := itemF.Elem().Interface()
 		if itemValue != value {
 			return false
@@ -59,6 +63,95 @@ func (f *FilterData) SelectorMatch(item any) bool {
 	return true
 }
 
+// Returns a copy of the provided function data only containing
+// the list items matching the selector and, if elements are provided,
+// only the requested fields of these items. Identifier fields are always kept.
+//
+// The provided data has to be a pointer to a function data type, e.g. *MeasurementListDataType,
+// and is not modified.
+//
+// See EEBus_SPINE_TS_ProtocolSpecification.pdf, chapter "5.3.4 Restricted function exchange with cmdOptions"
+func (f *FilterData) FilteredData(data any) any {
+	if data == nil || (f.Selector == nil && f.Elements == nil) {
+		return data
+	}
+
+	dataV := reflect.ValueOf(data)
+	if dataV.Kind() != reflect.Ptr || dataV.IsNil() || dataV.Elem().Kind() != reflect.Struct {
+		return data
+	}
+
+	result := reflect.New(dataV.Elem().Type())
+	result.Elem().Set(dataV.Elem())
+
+	// non list data types have no selectors, so only the elements are applied
+	if _, isList := data.(Updater); !isList {
+		if f.Elements != nil {
+			result.Elem().Set(f.elementsOfItem(result.Elem()))
+		}
+		return result.Interface()
+	}
+
+	for i := 0; i < result.Elem().NumField(); i++ {
+		listF := result.Elem().Field(i)
+		if listF.Kind() != reflect.Slice || listF.Type().Elem().Kind() != reflect.Struct {
+			continue
+		}
+
+		filtered := reflect.MakeSlice(listF.Type(), 0, listF.Len())
+		for j := 0; j < listF.Len(); j++ {
+			item := listF.Index(j)
+
+			if f.Selector != nil {
+				itemPtr := reflect.New(item.Type())
+				itemPtr.Elem().Set(item)
+				if !f.SelectorMatch(itemPtr.Interface()) {
+					continue
+				}
+			}
+
+			if f.Elements != nil {
+				item = f.elementsOfItem(item)
+			}
+
+			filtered = reflect.Append(filtered, item)
+		}
+
+		listF.Set(filtered)
+	}
+
+	return result.Interface()
+}
+
+// return a copy of the item only containing the fields defined in the elements
+// and the identifier fields
+func (f *FilterData) elementsOfItem(item reflect.Value) reflect.Value {
+	elementsV := reflect.ValueOf(f.Elements)
+	if elementsV.Kind() != reflect.Ptr || elementsV.IsNil() {
+		return item
+	}
+	elementsV = elementsV.Elem()
+
+	keys := fieldNamesWithEEBusTag(EEBusTagKey, item.Interface())
+
+	result := reflect.New(item.Type()).Elem()
+	for i := 0; i < item.NumField(); i++ {
+		fieldName := item.Type().Field(i).Name
+
+		keep := isStringValueInSlice(fieldName, keys)
+		if !keep {
+			elementF := elementsV.FieldByName(fieldName)
+			keep = elementF.IsValid() && elementF.Kind() == reflect.Ptr && !elementF.IsNil()
+		}
+
+		if keep && result.Field(i).CanSet() {
+			result.Field(i).Set(item.Field(i))
+		}
+	}
+
+	return result
+}
+
 // Get the field for a given functionType
 func (f *FilterType) SetDataForFunction(tagType EEBusTagTypeType, fct FunctionType, data any) {
 	if data == nil || reflect.ValueOf(data).Kind() != reflect.Ptr {
@@ -116,6 +209,43 @@ func (f *FilterType) SetDataForFunction(tagType EEBusTagTypeType, fct FunctionTy
 	}
 }
 
+// Create the selector of a function which selects a list item by its fields tagged with eebus:"key"
+//
+// returns nil if the function has no selector, or if the selector can not contain all identifiers of the item
+func SelectorForItem(fct FunctionType, item any) any {
+	var selectorType reflect.Type
+
+	ft := reflect.TypeOf(FilterType{})
+	for i := 0; i < ft.NumField(); i++ {
+		eebusTags := EEBusTags(ft.Field(i))
+		if eebusTags[EEBusTagFunction] == string(fct) && eebusTags[EEBusTagType] == string(EEBusTagTypeTypeSelector) {
+			selectorType = ft.Field(i).Type.Elem()
+			break
+		}
+	}
+
+	keys := fieldNamesWithEEBusTag(EEBusTagKey, item)
+	if selectorType == nil || len(keys) == 0 {
+		return nil
+	}
+
+	selector := reflect.New(selectorType)
+	itemV := reflect.ValueOf(item)
+	for _, fieldName := range keys {
+		value := itemV.FieldByName(fieldName)
+		field := selector.Elem().FieldByName(fieldName)
+		if value.IsNil() || !field.IsValid() || field.Type() != value.Type() {
+			return nil
+		}
+
+		copiedValue := reflect.New(value.Elem().Type())
+		copiedValue.Elem().Set(value.Elem())
+		field.Set(copiedValue)
+	}
+
+	return selector.Interface()
+}
+
 // Get the data and some meta data for the current value
 func (f *FilterType) Data() (*FilterData, error) {
 	var elements any = nil
@@ -282,6 +412,9 @@ func (cmd *CmdType) DataName() string {
 func (cmd *CmdType) ExtractFilter() (filterPartial *FilterType, filterDelete *FilterType) {
 	if cmd != nil && cmd.Filter != nil && len(cmd.Filter) > 0 {
 		for i := range cmd.Filter {
+			if cmd.Filter[i].CmdControl == nil {
+				continue
+			}
 			if cmd.Filter[i].CmdControl.Partial != nil {
 				filterPartial = &cmd.Filter[i]
 			} else if cmd.Filter[i].CmdControl.Delete != nil {
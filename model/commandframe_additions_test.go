@@ -74,6 +74,87 @@ func TestFilterType_Elements_SetDataForFunction(t *testing.T) {
 	assert.NotNil(t, cmd.ElectricalConnectionDescriptionDataElements)
 }
 
+func TestFilterData_SelectorMatch(t *testing.T) {
+	sut := &FilterData{
+		Selector: &MeasurementListDataSelectorsType{
+			MeasurementId: util.Ptr(MeasurementIdType(1)),
+		},
+	}
+
+	assert.True(t, sut.SelectorMatch(&MeasurementDataType{MeasurementId: util.Ptr(MeasurementIdType(1))}))
+	assert.False(t, sut.SelectorMatch(&MeasurementDataType{MeasurementId: util.Ptr(MeasurementIdType(2))}))
+	assert.False(t, sut.SelectorMatch(&MeasurementDataType{}))
+
+	sut = &FilterData{}
+	assert.False(t, sut.SelectorMatch(&MeasurementDataType{}))
+}
+
+func TestFilterData_FilteredData(t *testing.T) {
+	data := &MeasurementListDataType{
+		MeasurementData: []MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(MeasurementIdType(0)),
+				ValueType:     util.Ptr(MeasurementValueTypeTypeValue),
+				Value:         NewScaledNumberType(10),
+				ValueSource:   util.Ptr(MeasurementValueSourceTypeMeasuredValue),
+			},
+			{
+				MeasurementId: util.Ptr(MeasurementIdType(1)),
+				ValueType:     util.Ptr(MeasurementValueTypeTypeValue),
+				Value:         NewScaledNumberType(20),
+				ValueSource:   util.Ptr(MeasurementValueSourceTypeMeasuredValue),
+			},
+		},
+	}
+
+	sut := &FilterData{}
+	assert.Equal(t, data, sut.FilteredData(data))
+	assert.Nil(t, sut.FilteredData(nil))
+
+	sut = &FilterData{
+		Selector: &MeasurementListDataSelectorsType{
+			MeasurementId: util.Ptr(MeasurementIdType(1)),
+		},
+	}
+	result, ok := sut.FilteredData(data).(*MeasurementListDataType)
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(result.MeasurementData))
+	assert.Equal(t, MeasurementIdType(1), *result.MeasurementData[0].MeasurementId)
+	assert.NotNil(t, result.MeasurementData[0].ValueSource)
+	assert.Equal(t, 2, len(data.MeasurementData))
+
+	sut = &FilterData{
+		Elements: &MeasurementDataElementsType{
+			Value: util.Ptr(ElementTagType{}),
+		},
+	}
+	result, ok = sut.FilteredData(data).(*MeasurementListDataType)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(result.MeasurementData))
+	for _, item := range result.MeasurementData {
+		assert.NotNil(t, item.MeasurementId)
+		assert.NotNil(t, item.ValueType)
+		assert.NotNil(t, item.Value)
+		assert.Nil(t, item.ValueSource)
+	}
+	assert.NotNil(t, data.MeasurementData[0].ValueSource)
+
+	manufacturer := &DeviceClassificationManufacturerDataType{
+		BrandName:  util.Ptr(DeviceClassificationStringType("brand")),
+		DeviceName: util.Ptr(DeviceClassificationStringType("device")),
+	}
+	sut = &FilterData{
+		Elements: &DeviceClassificationManufacturerDataElementsType{
+			BrandName: util.Ptr(ElementTagType{}),
+		},
+	}
+	manufacturerResult, ok := sut.FilteredData(manufacturer).(*DeviceClassificationManufacturerDataType)
+	assert.True(t, ok)
+	assert.NotNil(t, manufacturerResult.BrandName)
+	assert.Nil(t, manufacturerResult.DeviceName)
+	assert.NotNil(t, manufacturer.DeviceName)
+}
+
 func TestCmdType_Data(t *testing.T) {
 	data := &NodeManagementDetailedDiscoveryDataType{
 		SpecificationVersionList: &NodeManagementSpecificationVersionListType{[]SpecificationVersionDataType{SpecificationVersionDataType("dummy")}},
@@ -140,3 +221,35 @@ func TestCmdType_ExtractFilter_FilterPartialDelete(t *testing.T) {
 	assert.NotNil(t, filterDelete)
 	assert.Equal(t, &filterD, filterDelete)
 }
+
+func TestCmdType_ExtractFilter_NoCmdControl(t *testing.T) {
+	sut := &CmdType{
+		Filter: []FilterType{
+			{NodeManagementDetailedDiscoveryDataSelectors: &NodeManagementDetailedDiscoveryDataSelectorsType{}},
+		},
+		NodeManagementDetailedDiscoveryData: &NodeManagementDetailedDiscoveryDataType{},
+	}
+
+	// Act
+	filterPartial, filterDelete := sut.ExtractFilter()
+	assert.Nil(t, filterPartial)
+	assert.Nil(t, filterDelete)
+}
+
+func TestSelectorForItem(t *testing.T) {
+	item := ElectricalConnectionDescriptionDataType{
+		ElectricalConnectionId: util.Ptr(ElectricalConnectionIdType(1)),
+		PowerSupplyType:        util.Ptr(ElectricalConnectionVoltageTypeTypeAc),
+	}
+
+	// Act
+	selector := SelectorForItem(FunctionTypeElectricalConnectionDescriptionListData, item)
+	expected := &ElectricalConnectionDescriptionListDataSelectorsType{
+		ElectricalConnectionId: util.Ptr(ElectricalConnectionIdType(1)),
+	}
+	assert.Equal(t, expected, selector)
+
+	// Act
+	selector = SelectorForItem(FunctionTypeDeviceClassificationManufacturerData, item)
+	assert.Nil(t, selector)
+}
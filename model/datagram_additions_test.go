@@ -129,3 +129,22 @@ func TestPrintMessageOverview_Write_Recv(t *testing.T) {
 	result := datagram.PrintMessageOverview(true, "", "")
 	assert.NotEqual(t, emptyResult, result)
 }
+
+func TestPrintMessageOverview_MissingFields(t *testing.T) {
+	for _, cmdClassifier := range []CmdClassifierType{CmdClassifierTypeReply, CmdClassifierTypeResult} {
+		datagram := &DatagramType{
+			Header: HeaderType{
+				MsgCounter:    util.Ptr(MsgCounterType(1)),
+				CmdClassifier: util.Ptr(cmdClassifier),
+			},
+			Payload: PayloadType{
+				Cmd: []CmdType{
+					{},
+				},
+			},
+		}
+
+		result := datagram.PrintMessageOverview(false, "", "")
+		assert.NotEqual(t, emptyResult, result)
+	}
+}
@@ -0,0 +1,117 @@
+package model
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// add the cmds of all datagrams in the spine testdata as seeds
+func addCmdSeeds(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("..", "spine", "testdata", "*.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		var datagram Datagram
+		if err := json.Unmarshal(data, &datagram); err != nil {
+			continue
+		}
+
+		for _, cmd := range datagram.Datagram.Payload.Cmd {
+			if seed, err := json.Marshal(cmd); err == nil {
+				f.Add(seed)
+			}
+		}
+	}
+}
+
+func FuzzCmdType(f *testing.F) {
+	addCmdSeeds(f)
+	f.Add([]byte(`{"filter":[{}],"measurementListData":{}}`))
+	f.Add([]byte(`{"function":"measurementListData","filter":[{"cmdControl":{"partial":{}},"measurementListDataSelectors":{"measurementId":1}}],"measurementListData":{"measurementData":[]}}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var cmd CmdType
+		if err := json.Unmarshal(data, &cmd); err != nil {
+			return
+		}
+
+		cmdData, err := cmd.Data()
+		if err == nil {
+			if cmdData.Value == nil || len(cmdData.FieldName) == 0 {
+				t.Errorf("incomplete cmd data: %v", cmdData)
+			}
+			if cmd.DataName() != cmdData.FieldName {
+				t.Errorf("data name %s does not match field name %s", cmd.DataName(), cmdData.FieldName)
+			}
+		}
+
+		filterPartial, filterDelete := cmd.ExtractFilter()
+		if filterPartial != nil {
+			if filterPartial.CmdControl == nil || filterPartial.CmdControl.Partial == nil {
+				t.Errorf("partial filter without partial cmdControl: %v", filterPartial)
+			}
+			_, _ = filterPartial.Data()
+		}
+		if filterDelete != nil {
+			if filterDelete.CmdControl == nil || filterDelete.CmdControl.Delete == nil {
+				t.Errorf("delete filter without delete cmdControl: %v", filterDelete)
+			}
+			_, _ = filterDelete.Data()
+		}
+	})
+}
+
+func FuzzUpdateList(f *testing.F) {
+	existing := []byte(`[{"measurementId":1,"valueType":"value","value":{"number":10}},{"measurementId":2,"valueType":"value"}]`)
+	newData := []byte(`[{"measurementId":1,"valueType":"value","value":{"number":11}},{"measurementId":3,"valueType":"value"}]`)
+	noFilter := []byte(`null`)
+
+	f.Add(existing, newData, noFilter, noFilter, false)
+	f.Add(existing, []byte(`[{"value":{"number":1}}]`), noFilter, noFilter, true)
+	f.Add(existing, newData,
+		[]byte(`{"cmdControl":{"partial":{}},"measurementListDataSelectors":{"measurementId":1}}`),
+		noFilter, false)
+	f.Add(existing, []byte(`[]`),
+		[]byte(`{"cmdControl":{"partial":{}},"measurementListDataSelectors":{"measurementId":1}}`),
+		[]byte(`{"cmdControl":{"delete":{}},"measurementListDataSelectors":{"measurementId":2},"measurementDataElements":{"value":{}}}`),
+		true)
+
+	f.Fuzz(func(t *testing.T, existingJson, newJson, partialJson, deleteJson []byte, remoteWrite bool) {
+		var existingData, newData []MeasurementDataType
+		if json.Unmarshal(existingJson, &existingData) != nil || json.Unmarshal(newJson, &newData) != nil {
+			return
+		}
+
+		var filterPartial, filterDelete *FilterType
+		if json.Unmarshal(partialJson, &filterPartial) != nil || json.Unmarshal(deleteJson, &filterDelete) != nil {
+			return
+		}
+
+		result, _ := UpdateList(remoteWrite, existingData, newData, filterPartial, filterDelete)
+
+		// a merge of lists with unique keys may not result in duplicate keys
+		if filterPartial != nil {
+			if _, err := filterPartial.Data(); err == nil {
+				return
+			}
+		}
+		if len(newData) == 0 || !HasIdentifiers(newData[0]) ||
+			!hasUniqueIdentifiers(reflect.ValueOf(existingData)) ||
+			!hasUniqueIdentifiers(reflect.ValueOf(newData)) {
+			return
+		}
+		if !hasUniqueIdentifiers(reflect.ValueOf(result)) {
+			t.Errorf("duplicate keys after merge: %v", result)
+		}
+	})
+}
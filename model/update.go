@@ -50,6 +50,11 @@ func UpdateList[T any](remoteWrite bool, existingData []T, newData []T, filterPa
 	// process update filter (with selectors and elements)
 	if filterPartial != nil {
 		if filterData, err := filterPartial.Data(); err == nil {
+			// without data there is nothing to copy to the selected items
+			if len(newData) == 0 {
+				return existingData, success
+			}
+
 			newData, noErrors := copyToSelectedData(remoteWrite, existingData, filterData, &newData[0])
 			if !noErrors {
 				success = false
@@ -81,6 +86,111 @@ func UpdateList[T any](remoteWrite bool, existingData []T, newData []T, filterPa
 	return result, success
 }
 
+// Compare two lists by the fields tagged with eebus:"key"
+//
+// existingList and newList have to be slices of the same item type
+//
+// returns:
+//   - the items of newList which were added or changed, as a slice of the item type
+//   - the items of existingList which are not part of newList anymore, as a slice of the item type
+//   - true if the changes can be described by partial updates, false if not, e.g. because
+//     items have incomplete identifiers or a field of a changed item was removed
+func DiffList(existingList, newList any) (changed any, removed any, success bool) {
+	existingV := reflect.ValueOf(existingList)
+	newV := reflect.ValueOf(newList)
+	if existingV.Kind() != reflect.Slice || newV.Type() != existingV.Type() {
+		return nil, nil, false
+	}
+
+	changedV := reflect.MakeSlice(newV.Type(), 0, 0)
+	removedV := reflect.MakeSlice(existingV.Type(), 0, 0)
+
+	if !hasUniqueIdentifiers(existingV) || !hasUniqueIdentifiers(newV) {
+		return nil, nil, false
+	}
+
+	for i := 0; i < newV.Len(); i++ {
+		newItem := newV.Index(i)
+
+		existingItem, found := itemWithSameKeys(existingV, newItem)
+		if !found {
+			changedV = reflect.Append(changedV, newItem)
+			continue
+		}
+
+		if reflect.DeepEqual(existingItem.Interface(), newItem.Interface()) {
+			continue
+		}
+
+		// a partial update can not remove fields of an existing item
+		for j := 0; j < newItem.NumField(); j++ {
+			if !isFieldValueNil(existingItem.Field(j).Interface()) && isFieldValueNil(newItem.Field(j).Interface()) {
+				return nil, nil, false
+			}
+		}
+
+		changedV = reflect.Append(changedV, newItem)
+	}
+
+	for i := 0; i < existingV.Len(); i++ {
+		existingItem := existingV.Index(i)
+
+		if _, found := itemWithSameKeys(newV, existingItem); !found {
+			removedV = reflect.Append(removedV, existingItem)
+		}
+	}
+
+	return changedV.Interface(), removedV.Interface(), true
+}
+
+// check if all items of a list have all identifiers set and no identifiers are used twice
+func hasUniqueIdentifiers(list reflect.Value) bool {
+	if list.Len() == 0 {
+		return true
+	}
+
+	if len(fieldNamesWithEEBusTag(EEBusTagKey, list.Index(0).Interface())) == 0 {
+		return false
+	}
+
+	for i := 0; i < list.Len(); i++ {
+		item := list.Index(i)
+		if !HasIdentifiers(item.Interface()) {
+			return false
+		}
+
+		for j := i + 1; j < list.Len(); j++ {
+			if sameKeys(item, list.Index(j)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// return the item of a list with the same identifiers as the given item
+func itemWithSameKeys(list reflect.Value, item reflect.Value) (reflect.Value, bool) {
+	for i := 0; i < list.Len(); i++ {
+		if sameKeys(list.Index(i), item) {
+			return list.Index(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// check if two items have the same values in all fields tagged with eebus:"key"
+func sameKeys(item1, item2 reflect.Value) bool {
+	for _, fieldName := range fieldNamesWithEEBusTag(EEBusTagKey, item1.Interface()) {
+		if !reflect.DeepEqual(item1.FieldByName(fieldName).Interface(), item2.FieldByName(fieldName).Interface()) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // return a list of field names that have the eebus tag
 func fieldNamesWithEEBusTag(tag EEBusTag, item any) []string {
 	var result []string
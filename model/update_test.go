@@ -221,3 +221,46 @@ func TestUpdateList_DeleteSelector(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 */
+
+func TestDiffList(t *testing.T) {
+	existingData := []TestUpdateData{{Id: util.Ptr(uint(1)), DataItem: util.Ptr(int(1))}, {Id: util.Ptr(uint(2)), DataItem: util.Ptr(int(2))}}
+
+	// Act
+	changed, removed, boolV := DiffList(existingData, existingData)
+
+	assert.True(t, boolV)
+	assert.Equal(t, 0, len(changed.([]TestUpdateData)))
+	assert.Equal(t, 0, len(removed.([]TestUpdateData)))
+
+	newData := []TestUpdateData{{Id: util.Ptr(uint(1)), DataItem: util.Ptr(int(3))}, {Id: util.Ptr(uint(3)), DataItem: util.Ptr(int(3))}}
+
+	// Act
+	changed, removed, boolV = DiffList(existingData, newData)
+
+	assert.True(t, boolV)
+	assert.Equal(t, newData, changed)
+	assert.Equal(t, []TestUpdateData{{Id: util.Ptr(uint(2)), DataItem: util.Ptr(int(2))}}, removed)
+
+	// a removed field can not be sent in a partial notify
+	newData = []TestUpdateData{{Id: util.Ptr(uint(1))}, {Id: util.Ptr(uint(2)), DataItem: util.Ptr(int(2))}}
+
+	// Act
+	_, _, boolV = DiffList(existingData, newData)
+
+	assert.False(t, boolV)
+
+	// items need to have unique identifiers
+	newData = []TestUpdateData{{DataItem: util.Ptr(int(1))}}
+
+	// Act
+	_, _, boolV = DiffList(existingData, newData)
+
+	assert.False(t, boolV)
+
+	newData = []TestUpdateData{{Id: util.Ptr(uint(1)), DataItem: util.Ptr(int(1))}, {Id: util.Ptr(uint(1)), DataItem: util.Ptr(int(2))}}
+
+	// Act
+	_, _, boolV = DiffList(existingData, newData)
+
+	assert.False(t, boolV)
+}
@@ -0,0 +1,125 @@
+package spine
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// A rule of an AccessControlList
+//
+// A rule matches a request if all set fields match, empty fields match every request
+type AccessRule struct {
+	Ski              string                    // SKI of the remote device
+	RemoteEntityType *model.EntityTypeType     // type of the remote entity
+	UseCase          *model.UseCaseNameType    // use case the remote entity has to support
+	LocalFeature     *model.FeatureAddressType // address of the local feature, does not match forwarded datagrams
+	Function         *model.FunctionType       // function of the local feature
+	Operations       []api.AccessOperationType // operations the rule applies to
+
+	Allow bool // allow or deny matching requests
+}
+
+// Access control using a list of rules
+//
+// The first rule matching a request decides, if no rule matches the default is used
+type AccessControlList struct {
+	rules        []AccessRule
+	defaultAllow bool
+
+	mux sync.Mutex
+}
+
+var _ api.AccessControlInterface = (*AccessControlList)(nil)
+
+// Create a new access control list
+//
+// defaultAllow defines if requests not matching any rule are allowed
+func NewAccessControlList(defaultAllow bool) *AccessControlList {
+	return &AccessControlList{
+		defaultAllow: defaultAllow,
+	}
+}
+
+// Add a rule, rules are evaluated in the order they were added
+func (a *AccessControlList) AddRule(rule AccessRule) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	a.rules = append(a.rules, rule)
+}
+
+/* AccessControlInterface */
+
+func (a *AccessControlList) IsAccessAllowed(
+	remoteFeature api.FeatureRemoteInterface,
+	localFeature api.FeatureLocalInterface,
+	function model.FunctionType,
+	operation api.AccessOperationType) bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	for _, rule := range a.rules {
+		if accessRuleMatch(rule, remoteFeature, localFeature, function, operation) {
+			return rule.Allow
+		}
+	}
+
+	return a.defaultAllow
+}
+
+func accessRuleMatch(
+	rule AccessRule,
+	remoteFeature api.FeatureRemoteInterface,
+	localFeature api.FeatureLocalInterface,
+	function model.FunctionType,
+	operation api.AccessOperationType) bool {
+	if len(rule.Operations) > 0 && !slices.Contains(rule.Operations, operation) {
+		return false
+	}
+
+	if len(rule.Ski) > 0 && rule.Ski != remoteFeature.Device().Ski() {
+		return false
+	}
+
+	if rule.RemoteEntityType != nil && *rule.RemoteEntityType != remoteFeature.Entity().EntityType() {
+		return false
+	}
+
+	if rule.UseCase != nil && !remoteEntityHasUseCase(remoteFeature.Entity(), *rule.UseCase) {
+		return false
+	}
+
+	if rule.LocalFeature != nil &&
+		(localFeature == nil ||
+			!reflect.DeepEqual(rule.LocalFeature.Entity, localFeature.Address().Entity) ||
+			!reflect.DeepEqual(rule.LocalFeature.Feature, localFeature.Address().Feature)) {
+		return false
+	}
+
+	if rule.Function != nil && *rule.Function != function {
+		return false
+	}
+
+	return true
+}
+
+// check if the remote entity supports a use case
+func remoteEntityHasUseCase(entity api.EntityRemoteInterface, useCase model.UseCaseNameType) bool {
+	for _, item := range entity.Device().UseCases() {
+		if item.Address == nil || !reflect.DeepEqual(item.Address.Entity, entity.Address().Entity) {
+			continue
+		}
+
+		for _, support := range item.UseCaseSupport {
+			if support.UseCaseName != nil && *support.UseCaseName == useCase {
+				return true
+			}
+		}
+	}
+
+	return false
+}
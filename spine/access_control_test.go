@@ -0,0 +1,112 @@
+package spine
+
+import (
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAccessControlListSuite(t *testing.T) {
+	suite.Run(t, new(AccessControlListSuite))
+}
+
+type AccessControlListSuite struct {
+	suite.Suite
+
+	localFeature  api.FeatureLocalInterface
+	remoteFeature api.FeatureRemoteInterface
+}
+
+func (s *AccessControlListSuite) BeforeTest(suiteName, testName string) {
+	localDevice, localEntity := createLocalDeviceAndEntity(1)
+	_, s.localFeature = createLocalFeatures(localEntity, model.FeatureTypeTypeBill, "")
+
+	remoteDevice := createRemoteDevice(localDevice, "ski", nil)
+	s.remoteFeature, _ = createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeBill, model.FunctionTypeBillListData)
+
+	nodeManagement := remoteDevice.FeatureByEntityTypeAndRole(
+		remoteDevice.Entity(NewAddressEntityType([]uint{DeviceInformationEntityId})), model.FeatureTypeTypeNodeManagement, model.RoleTypeSpecial)
+	_, _ = nodeManagement.UpdateData(true, model.FunctionTypeNodeManagementUseCaseData, &model.NodeManagementUseCaseDataType{
+		UseCaseInformation: []model.UseCaseInformationDataType{
+			{
+				Address: &model.FeatureAddressType{
+					Device: remoteDevice.Address(),
+					Entity: []model.AddressEntityType{1},
+				},
+				Actor: util.Ptr(model.UseCaseActorTypeEVSE),
+				UseCaseSupport: []model.UseCaseSupportType{
+					{UseCaseName: util.Ptr(model.UseCaseNameTypeEVSECommissioningAndConfiguration)},
+				},
+			},
+		},
+	}, nil, nil)
+}
+
+func (s *AccessControlListSuite) Test_Default() {
+	sut := NewAccessControlList(true)
+	assert.True(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+
+	sut = NewAccessControlList(false)
+	assert.False(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+}
+
+func (s *AccessControlListSuite) Test_Rules() {
+	sut := NewAccessControlList(false)
+
+	// the first matching rule decides
+	sut.AddRule(AccessRule{
+		Ski:        "other",
+		Operations: []api.AccessOperationType{api.AccessOperationRead},
+		Allow:      true,
+	})
+	sut.AddRule(AccessRule{
+		Function: util.Ptr(model.FunctionTypeBillListData),
+		Allow:    false,
+	})
+	sut.AddRule(AccessRule{
+		Ski:          "ski",
+		LocalFeature: s.localFeature.Address(),
+		Operations:   []api.AccessOperationType{api.AccessOperationRead, api.AccessOperationSubscribe},
+		Allow:        true,
+	})
+	sut.AddRule(AccessRule{
+		RemoteEntityType: util.Ptr(model.EntityTypeTypeEVSE),
+		UseCase:          util.Ptr(model.UseCaseNameTypeEVSECommissioningAndConfiguration),
+		Operations:       []api.AccessOperationType{api.AccessOperationWrite},
+		Allow:            true,
+	})
+
+	assert.False(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+	assert.True(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillDescriptionListData, api.AccessOperationRead))
+	assert.True(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, "", api.AccessOperationSubscribe))
+	assert.True(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillConstraintsListData, api.AccessOperationWrite))
+
+	// use case is not supported
+	sut = NewAccessControlList(false)
+	sut.AddRule(AccessRule{
+		UseCase: util.Ptr(model.UseCaseNameTypeLimitationOfPowerConsumption),
+		Allow:   true,
+	})
+	assert.False(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+
+	// other local feature
+	sut.AddRule(AccessRule{
+		LocalFeature: &model.FeatureAddressType{
+			Entity:  s.localFeature.Address().Entity,
+			Feature: util.Ptr(model.AddressFeatureType(100)),
+		},
+		Allow: true,
+	})
+	assert.False(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+
+	// other remote entity type
+	sut.AddRule(AccessRule{
+		RemoteEntityType: util.Ptr(model.EntityTypeTypeCEM),
+		Allow:            true,
+	})
+	assert.False(s.T(), sut.IsAccessAllowed(s.remoteFeature, s.localFeature, model.FunctionTypeBillListData, api.AccessOperationRead))
+}
@@ -0,0 +1,48 @@
+package spine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Returned if a subscription or binding request is rejected by an authorization callback
+var ErrRequestRejected = errors.New("request rejected")
+
+// Built-in authorization policy: a local server feature only accepts one binding
+//
+// This is used by the BindingManager by default
+func OneBindingPerServerFeature(ski string, clientFeature api.FeatureRemoteInterface, serverFeature api.FeatureLocalInterface) error {
+	bindings := serverFeature.Device().BindingManager().BindingsOnFeature(*serverFeature.Address())
+	if len(bindings) > 0 {
+		return errors.New("the server feature already has a binding")
+	}
+
+	return nil
+}
+
+// check if all callbacks accept the request
+func authorizeRequest(
+	callbacks []api.AuthorizationCallbackFunc,
+	ski string,
+	clientFeature api.FeatureRemoteInterface,
+	serverFeature api.FeatureLocalInterface) error {
+	for _, callback := range callbacks {
+		if err := callback(ski, clientFeature, serverFeature); err != nil {
+			return fmt.Errorf("%w: %w", ErrRequestRejected, err)
+		}
+	}
+
+	return nil
+}
+
+// return the error result for a failed subscription or binding request
+func errorTypeForRequest(err error) *model.ErrorType {
+	if errors.Is(err, ErrRequestRejected) {
+		return model.NewErrorType(model.ErrorNumberTypeCommandRejected, err.Error())
+	}
+
+	return model.NewErrorType(model.ErrorNumberTypeGeneralError, err.Error())
+}
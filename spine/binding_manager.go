@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -13,14 +14,18 @@ import (
 	"github.com/enbility/spine-go/util"
 )
 
+// returned if the requested binding already exists
+var errBindingExists = errors.New("requested binding is already present")
+
 type BindingManager struct {
 	localDevice api.DeviceLocalInterface
 
 	bindingNum     uint64
 	bindingEntries []*api.BindingEntry
 
+	authorizationCallbacks []api.AuthorizationCallbackFunc
+
 	mux sync.Mutex
-	// TODO: add persistence
 }
 
 func NewBindingManager(localDevice api.DeviceLocalInterface) *BindingManager {
@@ -29,6 +34,9 @@ func NewBindingManager(localDevice api.DeviceLocalInterface) *BindingManager {
 		localDevice: localDevice,
 	}
 
+	// a local feature can only have one remote binding
+	c.AddAuthorizationCallback(OneBindingPerServerFeature)
+
 	return c
 }
 
@@ -45,12 +53,6 @@ func (c *BindingManager) AddBinding(remoteDevice api.DeviceRemoteInterface, data
 		return err
 	}
 
-	// a local feature can only have one remote binding
-	bindings := c.BindingsOnFeature(*serverFeature.Address())
-	if len(bindings) > 0 {
-		return errors.New("the server feature already has a binding")
-	}
-
 	clientFeature := remoteDevice.FeatureByAddress(data.ClientAddress)
 	if clientFeature == nil {
 		return fmt.Errorf("client feature '%s' in remote device '%s' not found", data.ClientAddress, *remoteDevice.Address())
@@ -59,6 +61,10 @@ func (c *BindingManager) AddBinding(remoteDevice api.DeviceRemoteInterface, data
 		return err
 	}
 
+	if err := authorizeRequest(c.authorizationCallbacksList(), remoteDevice.Ski(), clientFeature, serverFeature); err != nil {
+		return err
+	}
+
 	bindingEntry := &api.BindingEntry{
 		Id:            c.bindingId(),
 		ServerFeature: serverFeature,
@@ -68,8 +74,18 @@ func (c *BindingManager) AddBinding(remoteDevice api.DeviceRemoteInterface, data
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
+	for _, item := range c.bindingEntries {
+		if reflect.DeepEqual(item.ServerFeature, serverFeature) && reflect.DeepEqual(item.ClientFeature, clientFeature) {
+			return errBindingExists
+		}
+	}
+
 	c.bindingEntries = append(c.bindingEntries, bindingEntry)
 
+	if storage := c.localDevice.Storage(); storage != nil {
+		logStorageError(storage.SaveBinding(remoteDevice.Ski(), newStorageEntry(serverFeature, clientFeature)))
+	}
+
 	payload := api.EventPayload{
 		Ski:          remoteDevice.Ski(),
 		EventType:    api.EventTypeBindingChange,
@@ -80,7 +96,7 @@ func (c *BindingManager) AddBinding(remoteDevice api.DeviceRemoteInterface, data
 		Feature:      clientFeature,
 		LocalFeature: serverFeature,
 	}
-	Events.Publish(payload)
+	c.localDevice.Events().Publish(payload)
 
 	return nil
 }
@@ -136,6 +152,10 @@ func (c *BindingManager) RemoveBinding(data model.BindingManagementDeleteCallTyp
 
 	c.bindingEntries = newBindingEntries
 
+	if storage := c.localDevice.Storage(); storage != nil {
+		logStorageError(storage.RemoveBinding(remoteDevice.Ski(), newStorageEntry(serverFeature, clientFeature)))
+	}
+
 	payload := api.EventPayload{
 		Ski:          remoteDevice.Ski(),
 		EventType:    api.EventTypeBindingChange,
@@ -146,12 +166,14 @@ func (c *BindingManager) RemoveBinding(data model.BindingManagementDeleteCallTyp
 		Feature:      clientFeature,
 		LocalFeature: serverFeature,
 	}
-	Events.Publish(payload)
+	c.localDevice.Events().Publish(payload)
 
 	return nil
 }
 
 // Remove all existing bindings for a given remote device
+//
+// Persisted bindings are kept, so they can be restored once the device reconnects
 func (c *BindingManager) RemoveBindingsForDevice(remoteDevice api.DeviceRemoteInterface) {
 	if remoteDevice == nil {
 		return
@@ -189,7 +211,7 @@ func (c *BindingManager) RemoveBindingsForEntity(remoteEntity api.EntityRemoteIn
 			Feature:      clientFeature,
 			LocalFeature: serverFeature,
 		}
-		Events.Publish(payload)
+		c.localDevice.Events().Publish(payload)
 	}
 
 	c.bindingEntries = newBindingEntries
@@ -234,6 +256,20 @@ func (c *BindingManager) BindingsOnFeature(featureAddress model.FeatureAddressTy
 	return result
 }
 
+func (c *BindingManager) AddAuthorizationCallback(callback api.AuthorizationCallbackFunc) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.authorizationCallbacks = append(c.authorizationCallbacks, callback)
+}
+
+func (c *BindingManager) authorizationCallbacksList() []api.AuthorizationCallbackFunc {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return slices.Clone(c.authorizationCallbacks)
+}
+
 func (c *BindingManager) bindingId() uint64 {
 	i := atomic.AddUint64(&c.bindingNum, 1)
 	return i
@@ -1,6 +1,7 @@
 package spine
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -186,3 +187,50 @@ func (suite *BindingManagerSuite) Test_Bindings() {
 	subs = bindingMgr.Bindings(suite.remoteDevice)
 	assert.Equal(suite.T(), 0, len(subs))
 }
+
+func (suite *BindingManagerSuite) Test_AuthorizationCallbacks() {
+	entity := NewEntityLocal(suite.localDevice, model.EntityTypeTypeCEM, []model.AddressEntityType{1}, time.Second*4)
+	suite.localDevice.AddEntity(entity)
+
+	localFeature := entity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+
+	remoteEntity := NewEntityRemote(suite.remoteDevice, model.EntityTypeTypeEVSE, []model.AddressEntityType{1})
+	remoteFeature := NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+	remoteEntity.AddFeature(remoteFeature)
+	remoteFeature2 := NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+	remoteEntity.AddFeature(remoteFeature2)
+	suite.remoteDevice.AddEntity(remoteEntity)
+
+	bindingMgr := suite.localDevice.BindingManager()
+
+	bindingRequest := model.BindingManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeLoadControl),
+	}
+
+	var rejectAll bool
+	bindingMgr.AddAuthorizationCallback(func(ski string, clientFeature api.FeatureRemoteInterface, serverFeature api.FeatureLocalInterface) error {
+		assert.Equal(suite.T(), suite.remoteDevice.Ski(), ski)
+		assert.Equal(suite.T(), localFeature, serverFeature)
+		if rejectAll {
+			return errors.New("rejected by application")
+		}
+		return nil
+	})
+
+	rejectAll = true
+	err := bindingMgr.AddBinding(suite.remoteDevice, bindingRequest)
+	assert.ErrorIs(suite.T(), err, ErrRequestRejected)
+	assert.Equal(suite.T(), 0, len(bindingMgr.Bindings(suite.remoteDevice)))
+
+	rejectAll = false
+	err = bindingMgr.AddBinding(suite.remoteDevice, bindingRequest)
+	assert.Nil(suite.T(), err)
+
+	// only one binding per server feature is accepted by default
+	bindingRequest.ClientAddress = remoteFeature2.Address()
+	err = bindingMgr.AddBinding(suite.remoteDevice, bindingRequest)
+	assert.ErrorIs(suite.T(), err, ErrRequestRejected)
+	assert.Equal(suite.T(), 1, len(bindingMgr.Bindings(suite.remoteDevice)))
+}
@@ -0,0 +1,211 @@
+package spine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	shipapi "github.com/enbility/ship-go/api"
+	"github.com/enbility/ship-go/logging"
+	"github.com/enbility/spine-go/api"
+)
+
+// A captured datagram, stored as one line of a JSON Lines capture
+type CaptureEntry struct {
+	Timestamp time.Time                 `json:"timestamp"`
+	Direction api.DatagramDirectionType `json:"direction"`
+	Ski       string                    `json:"ski"`
+	// The SHIP payload containing the datagram
+	Message json.RawMessage `json:"message"`
+}
+
+// Writes every recorded datagram as a CaptureEntry in JSON Lines format
+type DatagramRecorder struct {
+	writer io.Writer
+	closer io.Closer
+
+	mux sync.Mutex
+}
+
+var _ api.DatagramRecorderInterface = (*DatagramRecorder)(nil)
+
+// Create a recorder writing to a writer
+func NewDatagramRecorder(writer io.Writer) *DatagramRecorder {
+	return &DatagramRecorder{
+		writer: writer,
+	}
+}
+
+// Create a recorder appending to a file, the file is created if it does not exist
+func NewFileDatagramRecorder(path string) (*DatagramRecorder, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+
+	return &DatagramRecorder{
+		writer: file,
+		closer: file,
+	}, nil
+}
+
+/* DatagramRecorderInterface */
+
+func (r *DatagramRecorder) RecordDatagram(direction api.DatagramDirectionType, ski string, message []byte) {
+	// messages are stored compacted, so every entry is a single line
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, message); err != nil {
+		logging.Log().Debug("capturing invalid datagram failed:", err)
+		return
+	}
+
+	entry := CaptureEntry{
+		Timestamp: time.Now().UTC(),
+		Direction: direction,
+		Ski:       ski,
+		Message:   compacted.Bytes(),
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		logging.Log().Debug("capturing datagram failed:", err)
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if _, err := r.writer.Write(append(line, '\n')); err != nil {
+		logging.Log().Debug("capturing datagram failed:", err)
+	}
+}
+
+// Close the capture file, if the recorder was created for a file
+func (r *DatagramRecorder) Close() error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.closer == nil {
+		return nil
+	}
+
+	return r.closer.Close()
+}
+
+// Read all entries of a capture in JSON Lines format
+func ReadCapture(reader io.Reader) ([]CaptureEntry, error) {
+	var entries []CaptureEntry
+
+	scanner := bufio.NewScanner(reader)
+	// datagrams with detailed discovery data can be large
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var entry CaptureEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Read all entries of a capture file in JSON Lines format
+func ReadCaptureFile(path string) ([]CaptureEntry, error) {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ReadCapture(file)
+}
+
+// Feeds the incoming datagrams of a capture into a local device
+//
+// The datagrams are received by a synthetic remote device, the datagrams
+// the local device sends in response are collected and can be checked
+type DatagramReplayer struct {
+	localDevice  api.DeviceLocalInterface
+	remoteDevice *DeviceRemote
+
+	sentMessages [][]byte
+
+	mux sync.Mutex
+}
+
+var _ shipapi.ShipConnectionDataWriterInterface = (*DatagramReplayer)(nil)
+
+// Create a replayer with a synthetic remote device using the SKI
+//
+// The local device sends a detailed discovery request to the remote device right away,
+// like it does for every new remote device
+func NewDatagramReplayer(localDevice api.DeviceLocalInterface, ski string) *DatagramReplayer {
+	r := &DatagramReplayer{
+		localDevice: localDevice,
+	}
+
+	r.remoteDevice = localDevice.SetupRemoteDevice(ski, r).(*DeviceRemote)
+
+	return r
+}
+
+/* ShipConnectionDataWriterInterface */
+
+func (r *DatagramReplayer) WriteShipMessageWithPayload(message []byte) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.sentMessages = append(r.sentMessages, message)
+}
+
+// Get the synthetic remote device
+func (r *DatagramReplayer) RemoteDevice() api.DeviceRemoteInterface {
+	return r.remoteDevice
+}
+
+// Get all datagrams the local device sent to the synthetic remote device
+func (r *DatagramReplayer) SentMessages() [][]byte {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return slices.Clone(r.sentMessages)
+}
+
+// Feed the incoming datagrams of the capture entries into the local device, in order
+//
+// Outgoing datagrams are ignored. If ski is not empty, only datagrams of this SKI are replayed.
+// Returns the errors of all datagrams which could not be handled
+func (r *DatagramReplayer) Replay(entries []CaptureEntry, ski string) error {
+	var errs []error
+
+	for _, entry := range entries {
+		if entry.Direction != api.DatagramDirectionIncoming ||
+			(len(ski) > 0 && entry.Ski != ski) {
+			continue
+		}
+
+		if _, err := r.remoteDevice.HandleSpineMesssage(entry.Message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Remove the synthetic remote device from the local device
+func (r *DatagramReplayer) Close() {
+	r.localDevice.RemoveRemoteDeviceConnection(r.remoteDevice.Ski())
+}
@@ -0,0 +1,143 @@
+package spine
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestCaptureSuite(t *testing.T) {
+	suite.Run(t, new(CaptureSuite))
+}
+
+type CaptureSuite struct {
+	suite.Suite
+}
+
+func (s *CaptureSuite) Test_Recorder() {
+	var buffer bytes.Buffer
+	sut := NewDatagramRecorder(&buffer)
+
+	sut.RecordDatagram(api.DatagramDirectionIncoming, "ski", []byte("{\n  \"datagram\": {}\n}"))
+	sut.RecordDatagram(api.DatagramDirectionOutgoing, "ski", []byte("{\"datagram\":{}}"))
+	// invalid messages are not captured
+	sut.RecordDatagram(api.DatagramDirectionIncoming, "ski", []byte("{"))
+	assert.Nil(s.T(), sut.Close())
+
+	entries, err := ReadCapture(&buffer)
+	assert.Nil(s.T(), err)
+	if assert.Equal(s.T(), 2, len(entries)) {
+		assert.Equal(s.T(), api.DatagramDirectionIncoming, entries[0].Direction)
+		assert.Equal(s.T(), "ski", entries[0].Ski)
+		assert.Equal(s.T(), json.RawMessage("{\"datagram\":{}}"), entries[0].Message)
+		assert.False(s.T(), entries[0].Timestamp.IsZero())
+		assert.Equal(s.T(), api.DatagramDirectionOutgoing, entries[1].Direction)
+	}
+
+	_, err = ReadCapture(bytes.NewBufferString("{\n"))
+	assert.NotNil(s.T(), err)
+}
+
+func (s *CaptureSuite) Test_DeviceCapture() {
+	path := filepath.Join(s.T().TempDir(), "capture.jsonl")
+	recorder, err := NewFileDatagramRecorder(path)
+	assert.Nil(s.T(), err)
+
+	deviceA := NewDeviceLocal("Vendor", "DeviceA", "SerialA", "CodeA", "AddressA", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	deviceA.SetDatagramRecorder(recorder)
+	assert.Equal(s.T(), recorder, deviceA.DatagramRecorder())
+	deviceB := NewDeviceLocal("Vendor", "DeviceB", "SerialB", "CodeB", "AddressB", model.DeviceTypeTypeChargingStation, model.NetworkManagementFeatureSetTypeSmart)
+
+	loopback := NewLoopback(deviceA, "skiA", deviceB, "skiB", LoopbackOptions{})
+	assert.Eventually(s.T(), func() bool {
+		remoteDevice := deviceA.RemoteDeviceForSki("skiB")
+		return remoteDevice != nil && remoteDevice.Address() != nil
+	}, time.Second, time.Millisecond*10)
+	loopback.Close()
+	assert.Nil(s.T(), recorder.Close())
+
+	entries, err := ReadCaptureFile(path)
+	assert.Nil(s.T(), err)
+
+	var incoming, outgoing int
+	for _, entry := range entries {
+		assert.Equal(s.T(), "skiB", entry.Ski)
+		switch entry.Direction {
+		case api.DatagramDirectionIncoming:
+			incoming++
+		case api.DatagramDirectionOutgoing:
+			outgoing++
+		}
+	}
+	assert.NotEqual(s.T(), 0, incoming)
+	assert.NotEqual(s.T(), 0, outgoing)
+
+	_, err = ReadCaptureFile(filepath.Join(s.T().TempDir(), "missing.jsonl"))
+	assert.NotNil(s.T(), err)
+}
+
+func (s *CaptureSuite) Test_DeviceCapture_SetLater() {
+	localDevice, localEntity := createLocalDeviceAndEntity(1)
+	localFeature, _ := createLocalFeatures(localEntity, model.FeatureTypeTypeDeviceClassification, "")
+
+	writeHandler := &WriteMessageHandler{}
+	_ = localDevice.SetupRemoteDevice("ski", writeHandler)
+	remoteDevice := localDevice.RemoteDeviceForSki("ski").(*DeviceRemote)
+	_, remoteFeature := createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeDeviceClassification, model.FunctionTypeDeviceClassificationManufacturerData)
+
+	// the recorder is set after the remote device was added
+	var buffer bytes.Buffer
+	localDevice.SetDatagramRecorder(NewDatagramRecorder(&buffer))
+
+	_, err := remoteDevice.Sender().Notify(localFeature.Address(), remoteFeature.Address(), model.CmdType{})
+	assert.Nil(s.T(), err)
+
+	entries, err := ReadCapture(&buffer)
+	assert.Nil(s.T(), err)
+	if assert.Equal(s.T(), 1, len(entries)) {
+		assert.Equal(s.T(), api.DatagramDirectionOutgoing, entries[0].Direction)
+		assert.Equal(s.T(), "ski", entries[0].Ski)
+	}
+}
+
+func (s *CaptureSuite) Test_Replay() {
+	var buffer bytes.Buffer
+	recorder := NewDatagramRecorder(&buffer)
+	recorder.RecordDatagram(api.DatagramDirectionIncoming, "ski", loadFileData(s.T(), wallbox_detaileddiscoverydata_recv_reply_file_path))
+	recorder.RecordDatagram(api.DatagramDirectionIncoming, "other", loadFileData(s.T(), wallbox_detaileddiscoverydata_recv_reply_file_path))
+	recorder.RecordDatagram(api.DatagramDirectionOutgoing, "ski", []byte("{}"))
+
+	entries, err := ReadCapture(&buffer)
+	assert.Nil(s.T(), err)
+
+	localDevice, _ := createLocalDeviceAndEntity(1)
+	sut := NewDatagramReplayer(localDevice, "ski")
+
+	// the detailed discovery request is sent to the synthetic remote device
+	assert.Equal(s.T(), 1, len(sut.SentMessages()))
+
+	err = sut.Replay(entries, "ski")
+	assert.Nil(s.T(), err)
+
+	remoteDevice := sut.RemoteDevice()
+	assert.Equal(s.T(), remoteDevice, localDevice.RemoteDeviceForSki("ski"))
+	assert.Equal(s.T(), model.AddressDeviceType("Wallbox"), *remoteDevice.Address())
+	assert.Equal(s.T(), 2, len(remoteDevice.Entities()))
+
+	entries = append(entries, CaptureEntry{
+		Direction: api.DatagramDirectionIncoming,
+		Message:   json.RawMessage("[]"),
+	})
+	err = sut.Replay(entries, "")
+	assert.NotNil(s.T(), err)
+
+	sut.Close()
+	assert.Nil(s.T(), localDevice.RemoteDeviceForSki("ski"))
+}
@@ -0,0 +1,93 @@
+package spine
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Collects the processing results of all cmds of a received datagram,
+// so only one result message is sent back to the remote device once
+// all cmds are processed.
+//
+// As defined in SPINE chapter 5.2.4, a result is always sent if processing
+// a cmd failed, and a success result is only sent if it was requested via ackRequest.
+type datagramResult struct {
+	requestHeader *model.HeaderType
+	senderAddress *model.FeatureAddressType
+	sender        api.SenderInterface
+
+	pending int
+	err     *model.ErrorType
+
+	mux sync.Mutex
+}
+
+func newDatagramResult(requestHeader *model.HeaderType, senderAddress *model.FeatureAddressType, sender api.SenderInterface, cmdCount int) *datagramResult {
+	return &datagramResult{
+		requestHeader: requestHeader,
+		senderAddress: senderAddress,
+		sender:        sender,
+		pending:       cmdCount,
+	}
+}
+
+// Report the processing result of a single cmd, nil if it was processed successfully
+//
+// Only the first error is reported back to the remote device
+func (r *datagramResult) done(err *model.ErrorType) {
+	r.mux.Lock()
+
+	if r.pending == 0 {
+		r.mux.Unlock()
+		return
+	}
+
+	if err != nil && r.err == nil {
+		r.err = err
+	}
+
+	r.pending--
+	if r.pending > 0 {
+		r.mux.Unlock()
+		return
+	}
+
+	resultErr := r.err
+	r.mux.Unlock()
+
+	r.send(resultErr)
+}
+
+func (r *datagramResult) send(err *model.ErrorType) {
+	if r.sender == nil || r.requestHeader == nil || r.senderAddress == nil {
+		return
+	}
+
+	cmdClassifier := r.requestHeader.CmdClassifier
+
+	// Don't send responses for incoming result messages
+	if cmdClassifier != nil && *cmdClassifier == model.CmdClassifierTypeResult {
+		return
+	}
+
+	if err != nil {
+		_ = r.sender.ResultError(r.requestHeader, r.senderAddress, err)
+		return
+	}
+
+	ackRequest := r.requestHeader.AckRequest
+	ackClassifiers := []model.CmdClassifierType{
+		model.CmdClassifierTypeCall,
+		model.CmdClassifierTypeReply,
+		model.CmdClassifierTypeNotify,
+		model.CmdClassifierTypeWrite,
+	}
+
+	if ackRequest != nil && *ackRequest && cmdClassifier != nil && slices.Contains(ackClassifiers, *cmdClassifier) {
+		// return success as defined in SPINE chapter 5.2.4
+		_ = r.sender.ResultSuccess(r.requestHeader, r.senderAddress)
+	}
+}
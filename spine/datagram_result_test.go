@@ -0,0 +1,58 @@
+package spine
+
+import (
+	"testing"
+
+	"github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_DatagramResult(t *testing.T) {
+	address := featureAddressType(1, NewEntityAddressType("Sender", []uint{1}))
+
+	header := &model.HeaderType{
+		MsgCounter:    util.Ptr(model.MsgCounterType(1)),
+		CmdClassifier: util.Ptr(model.CmdClassifierTypeWrite),
+		AckRequest:    util.Ptr(true),
+	}
+
+	// all cmds successful, ack requested
+	sender := mocks.NewSenderInterface(t)
+	sender.EXPECT().ResultSuccess(header, address).Return(nil).Once()
+
+	sut := newDatagramResult(header, address, sender, 2)
+	sut.done(nil)
+	sut.done(nil)
+	// additional results are ignored
+	sut.done(nil)
+
+	// only the first error is reported
+	err1 := model.NewErrorTypeFromString("error 1")
+	err2 := model.NewErrorTypeFromString("error 2")
+
+	sender = mocks.NewSenderInterface(t)
+	sender.EXPECT().ResultError(header, address, err1).Return(nil).Once()
+
+	sut = newDatagramResult(header, address, sender, 3)
+	sut.done(nil)
+	sut.done(err1)
+	sut.done(err2)
+
+	// no ack requested
+	header.AckRequest = nil
+	sender = mocks.NewSenderInterface(t)
+
+	sut = newDatagramResult(header, address, sender, 1)
+	sut.done(nil)
+
+	// no results for incoming results
+	header.CmdClassifier = util.Ptr(model.CmdClassifierTypeResult)
+	sender = mocks.NewSenderInterface(t)
+
+	sut = newDatagramResult(header, address, sender, 1)
+	sut.done(err1)
+
+	sender.AssertNotCalled(t, "ResultError", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,178 @@
+package spine
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/enbility/spine-go/model"
+)
+
+var ErrInvalidDatagram = errors.New("invalid datagram")
+
+// the filters each cmd classifier may use, see SPINE Protocol Specification Table 7
+type allowedFilters struct {
+	partial, delete bool
+}
+
+var allowedFiltersForClassifier = map[model.CmdClassifierType]allowedFilters{
+	model.CmdClassifierTypeRead:   {partial: true},
+	model.CmdClassifierTypeReply:  {partial: true},
+	model.CmdClassifierTypeNotify: {partial: true, delete: true},
+	model.CmdClassifierTypeWrite:  {partial: true, delete: true},
+	model.CmdClassifierTypeCall:   {},
+	model.CmdClassifierTypeResult: {},
+}
+
+// Validate the header and payload of a received datagram
+//
+// Returns the error which is reported to the sender of the datagram,
+// or nil if the datagram is valid
+func validateDatagram(datagram *model.DatagramType) *model.ErrorType {
+	if err := validateDatagramHeader(&datagram.Header); err != nil {
+		return err
+	}
+
+	if len(datagram.Payload.Cmd) == 0 {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "no payload cmd content available")
+	}
+
+	for i := range datagram.Payload.Cmd {
+		if err := validateCmd(*datagram.Header.CmdClassifier, &datagram.Payload.Cmd[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateDatagramHeader(header *model.HeaderType) *model.ErrorType {
+	if header.SpecificationVersion == nil {
+		return model.NewErrorTypeFromString("specificationVersion is missing")
+	}
+	// only the major version has to match, all other versions are compatible
+	if major, _, _ := strings.Cut(string(*header.SpecificationVersion), "."); major != "1" {
+		return model.NewErrorTypeFromString(fmt.Sprintf("specificationVersion '%s' is not supported", *header.SpecificationVersion))
+	}
+
+	if header.AddressSource == nil || header.AddressSource.Feature == nil {
+		return model.NewErrorTypeFromString("addressSource is missing")
+	}
+
+	if header.AddressDestination == nil || header.AddressDestination.Feature == nil {
+		return model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, "addressDestination is missing")
+	}
+
+	if header.MsgCounter == nil {
+		return model.NewErrorTypeFromString("msgCounter is missing")
+	}
+
+	if header.CmdClassifier == nil {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "cmdClassifier is missing")
+	}
+	if _, ok := allowedFiltersForClassifier[*header.CmdClassifier]; !ok {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, fmt.Sprintf("cmdClassifier '%s' is not supported", *header.CmdClassifier))
+	}
+
+	if (*header.CmdClassifier == model.CmdClassifierTypeReply || *header.CmdClassifier == model.CmdClassifierTypeResult) &&
+		header.MsgCounterReference == nil {
+		return model.NewErrorTypeFromString(fmt.Sprintf("msgCounterReference is missing for %s", *header.CmdClassifier))
+	}
+
+	return nil
+}
+
+func validateCmd(cmdClassifier model.CmdClassifierType, cmd *model.CmdType) *model.ErrorType {
+	if cmdDataCount(cmd) != 1 {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "cmd has to contain exactly one data element")
+	}
+
+	cmdData, err := cmd.Data()
+	if err != nil {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, err.Error())
+	}
+
+	// results are only allowed with the result classifier and vice versa
+	if (cmdClassifier == model.CmdClassifierTypeResult) != (cmd.ResultData != nil) {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported,
+			fmt.Sprintf("%s is not supported for %s", cmdData.FieldName, cmdClassifier))
+	}
+
+	if cmd.Function != nil && (cmdData.Function == nil || *cmd.Function != *cmdData.Function) {
+		return model.NewErrorType(model.ErrorNumberTypeCommandRejected,
+			fmt.Sprintf("function '%s' does not match %s", *cmd.Function, cmdData.FieldName))
+	}
+
+	return validateCmdFilters(cmdClassifier, cmd, cmdData.Function)
+}
+
+func validateCmdFilters(cmdClassifier model.CmdClassifierType, cmd *model.CmdType, function *model.FunctionType) *model.ErrorType {
+	allowed := allowedFiltersForClassifier[cmdClassifier]
+
+	var partialCount, deleteCount int
+	for i := range cmd.Filter {
+		filter := &cmd.Filter[i]
+
+		// every filter is either a partial or a delete filter
+		if filter.CmdControl == nil ||
+			(filter.CmdControl.Partial == nil) == (filter.CmdControl.Delete == nil) {
+			return model.NewErrorType(model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported,
+				"filter has to contain either a partial or a delete cmdControl")
+		}
+
+		if filter.CmdControl.Partial != nil {
+			partialCount++
+		} else {
+			deleteCount++
+		}
+
+		// filters without selectors or elements apply to the complete data
+		if filterData, err := filter.Data(); err == nil && function != nil && *filterData.Function != *function {
+			return model.NewErrorType(model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported,
+				fmt.Sprintf("filter function '%s' does not match cmd function '%s'", *filterData.Function, *function))
+		}
+	}
+
+	if partialCount > 1 || deleteCount > 1 ||
+		(partialCount > 0 && !allowed.partial) ||
+		(deleteCount > 0 && !allowed.delete) {
+		return model.NewErrorType(model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported,
+			fmt.Sprintf("filter combination is not supported for %s", cmdClassifier))
+	}
+
+	return nil
+}
+
+// return the number of data elements set in a cmd
+func cmdDataCount(cmd *model.CmdType) int {
+	var count int
+
+	v := reflect.ValueOf(*cmd)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Kind() != reflect.Ptr || f.IsNil() {
+			continue
+		}
+
+		sf := v.Type().Field(i)
+		// Exclude the CmdOptionGroup fields
+		if sf.Name == "Function" || sf.Name == "Filter" {
+			continue
+		}
+
+		if _, exists := model.EEBusTags(sf)[model.EEBusTagFunction]; exists {
+			count++
+		}
+	}
+
+	return count
+}
+
+// check if a result can be sent for a datagram,
+// results are never answered to prevent endless loops
+func canReplyToDatagram(header *model.HeaderType) bool {
+	return header.AddressSource != nil && header.AddressDestination != nil &&
+		header.MsgCounter != nil &&
+		(header.CmdClassifier == nil || *header.CmdClassifier != model.CmdClassifierTypeResult)
+}
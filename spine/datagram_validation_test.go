@@ -0,0 +1,204 @@
+package spine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDatagramValidationSuite(t *testing.T) {
+	suite.Run(t, new(DatagramValidationSuite))
+}
+
+type DatagramValidationSuite struct {
+	suite.Suite
+
+	localDevice  *DeviceLocal
+	remoteDevice *DeviceRemote
+	writeHandler *WriteMessageHandler
+}
+
+func (s *DatagramValidationSuite) BeforeTest(suiteName, testName string) {
+	s.localDevice, _ = createLocalDeviceAndEntity(1)
+	s.writeHandler = &WriteMessageHandler{}
+	s.remoteDevice = s.localDevice.SetupRemoteDevice("ski", s.writeHandler).(*DeviceRemote)
+}
+
+// a valid read request of the detailed discovery data
+func (s *DatagramValidationSuite) datagram() model.DatagramType {
+	return model.DatagramType{
+		Header: model.HeaderType{
+			SpecificationVersion: &SpecificationVersion,
+			AddressSource: &model.FeatureAddressType{
+				Device:  util.Ptr(model.AddressDeviceType("Remote")),
+				Entity:  []model.AddressEntityType{0},
+				Feature: util.Ptr(model.AddressFeatureType(0)),
+			},
+			AddressDestination: &model.FeatureAddressType{
+				Device:  s.localDevice.Address(),
+				Entity:  []model.AddressEntityType{0},
+				Feature: util.Ptr(model.AddressFeatureType(0)),
+			},
+			MsgCounter:    util.Ptr(model.MsgCounterType(10)),
+			CmdClassifier: util.Ptr(model.CmdClassifierTypeRead),
+		},
+		Payload: model.PayloadType{
+			Cmd: []model.CmdType{
+				{NodeManagementDetailedDiscoveryData: &model.NodeManagementDetailedDiscoveryDataType{}},
+			},
+		},
+	}
+}
+
+func (s *DatagramValidationSuite) Test_Valid() {
+	datagram := s.datagram()
+	assert.Nil(s.T(), validateDatagram(&datagram))
+
+	datagram.Header.SpecificationVersion = util.Ptr(model.SpecificationVersionType("1.1.1"))
+	datagram.Payload.Cmd[0].Function = util.Ptr(model.FunctionTypeNodeManagementDetailedDiscoveryData)
+	datagram.Payload.Cmd[0].Filter = []model.FilterType{*model.NewFilterTypePartial()}
+	assert.Nil(s.T(), validateDatagram(&datagram))
+
+	datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeResult)
+	datagram.Header.MsgCounterReference = util.Ptr(model.MsgCounterType(1))
+	datagram.Payload.Cmd = []model.CmdType{{ResultData: &model.ResultDataType{}}}
+	assert.Nil(s.T(), validateDatagram(&datagram))
+}
+
+func (s *DatagramValidationSuite) Test_Invalid() {
+	deleteFilter := model.FilterType{CmdControl: &model.CmdControlType{Delete: &model.ElementTagType{}}}
+
+	tests := []struct {
+		name        string
+		modify      func(datagram *model.DatagramType)
+		errorNumber model.ErrorNumberType
+	}{
+		{"no specificationVersion", func(datagram *model.DatagramType) {
+			datagram.Header.SpecificationVersion = nil
+		}, model.ErrorNumberTypeGeneralError},
+		{"unsupported specificationVersion", func(datagram *model.DatagramType) {
+			datagram.Header.SpecificationVersion = util.Ptr(model.SpecificationVersionType("2.0.0"))
+		}, model.ErrorNumberTypeGeneralError},
+		{"no addressSource", func(datagram *model.DatagramType) {
+			datagram.Header.AddressSource = nil
+		}, model.ErrorNumberTypeGeneralError},
+		{"no addressDestination", func(datagram *model.DatagramType) {
+			datagram.Header.AddressDestination.Feature = nil
+		}, model.ErrorNumberTypeDestinationUnknown},
+		{"no msgCounter", func(datagram *model.DatagramType) {
+			datagram.Header.MsgCounter = nil
+		}, model.ErrorNumberTypeGeneralError},
+		{"no cmdClassifier", func(datagram *model.DatagramType) {
+			datagram.Header.CmdClassifier = nil
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"unknown cmdClassifier", func(datagram *model.DatagramType) {
+			datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierType("unknown"))
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"reply without msgCounterReference", func(datagram *model.DatagramType) {
+			datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeReply)
+		}, model.ErrorNumberTypeGeneralError},
+		{"no cmd", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd = nil
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"no cmd data", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd = []model.CmdType{{}}
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"multiple cmd data", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd[0].NodeManagementUseCaseData = &model.NodeManagementUseCaseDataType{}
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"result data without result classifier", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd = []model.CmdType{{ResultData: &model.ResultDataType{}}}
+		}, model.ErrorNumberTypeCommandNotSupported},
+		{"function mismatch", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd[0].Function = util.Ptr(model.FunctionTypeNodeManagementUseCaseData)
+		}, model.ErrorNumberTypeCommandRejected},
+		{"filter without cmdControl", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd[0].Filter = []model.FilterType{{}}
+		}, model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported},
+		{"delete filter for read", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd[0].Filter = []model.FilterType{deleteFilter}
+		}, model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported},
+		{"multiple partial filters", func(datagram *model.DatagramType) {
+			datagram.Payload.Cmd[0].Filter = []model.FilterType{*model.NewFilterTypePartial(), *model.NewFilterTypePartial()}
+		}, model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported},
+		{"filter function mismatch", func(datagram *model.DatagramType) {
+			filter := model.NewFilterTypePartial()
+			filter.NodeManagementUseCaseDataSelectors = &model.NodeManagementUseCaseDataSelectorsType{}
+			datagram.Payload.Cmd[0].Filter = []model.FilterType{*filter}
+		}, model.ErrorNumberTypeRestrictedFunctionExchangeCombinationNotSupported},
+	}
+
+	for _, test := range tests {
+		datagram := s.datagram()
+		test.modify(&datagram)
+
+		err := validateDatagram(&datagram)
+		if assert.NotNil(s.T(), err, test.name) {
+			assert.Equal(s.T(), test.errorNumber, err.ErrorNumber, test.name)
+		}
+	}
+}
+
+func (s *DatagramValidationSuite) Test_DeleteAndPartialFilterForNotify() {
+	datagram := s.datagram()
+	datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeNotify)
+	datagram.Payload.Cmd[0].Filter = []model.FilterType{
+		{CmdControl: &model.CmdControlType{Delete: &model.ElementTagType{}}},
+		*model.NewFilterTypePartial(),
+	}
+	assert.Nil(s.T(), validateDatagram(&datagram))
+
+	datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeCall)
+	assert.NotNil(s.T(), validateDatagram(&datagram))
+}
+
+func (s *DatagramValidationSuite) handle(datagram model.DatagramType) error {
+	msg, err := json.Marshal(model.Datagram{Datagram: datagram})
+	assert.Nil(s.T(), err)
+
+	_, err = s.remoteDevice.HandleSpineMesssage(msg)
+	return err
+}
+
+func (s *DatagramValidationSuite) Test_HandleSpineMessage_MissingAddressSource() {
+	datagram := s.datagram()
+	datagram.Header.AddressSource = nil
+
+	err := s.handle(datagram)
+	assert.True(s.T(), errors.Is(err, ErrInvalidDatagram))
+
+	// there is no address to send a result to
+	assert.Nil(s.T(), s.writeHandler.ResultWithReference(datagram.Header.MsgCounter))
+}
+
+func (s *DatagramValidationSuite) Test_HandleSpineMessage_ReplyWithoutReference() {
+	datagram := s.datagram()
+	datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeReply)
+
+	err := s.handle(datagram)
+	assert.True(s.T(), errors.Is(err, ErrInvalidDatagram))
+
+	msg := s.writeHandler.ResultWithReference(datagram.Header.MsgCounter)
+	if assert.NotNil(s.T(), msg) {
+		var result model.Datagram
+		assert.Nil(s.T(), json.Unmarshal(msg, &result))
+		resultData := result.Datagram.Payload.Cmd[0].ResultData
+		assert.Equal(s.T(), model.ErrorNumberTypeGeneralError, *resultData.ErrorNumber)
+	}
+}
+
+func (s *DatagramValidationSuite) Test_HandleSpineMessage_InvalidResult() {
+	datagram := s.datagram()
+	datagram.Header.CmdClassifier = util.Ptr(model.CmdClassifierTypeResult)
+
+	err := s.handle(datagram)
+	assert.True(s.T(), errors.Is(err, ErrInvalidDatagram))
+
+	// results are never answered
+	assert.Nil(s.T(), s.writeHandler.ResultWithReference(datagram.Header.MsgCounter))
+}
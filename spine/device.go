@@ -1,11 +1,18 @@
 package spine
 
-import "github.com/enbility/spine-go/model"
+import (
+	"sync"
+
+	"github.com/enbility/spine-go/model"
+)
 
 type Device struct {
 	address    *model.AddressDeviceType
 	dType      *model.DeviceTypeType
 	featureSet *model.NetworkManagementFeatureSetType
+
+	// remote devices are updated while other connections read them, e.g. for routing
+	descriptionMux sync.RWMutex
 }
 
 // Initialize a new device
@@ -30,14 +37,23 @@ func NewDevice(address *model.AddressDeviceType, dType *model.DeviceTypeType, fe
 }
 
 func (r *Device) Address() *model.AddressDeviceType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.address
 }
 
 func (r *Device) DeviceType() *model.DeviceTypeType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.dType
 }
 
 func (r *Device) FeatureSet() *model.NetworkManagementFeatureSetType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.featureSet
 }
 
@@ -52,3 +68,19 @@ func (r *Device) DestinationData() model.NodeManagementDestinationDataType {
 		},
 	}
 }
+
+// update the device with the values set in a description
+func (r *Device) setDescription(description *model.NetworkManagementDeviceDescriptionDataType) {
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
+	if description.DeviceAddress != nil && description.DeviceAddress.Device != nil {
+		r.address = description.DeviceAddress.Device
+	}
+	if description.DeviceType != nil {
+		r.dType = description.DeviceType
+	}
+	if description.NetworkFeatureSet != nil {
+		r.featureSet = description.NetworkFeatureSet
+	}
+}
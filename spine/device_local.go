@@ -1,6 +1,7 @@
 package spine
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -14,15 +15,34 @@ import (
 	"github.com/enbility/spine-go/util"
 )
 
+// Returned for datagrams which are received after the local device was shut down
+var ErrDeviceShutdown = errors.New("device is shut down")
+
 type DeviceLocal struct {
 	*Device
 	entities            []api.EntityLocalInterface
 	subscriptionManager api.SubscriptionManagerInterface
 	bindingManager      api.BindingManagerInterface
 	nodeManagement      *NodeManagement
+	events              *events
+	storage             api.StorageInterface
+	accessControl       api.AccessControlInterface
+	notifyLimiter       *notifyLimiter
+	outboundEntries     *outboundEntries
+	datagramRecorder    api.DatagramRecorderInterface
+	quirks              *QuirksRegistry
+
+	inboundInterceptors  *interceptorChain
+	outboundInterceptors *interceptorChain
+	inboundQueueSize     uint
 
 	remoteDevices map[string]api.DeviceRemoteInterface
 
+	// set once Shutdown is called, inbound datagrams are rejected afterwards
+	isShutdown bool
+	// goroutines restoring subscriptions and bindings of remote devices
+	backgroundTasks inFlightCounter
+
 	brandName    string
 	deviceModel  string
 	deviceCode   string
@@ -50,14 +70,24 @@ func NewDeviceLocal(
 	res := &DeviceLocal{
 		Device:        NewDevice(&address, &deviceType, fSet),
 		remoteDevices: make(map[string]api.DeviceRemoteInterface),
+		events:        newEvents(),
 		brandName:     brandName,
 		deviceModel:   deviceModel,
 		serialNumber:  serialNumber,
 		deviceCode:    deviceCode,
+		quirks:        NewQuirksRegistry(),
+
+		inboundInterceptors:  &interceptorChain{},
+		outboundInterceptors: &interceptorChain{},
 	}
 
 	res.subscriptionManager = NewSubscriptionManager(res)
+	res.notifyLimiter = newNotifyLimiter(res)
+	res.outboundEntries = newOutboundEntries(res)
 	res.bindingManager = NewBindingManager(res)
+	res.subscriptionManager.AddAuthorizationCallback(res.authorizeSubscription)
+
+	_ = res.events.subscribe(api.EventHandlerLevelCore, res)
 
 	res.addDeviceInformation()
 	return res
@@ -99,22 +129,212 @@ func (r *DeviceLocal) HandleEvent(payload api.EventPayload) {
 
 		// Request Use Case Data
 		_, _ = r.nodeManagement.RequestUseCaseData(payload.Device.Ski(), remoteDevice.Address(), payload.Device.Sender())
+
+		// the remote device address is known now
+		r.updateDestinationList()
+
+		// the remote features are known now, so persisted entries can be restored
+		// this publishes events, which is not possible while handling an event
+		r.runBackgroundTask(func() { r.restoreStorageEntries(remoteDevice) })
+
+		// re-establish the subscriptions and bindings of the local client features after a reconnect
+		r.runBackgroundTask(func() { r.outboundEntries.restore(remoteDevice) })
+	}
+}
+
+// Run a task on a new goroutine, which Shutdown waits for
+//
+// The task is not run if the device is already shut down
+func (r *DeviceLocal) runBackgroundTask(task func()) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if r.isShutdown {
+		return
+	}
+
+	r.backgroundTasks.add()
+	go func() {
+		defer r.backgroundTasks.done()
+		task()
+	}()
+}
+
+// Returns true once Shutdown was called
+func (r *DeviceLocal) shutdownStarted() bool {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.isShutdown
+}
+
+// check if the remote feature may subscribe to the local feature
+func (r *DeviceLocal) authorizeSubscription(ski string, clientFeature api.FeatureRemoteInterface, serverFeature api.FeatureLocalInterface) error {
+	if !r.checkAccess(clientFeature, serverFeature, "", api.AccessOperationSubscribe) {
+		return errors.New("access denied")
+	}
+
+	return nil
+}
+
+// return the access operation required for processing a message with the cmd classifier
+func accessOperationForClassifier(cmdClassifier model.CmdClassifierType) (api.AccessOperationType, bool) {
+	switch cmdClassifier {
+	case model.CmdClassifierTypeRead:
+		return api.AccessOperationRead, true
+	case model.CmdClassifierTypeWrite:
+		return api.AccessOperationWrite, true
+	case model.CmdClassifierTypeCall:
+		return api.AccessOperationCall, true
+	default:
+		return 0, false
+	}
+}
+
+// check if the remote feature may access the function of the local feature
+//
+// The NodeManagement is always accessible, as it is required to establish the communication
+func (r *DeviceLocal) isAccessAllowed(
+	remoteFeature api.FeatureRemoteInterface,
+	localFeature api.FeatureLocalInterface,
+	function model.FunctionType,
+	operation api.AccessOperationType) bool {
+	accessControl := r.AccessControl()
+	if accessControl == nil || (localFeature != nil && localFeature.Type() == model.FeatureTypeTypeNodeManagement) {
+		return true
+	}
+
+	return accessControl.IsAccessAllowed(remoteFeature, localFeature, function, operation)
+}
+
+// check if the remote feature may access the function of the local feature
+// and publish an event if the access is denied
+func (r *DeviceLocal) checkAccess(
+	remoteFeature api.FeatureRemoteInterface,
+	localFeature api.FeatureLocalInterface,
+	function model.FunctionType,
+	operation api.AccessOperationType) bool {
+	if r.isAccessAllowed(remoteFeature, localFeature, function, operation) {
+		return true
+	}
+
+	payload := api.EventPayload{
+		Ski:          remoteFeature.Device().Ski(),
+		EventType:    api.EventTypeAccessDenied,
+		ChangeType:   api.ElementChangeUpdate,
+		Device:       remoteFeature.Device(),
+		Entity:       remoteFeature.Entity(),
+		Feature:      remoteFeature,
+		LocalFeature: localFeature,
+		Function:     function,
+		Data:         operation,
+	}
+	r.events.Publish(payload)
+
+	return false
+}
+
+// Restore the persisted subscriptions and bindings of a remote device
+//
+// Entries which are already active are skipped, entries whose local or remote feature
+// does not exist anymore are removed. Entries which are rejected, e.g. by an authorization
+// callback, are kept for the next time
+func (r *DeviceLocal) restoreStorageEntries(remoteDevice api.DeviceRemoteInterface) {
+	storage := r.Storage()
+	if storage == nil {
+		return
+	}
+
+	subscriptions, err := storage.Subscriptions(remoteDevice.Ski())
+	logStorageError(err)
+	for _, entry := range subscriptions {
+		if r.shutdownStarted() {
+			return
+		}
+
+		serverFeature, clientFeature := r.storageEntryFeatures(remoteDevice, entry)
+		if serverFeature == nil || clientFeature == nil {
+			// the entry can not be restored anymore, so it is removed instead of failing again on every reconnect
+			logging.Log().Debug("restoring subscription failed, the feature does not exist anymore, removing it")
+			logStorageError(storage.RemoveSubscription(remoteDevice.Ski(), entry))
+			continue
+		}
+
+		// e.g. the detailed discovery data was received again or the remote subscribed again in the meantime
+		if slices.ContainsFunc(r.subscriptionManager.SubscriptionsOnFeature(*serverFeature.Address()), func(item *api.SubscriptionEntry) bool {
+			return item.ClientFeature == clientFeature
+		}) {
+			continue
+		}
+
+		data := model.SubscriptionManagementRequestCallType{
+			ClientAddress:     &entry.ClientAddress,
+			ServerAddress:     &entry.ServerAddress,
+			ServerFeatureType: &entry.ServerFeatureType,
+		}
+		if err := r.subscriptionManager.AddSubscription(remoteDevice, data); err != nil && !errors.Is(err, errSubscriptionExists) {
+			logging.Log().Debug("restoring subscription failed:", err)
+		}
+	}
+
+	bindings, err := storage.Bindings(remoteDevice.Ski())
+	logStorageError(err)
+	for _, entry := range bindings {
+		if r.shutdownStarted() {
+			return
+		}
+
+		serverFeature, clientFeature := r.storageEntryFeatures(remoteDevice, entry)
+		if serverFeature == nil || clientFeature == nil {
+			logging.Log().Debug("restoring binding failed, the feature does not exist anymore, removing it")
+			logStorageError(storage.RemoveBinding(remoteDevice.Ski(), entry))
+			continue
+		}
+
+		if r.bindingManager.HasLocalFeatureRemoteBinding(serverFeature.Address(), clientFeature.Address()) {
+			continue
+		}
+
+		data := model.BindingManagementRequestCallType{
+			ClientAddress:     &entry.ClientAddress,
+			ServerAddress:     &entry.ServerAddress,
+			ServerFeatureType: &entry.ServerFeatureType,
+		}
+		if err := r.bindingManager.AddBinding(remoteDevice, data); err != nil && !errors.Is(err, errBindingExists) {
+			logging.Log().Debug("restoring binding failed:", err)
+		}
 	}
 }
 
+// return the local server feature and the remote client feature of a persisted entry,
+// nil if the feature does not exist
+func (r *DeviceLocal) storageEntryFeatures(remoteDevice api.DeviceRemoteInterface, entry api.StorageEntry) (api.FeatureLocalInterface, api.FeatureRemoteInterface) {
+	return r.FeatureByAddress(&entry.ServerAddress), remoteDevice.FeatureByAddress(&entry.ClientAddress)
+}
+
 var _ api.DeviceLocalInterface = (*DeviceLocal)(nil)
 
 /* DeviceLocalInterface */
 
 // Setup a new remote device with a given SKI and triggers SPINE requesting device details
 func (r *DeviceLocal) SetupRemoteDevice(ski string, writeI shipapi.ShipConnectionDataWriterInterface) shipapi.ShipConnectionDataReaderInterface {
-	sender := NewSender(writeI)
+	sender := newSender(writeI)
+	sender.ski = ski
+	sender.setDeviceInterceptors(r.outboundInterceptors)
+	if storage := r.Storage(); storage != nil {
+		sender.setStorage(storage)
+	}
+	sender.setLocalDevice(r)
 	rDevice := NewDeviceRemote(r, ski, sender)
 
-	r.AddRemoteDeviceForSki(ski, rDevice)
+	r.mux.Lock()
+	inboundQueueSize := r.inboundQueueSize
+	r.mux.Unlock()
+	if inboundQueueSize > 0 {
+		rDevice.startInboundQueue(inboundQueueSize)
+	}
 
-	// always add subscription, as it checks if it already exists
-	_ = Events.subscribe(api.EventHandlerLevelCore, r)
+	r.AddRemoteDeviceForSki(ski, rDevice)
 
 	// Request Detailed Discovery Data
 	_, _ = r.RequestRemoteDetailedDiscoveryData(rDevice)
@@ -150,40 +370,47 @@ func (r *DeviceLocal) RemoveRemoteDeviceConnection(ski string) {
 		ChangeType: api.ElementChangeRemove,
 		Device:     remoteDevice,
 	}
-	Events.Publish(payload)
+	r.events.Publish(payload)
 }
 
 func (r *DeviceLocal) RemoveRemoteDevice(ski string) {
-	remoteDevice := r.RemoteDeviceForSki(ski)
+	// the device is removed from the list first, so concurrent calls clean up only once
+	r.mux.Lock()
+	remoteDevice := r.remoteDevices[ski]
+	delete(r.remoteDevices, ski)
+	r.mux.Unlock()
+
 	if remoteDevice == nil {
 		return
 	}
 
+	// queued datagrams of a disconnected device are not processed anymore
+	if rDevice, ok := remoteDevice.(*DeviceRemote); ok {
+		rDevice.stopInboundQueue()
+	}
+
 	// remove all subscriptions for this device
 	subscriptionMgr := r.SubscriptionManager()
-	subscriptionMgr.RemoveSubscriptionsForDevice(r.remoteDevices[ski])
+	subscriptionMgr.RemoveSubscriptionsForDevice(remoteDevice)
 
 	// remove all bindings for this device
 	bindingMgr := r.BindingManager()
-	bindingMgr.RemoveBindingsForDevice(r.remoteDevices[ski])
-
-	delete(r.remoteDevices, ski)
+	bindingMgr.RemoveBindingsForDevice(remoteDevice)
 
-	// only unsubscribe if we don't have any remote devices left
-	if len(r.remoteDevices) == 0 {
-		_ = Events.unsubscribe(api.EventHandlerLevelCore, r)
-	}
+	r.notifyLimiter.removeRemoteDevice(ski)
 
 	remoteDeviceAddress := &model.DeviceAddressType{
 		Device: remoteDevice.Address(),
 	}
 	// remove all data caches for this device
-	for _, entity := range r.entities {
+	for _, entity := range r.Entities() {
 		for _, feature := range entity.Features() {
 			feature.CleanWriteApprovalCaches(ski)
 			feature.CleanRemoteDeviceCaches(remoteDeviceAddress)
 		}
 	}
+
+	r.updateDestinationList()
 }
 
 func (r *DeviceLocal) RemoteDevices() []api.DeviceRemoteInterface {
@@ -253,11 +480,81 @@ func (r *DeviceLocal) RemoveEntity(entity api.EntityLocalInterface) {
 	r.notifySubscribersOfEntity(entity, model.NetworkManagementStateChangeTypeRemoved)
 }
 
+// a local feature which holds pending requests and approvals that have to be failed on shutdown
+type shutdownFeature interface {
+	shutdown()
+}
+
+func (r *DeviceLocal) Shutdown(ctx context.Context) error {
+	r.mux.Lock()
+	r.isShutdown = true
+	r.mux.Unlock()
+
+	// queued datagrams are not processed anymore
+	for _, remoteDevice := range r.RemoteDevices() {
+		if rDevice, ok := remoteDevice.(*DeviceRemote); ok {
+			rDevice.stopInboundQueue()
+		}
+	}
+
+	// the pending notifies are sent before the entities are removed
+	r.notifyLimiter.stop()
+
+	entities := r.Entities()
+
+	for _, entity := range entities {
+		if heartbeatMgr := entity.HeartbeatManager(); heartbeatMgr != nil {
+			heartbeatMgr.StopHeartbeat()
+		}
+	}
+
+	// the results of denied writes have to be sent while the remote devices still know the entities
+	for _, entity := range entities {
+		for _, feature := range entity.Features() {
+			if f, ok := feature.(shutdownFeature); ok {
+				f.shutdown()
+			}
+		}
+	}
+
+	// the subscriptions of the node management are removed last,
+	// so the remote devices still receive all changes of this device until then
+	for _, entity := range entities {
+		for _, feature := range entity.Features() {
+			if feature.Type() != model.FeatureTypeTypeNodeManagement {
+				feature.RemoveAllRemoteSubscriptions()
+				feature.RemoveAllRemoteBindings()
+			}
+		}
+	}
+
+	for _, entity := range entities {
+		if entityAddress := entity.Address().Entity; len(entityAddress) > 0 &&
+			entityAddress[0] == model.AddressEntityType(DeviceInformationEntityId) {
+			continue
+		}
+
+		r.RemoveEntity(entity)
+	}
+
+	r.nodeManagement.RemoveAllRemoteSubscriptions()
+	r.nodeManagement.RemoveAllRemoteBindings()
+
+	for _, wait := range []func(context.Context) error{r.notifyLimiter.wait, r.backgroundTasks.wait, r.events.wait} {
+		if err := wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns a copy of the entities list, which may be used while entities are added or removed
 func (r *DeviceLocal) Entities() []api.EntityLocalInterface {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	return r.entities
+	return slices.Clone(r.entities)
 }
 
 func (r *DeviceLocal) Entity(id []model.AddressEntityType) api.EntityLocalInterface {
@@ -293,7 +590,7 @@ func (r *DeviceLocal) FeatureByAddress(address *model.FeatureAddressType) api.Fe
 }
 
 func (r *DeviceLocal) CleanRemoteEntityCaches(remoteAddress *model.EntityAddressType) {
-	for _, entity := range r.entities {
+	for _, entity := range r.Entities() {
 		for _, feature := range entity.Features() {
 			feature.CleanRemoteEntityCaches(remoteAddress)
 		}
@@ -308,10 +605,6 @@ func (r *DeviceLocal) ProcessCmd(datagram model.DatagramType, remoteDevice api.D
 	if len(datagram.Payload.Cmd) == 0 {
 		return errors.New("no payload cmd content available")
 	}
-	cmd := datagram.Payload.Cmd[0]
-
-	// TODO check if cmd.Function is the same as the provided cmd value
-	filterPartial, filterDelete := cmd.ExtractFilter()
 
 	remoteEntity := remoteDevice.Entity(datagram.Header.AddressSource.Entity)
 	remoteFeature := remoteDevice.FeatureByAddress(datagram.Header.AddressSource)
@@ -319,29 +612,19 @@ func (r *DeviceLocal) ProcessCmd(datagram model.DatagramType, remoteDevice api.D
 		return fmt.Errorf("invalid remote feature address: '%s'", datagram.Header.AddressSource)
 	}
 
-	message := &api.Message{
-		RequestHeader: &datagram.Header,
-		Cmd:           cmd,
-		FilterPartial: filterPartial,
-		FilterDelete:  filterDelete,
-		FeatureRemote: remoteFeature,
-		EntityRemote:  remoteEntity,
-		DeviceRemote:  remoteDevice,
-	}
+	sender := remoteFeature.Device().Sender()
 
-	if cmdClassifier != nil {
-		message.CmdClassifier = *cmdClassifier
-	} else {
+	if cmdClassifier == nil {
 		errorMessage := "cmdClassifier may not be empty"
 
-		_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, destAddr, model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, errorMessage))
+		_ = sender.ResultError(&datagram.Header, destAddr, model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, errorMessage))
 
 		return errors.New(errorMessage)
 	}
 
 	if localFeature == nil {
 		errorMessage := "invalid feature address"
-		_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, destAddr, model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, errorMessage))
+		_ = sender.ResultError(&datagram.Header, destAddr, model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, errorMessage))
 
 		return errors.New(errorMessage)
 	}
@@ -351,37 +634,95 @@ func (r *DeviceLocal) ProcessCmd(datagram model.DatagramType, remoteDevice api.D
 
 	logging.Log().Debug(datagram.PrintMessageOverview(false, lfType, rfType))
 
+	// all cmds of a datagram are processed in order and only one combined result is sent
+	result := newDatagramResult(&datagram.Header, localFeature.Address(), sender, len(datagram.Payload.Cmd))
+
+	var errs []error
+	for index, cmd := range datagram.Payload.Cmd {
+		message := &api.Message{
+			RequestHeader: &datagram.Header,
+			CmdClassifier: *cmdClassifier,
+			Cmd:           cmd,
+			FeatureRemote: remoteFeature,
+			EntityRemote:  remoteEntity,
+			DeviceRemote:  remoteDevice,
+			CmdIndex:      index,
+		}
+
+		if err := r.processCmdMessage(message, localFeature, result); err != nil {
+			errs = append(errs, errors.New(err.String()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// run the inbound interceptors for a received datagram
+//
+// returns false if the datagram should not be processed,
+// rejected datagrams are answered with a result error
+func (r *DeviceLocal) interceptInbound(remoteDevice api.DeviceRemoteInterface, datagram *model.DatagramType) bool {
+	action, err := r.inboundInterceptors.run(remoteDevice.Ski(), datagram)
+
+	switch action {
+	case api.InterceptorActionStop:
+		return false
+	case api.InterceptorActionReject:
+		header := &datagram.Header
+		if canReplyToDatagram(header) {
+			_ = remoteDevice.Sender().ResultError(header, header.AddressDestination, err)
+		}
+		return false
+	}
+
+	return true
+}
+
+// process a single cmd of a datagram and report the result to the datagram result
+func (r *DeviceLocal) processCmdMessage(message *api.Message, localFeature api.FeatureLocalInterface, result *datagramResult) *model.ErrorType {
+	message.FilterPartial, message.FilterDelete = message.Cmd.ExtractFilter()
+
+	remoteFeature := message.FeatureRemote
+
+	// check if the remote may read, write or call the function
+	if operation, ok := accessOperationForClassifier(message.CmdClassifier); ok {
+		if cmdData, err := message.Cmd.Data(); err == nil && cmdData.Function != nil &&
+			!r.checkAccess(remoteFeature, localFeature, *cmdData.Function, operation) {
+			err := model.NewErrorType(model.ErrorNumberTypeCommandRejected, "access denied")
+			result.done(err)
+			return err
+		}
+	}
+
 	// check if this is a write with an existing binding and if write is allowed on this feature
 	if message.CmdClassifier == model.CmdClassifierTypeWrite {
-		cmdData, err := cmd.Data()
+		cmdData, err := message.Cmd.Data()
 		if err != nil || cmdData.Function == nil {
 			err := model.NewErrorTypeFromString("no function found for cmd data")
-			_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, localFeature.Address(), err)
-			return errors.New(err.String())
+			result.done(err)
+			return err
 		}
 
 		if operations, ok := localFeature.Operations()[*cmdData.Function]; !ok || !operations.Write() {
 			err := model.NewErrorTypeFromString("write is not allowed on this function")
-			_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, localFeature.Address(), err)
-			return errors.New(err.String())
+			result.done(err)
+			return err
 		}
 
 		if !r.BindingManager().HasLocalFeatureRemoteBinding(localFeature.Address(), remoteFeature.Address()) {
 			err := model.NewErrorTypeFromString("write denied due to missing binding")
-			_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, localFeature.Address(), err)
-			return errors.New(err.String())
+			result.done(err)
+			return err
 		}
+
+		// the write result may be provided asynchronously, e.g. if the write needs to be approved
+		message.ResultHandler = result.done
 	}
 
 	err := localFeature.HandleMessage(message)
 	if err != nil {
 		// TODO: add error description in a useful format
 
-		// Don't send error responses for incoming resulterror messages
-		if message.CmdClassifier != model.CmdClassifierTypeResult {
-			_ = remoteFeature.Device().Sender().ResultError(message.RequestHeader, localFeature.Address(), err)
-		}
-
 		// if this is an error for a notify message, automatically trigger a read request for the same
 		if message.CmdClassifier == model.CmdClassifierTypeNotify {
 			// set the command function to empty
@@ -390,22 +731,18 @@ func (r *DeviceLocal) ProcessCmd(datagram model.DatagramType, remoteDevice api.D
 				_, _ = localFeature.RequestRemoteData(*cmdData.Function, nil, nil, remoteFeature)
 			}
 		}
-
-		return errors.New(err.String())
 	}
 
-	ackRequest := message.RequestHeader.AckRequest
-	ackClassifiers := []model.CmdClassifierType{
-		model.CmdClassifierTypeCall,
-		model.CmdClassifierTypeReply,
-		model.CmdClassifierTypeNotify}
-
-	if ackRequest != nil && *ackRequest && slices.Contains(ackClassifiers, message.CmdClassifier) {
-		// return success as defined in SPINE chapter 5.2.4
-		_ = remoteFeature.Device().Sender().ResultSuccess(message.RequestHeader, localFeature.Address())
+	// write results are reported via the ResultHandler of the message
+	if message.CmdClassifier != model.CmdClassifierTypeWrite || err != nil {
+		result.done(err)
 	}
 
-	return nil
+	return err
+}
+
+func (r *DeviceLocal) Events() api.EventsInterface {
+	return r.events
 }
 
 func (r *DeviceLocal) NodeManagement() api.NodeManagementInterface {
@@ -416,6 +753,75 @@ func (r *DeviceLocal) SubscriptionManager() api.SubscriptionManagerInterface {
 	return r.subscriptionManager
 }
 
+func (r *DeviceLocal) SetStorage(storage api.StorageInterface) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.storage = storage
+}
+
+func (r *DeviceLocal) Storage() api.StorageInterface {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.storage
+}
+
+func (r *DeviceLocal) SetAccessControl(accessControl api.AccessControlInterface) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.accessControl = accessControl
+}
+
+func (r *DeviceLocal) AccessControl() api.AccessControlInterface {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.accessControl
+}
+
+func (r *DeviceLocal) SetDatagramRecorder(recorder api.DatagramRecorderInterface) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.datagramRecorder = recorder
+}
+
+func (r *DeviceLocal) DatagramRecorder() api.DatagramRecorderInterface {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return r.datagramRecorder
+}
+
+func (r *DeviceLocal) Quirks() api.QuirksRegistryInterface {
+	return r.quirks
+}
+
+func (r *DeviceLocal) AddInboundInterceptor(interceptor api.InterceptorFunc) {
+	r.inboundInterceptors.add(interceptor)
+}
+
+func (r *DeviceLocal) AddOutboundInterceptor(interceptor api.InterceptorFunc) {
+	r.outboundInterceptors.add(interceptor)
+}
+
+func (r *DeviceLocal) SetNotifyRateLimit(featureAddress *model.FeatureAddressType, limit api.NotifyRateLimit) {
+	r.notifyLimiter.setRateLimit(featureAddress, limit)
+}
+
+func (r *DeviceLocal) SetNotifyPriorityFunctions(functions ...model.FunctionType) {
+	r.notifyLimiter.setPriorityFunctions(functions)
+}
+
+func (r *DeviceLocal) SetInboundQueueSize(size uint) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.inboundQueueSize = size
+}
+
 func (r *DeviceLocal) BindingManager() api.BindingManagerInterface {
 	return r.bindingManager
 }
@@ -424,20 +830,34 @@ func (r *DeviceLocal) Information() *model.NodeManagementDetailedDiscoveryDevice
 	res := model.NodeManagementDetailedDiscoveryDeviceInformationType{
 		Description: &model.NetworkManagementDeviceDescriptionDataType{
 			DeviceAddress: &model.DeviceAddressType{
-				Device: r.address,
+				Device: r.Address(),
 			},
-			DeviceType:        r.dType,
-			NetworkFeatureSet: r.featureSet,
+			DeviceType:        r.DeviceType(),
+			NetworkFeatureSet: r.FeatureSet(),
 		},
 	}
 	return &res
 }
 
-func (r *DeviceLocal) NotifySubscribers(featureAddress *model.FeatureAddressType, cmd model.CmdType) {
+func (r *DeviceLocal) NotifySubscribers(featureAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+	if len(cmd) == 0 {
+		return
+	}
+
+	// all cmds contain data of the same function
+	var function model.FunctionType
+	if cmdData, err := cmd[0].Data(); err == nil && cmdData.Function != nil {
+		function = *cmdData.Function
+	}
+
 	subscriptions := r.SubscriptionManager().SubscriptionsOnFeature(*featureAddress)
 	for _, subscription := range subscriptions {
-		// TODO: error handling
-		_, _ = subscription.ClientFeature.Device().Sender().Notify(subscription.ServerFeature.Address(), subscription.ClientFeature.Address(), cmd)
+		// only notify subscribers which may receive the data of this function
+		if !r.isAccessAllowed(subscription.ClientFeature, subscription.ServerFeature, function, api.AccessOperationSubscribe) {
+			continue
+		}
+
+		r.notifyLimiter.notify(subscription, function, cmd)
 	}
 }
 
@@ -476,6 +896,7 @@ func (r *DeviceLocal) addDeviceInformation() {
 	{
 		r.nodeManagement = NewNodeManagement(entity.NextFeatureId(), entity)
 		entity.AddFeature(r.nodeManagement)
+		r.updateDestinationList()
 	}
 	{
 		f := NewFeatureLocal(entity.NextFeatureId(), entity, model.FeatureTypeTypeDeviceClassification, model.RoleTypeServer)
@@ -494,5 +915,7 @@ func (r *DeviceLocal) addDeviceInformation() {
 		entity.AddFeature(f)
 	}
 
+	r.mux.Lock()
 	r.entities = append(r.entities, entity)
+	r.mux.Unlock()
 }
@@ -1,10 +1,13 @@
 package spine
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	shipapi "github.com/enbility/ship-go/api"
+	"github.com/enbility/spine-go/api"
 	"github.com/enbility/spine-go/model"
 	"github.com/enbility/spine-go/util"
 	"github.com/stretchr/testify/assert"
@@ -177,6 +180,176 @@ func (d *DeviceLocalTestSuite) Test_ProcessCmd_NotifyError() {
 	assert.NotNil(d.T(), err)
 }
 
+func (d *DeviceLocalTestSuite) Test_ProcessCmd_MultipleCmds() {
+	sut := NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	localEntity := NewEntityLocal(sut, model.EntityTypeTypeCEM, NewAddressEntityType([]uint{1}), time.Second*4)
+	localFeature := NewFeatureLocal(50, localEntity, model.FeatureTypeTypeMeasurement, model.RoleTypeClient)
+	localEntity.AddFeature(localFeature)
+	sut.AddEntity(localEntity)
+
+	ski := "test"
+	writeHandler := &WriteMessageHandler{}
+	_ = sut.SetupRemoteDevice(ski, writeHandler)
+	remote := sut.RemoteDeviceForSki(ski)
+	assert.NotNil(d.T(), remote)
+	remoteEntity := NewEntityRemote(remote, model.EntityTypeTypeCEM, []model.AddressEntityType{1})
+	remoteFeature := NewFeatureRemote(50, remoteEntity, model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+	remoteEntity.AddFeature(remoteFeature)
+	remote.AddEntity(remoteEntity)
+
+	datagram := model.DatagramType{
+		Header: model.HeaderType{
+			AddressSource:      remoteFeature.Address(),
+			AddressDestination: localFeature.Address(),
+			MsgCounter:         util.Ptr(model.MsgCounterType(1)),
+			CmdClassifier:      util.Ptr(model.CmdClassifierTypeNotify),
+			AckRequest:         util.Ptr(true),
+		},
+		Payload: model.PayloadType{
+			Cmd: []model.CmdType{
+				{
+					MeasurementDescriptionListData: &model.MeasurementDescriptionListDataType{
+						MeasurementDescriptionData: []model.MeasurementDescriptionDataType{
+							{
+								MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+							},
+						},
+					},
+				},
+				{
+					MeasurementListData: &model.MeasurementListDataType{
+						MeasurementData: []model.MeasurementDataType{
+							{
+								MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+								Value:         model.NewScaledNumberType(99),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := sut.ProcessCmd(datagram, remote)
+	assert.Nil(d.T(), err)
+
+	// all cmds are processed
+	descData := remoteFeature.DataCopy(model.FunctionTypeMeasurementDescriptionListData).(*model.MeasurementDescriptionListDataType)
+	assert.Equal(d.T(), 1, len(descData.MeasurementDescriptionData))
+	data := remoteFeature.DataCopy(model.FunctionTypeMeasurementListData).(*model.MeasurementListDataType)
+	assert.Equal(d.T(), 1, len(data.MeasurementData))
+
+	// only one combined result is sent, the first message is the detailed discovery read
+	assert.Equal(d.T(), 2, len(writeHandler.sentMessages))
+	waitForAck(d.T(), datagram.Header.MsgCounter, writeHandler)
+
+	// an invalid cmd results in one error result, but the other cmds are still processed
+	datagram.Header.MsgCounter = util.Ptr(model.MsgCounterType(2))
+	datagram.Payload.Cmd = []model.CmdType{
+		{},
+		datagram.Payload.Cmd[1],
+	}
+
+	err = sut.ProcessCmd(datagram, remote)
+	assert.NotNil(d.T(), err)
+
+	msg := writeHandler.ResultWithReference(datagram.Header.MsgCounter)
+	assert.NotNil(d.T(), msg)
+	assert.Contains(d.T(), string(msg), `"errorNumber":6`)
+	assert.Equal(d.T(), 3, len(writeHandler.sentMessages))
+}
+
+func (d *DeviceLocalTestSuite) Test_ProcessCmd_MultipleWriteCmds_Approval() {
+	sut := NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	localEntity := NewEntityLocal(sut, model.EntityTypeTypeCEM, NewAddressEntityType([]uint{1}), time.Second*4)
+	localFeature := localEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	localFeature.AddFunctionType(model.FunctionTypeLoadControlLimitListData, true, true)
+	localFeature.AddFunctionType(model.FunctionTypeLoadControlLimitDescriptionListData, true, true)
+	localFeature.SetWriteApprovalTimeout(time.Millisecond * 200)
+	sut.AddEntity(localEntity)
+
+	ski := "test"
+	writeHandler := &WriteMessageHandler{}
+	_ = sut.SetupRemoteDevice(ski, writeHandler)
+	remote := sut.RemoteDeviceForSki(ski)
+	assert.NotNil(d.T(), remote)
+	remoteEntity := NewEntityRemote(remote, model.EntityTypeTypeEVSE, []model.AddressEntityType{1})
+	remoteFeature := NewFeatureRemote(1, remoteEntity, model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+	remoteEntity.AddFeature(remoteFeature)
+	remote.AddEntity(remoteEntity)
+
+	err := sut.BindingManager().AddBinding(remote, model.BindingManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeLoadControl),
+	})
+	assert.Nil(d.T(), err)
+
+	// every cmd of the datagram has to be approved on its own
+	var approvals atomic.Int32
+	cb := func(msg *api.Message) {
+		approvals.Add(1)
+		localFeature.ApproveOrDenyWrite(msg, model.ErrorType{ErrorNumber: 0})
+	}
+	err = localFeature.AddWriteApprovalCallback(cb)
+	assert.Nil(d.T(), err)
+
+	datagram := model.DatagramType{
+		Header: model.HeaderType{
+			AddressSource:      remoteFeature.Address(),
+			AddressDestination: localFeature.Address(),
+			MsgCounter:         util.Ptr(model.MsgCounterType(1)),
+			CmdClassifier:      util.Ptr(model.CmdClassifierTypeWrite),
+			AckRequest:         util.Ptr(true),
+		},
+		Payload: model.PayloadType{
+			Cmd: []model.CmdType{
+				{
+					LoadControlLimitListData: &model.LoadControlLimitListDataType{
+						LoadControlLimitData: []model.LoadControlLimitDataType{
+							{
+								LimitId: util.Ptr(model.LoadControlLimitIdType(1)),
+								Value:   model.NewScaledNumberType(16),
+							},
+						},
+					},
+				},
+				{
+					LoadControlLimitDescriptionListData: &model.LoadControlLimitDescriptionListDataType{
+						LoadControlLimitDescriptionData: []model.LoadControlLimitDescriptionDataType{
+							{
+								LimitId: util.Ptr(model.LoadControlLimitIdType(1)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = sut.ProcessCmd(datagram, remote)
+	assert.Nil(d.T(), err)
+
+	assert.Eventually(d.T(), func() bool {
+		return writeHandler.ResultWithReference(datagram.Header.MsgCounter) != nil
+	}, time.Second, time.Millisecond*10)
+	waitForAck(d.T(), datagram.Header.MsgCounter, writeHandler)
+	assert.Equal(d.T(), int32(2), approvals.Load())
+
+	// both writes are applied
+	data, ok := localFeature.DataCopy(model.FunctionTypeLoadControlLimitListData).(*model.LoadControlLimitListDataType)
+	assert.True(d.T(), ok)
+	assert.Equal(d.T(), 1, len(data.LoadControlLimitData))
+	descData, ok := localFeature.DataCopy(model.FunctionTypeLoadControlLimitDescriptionListData).(*model.LoadControlLimitDescriptionListDataType)
+	assert.True(d.T(), ok)
+	assert.Equal(d.T(), 1, len(descData.LoadControlLimitDescriptionData))
+
+	// no approval timer is left which could report another result
+	sentMessages := len(writeHandler.sentMessages)
+	time.Sleep(time.Millisecond * 300)
+	assert.Equal(d.T(), sentMessages, len(writeHandler.sentMessages))
+}
+
 func (d *DeviceLocalTestSuite) Test_ProcessCmd_Errors() {
 	sut := NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
 	localEntity := NewEntityLocal(sut, model.EntityTypeTypeCEM, NewAddressEntityType([]uint{1}), time.Second*4)
@@ -356,3 +529,233 @@ func (d *DeviceLocalTestSuite) Test_ProcessCmd() {
 	err = sut.ProcessCmd(datagram, remote)
 	assert.NotNil(d.T(), err)
 }
+
+func (d *DeviceLocalTestSuite) Test_Storage() {
+	storage := NewMemoryStorage()
+
+	sut := NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	sut.SetStorage(storage)
+	assert.Equal(d.T(), storage, sut.Storage())
+
+	localEntity := NewEntityLocal(sut, model.EntityTypeTypeCEM, NewAddressEntityType([]uint{1}), time.Second*4)
+	sut.AddEntity(localEntity)
+	localFeature := localEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+
+	ski := "test"
+	setupRemoteDevice := func() (*DeviceRemote, api.FeatureRemoteInterface) {
+		_ = sut.SetupRemoteDevice(ski, d)
+		remoteDevice := sut.RemoteDeviceForSki(ski).(*DeviceRemote)
+		remoteDevice.address = util.Ptr(model.AddressDeviceType("remote"))
+		remoteFeature, _ := createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeLoadControl, model.FunctionTypeLoadControlLimitListData)
+		return remoteDevice, remoteFeature
+	}
+
+	remoteDevice, remoteFeature := setupRemoteDevice()
+
+	// the detailed discovery request used the first msgCounter, which reserved a block
+	msgCounter, _ := storage.MsgCounter(ski)
+	assert.Equal(d.T(), model.MsgCounterType(msgCounterReserveBlock), msgCounter)
+
+	// further messages use the reserved block without writing to the storage
+	_, _ = remoteDevice.Sender().Notify(localFeature.Address(), remoteFeature.Address(), model.CmdType{})
+	msgCounter, _ = storage.MsgCounter(ski)
+	assert.Equal(d.T(), model.MsgCounterType(msgCounterReserveBlock), msgCounter)
+
+	err := sut.SubscriptionManager().AddSubscription(remoteDevice, model.SubscriptionManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeLoadControl),
+	})
+	assert.Nil(d.T(), err)
+	err = sut.BindingManager().AddBinding(remoteDevice, model.BindingManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeLoadControl),
+	})
+	assert.Nil(d.T(), err)
+
+	subscriptions, _ := storage.Subscriptions(ski)
+	assert.Equal(d.T(), 1, len(subscriptions))
+	bindings, _ := storage.Bindings(ski)
+	assert.Equal(d.T(), 1, len(bindings))
+
+	// disconnecting keeps the persisted entries
+	sut.RemoveRemoteDeviceConnection(ski)
+	subscriptions, _ = storage.Subscriptions(ski)
+	assert.Equal(d.T(), 1, len(subscriptions))
+
+	remoteDevice, remoteFeature = setupRemoteDevice()
+
+	// the msgCounter continues after the reserved block when reconnecting
+	msgCounter, _ = storage.MsgCounter(ski)
+	assert.Equal(d.T(), model.MsgCounterType(2*msgCounterReserveBlock), msgCounter)
+
+	// an entry which can not be restored anymore is removed
+	invalidEntry := api.StorageEntry{
+		ClientAddress:     *remoteFeature.Address(),
+		ServerAddress:     *localFeature.Address(),
+		ServerFeatureType: model.FeatureTypeTypeLoadControl,
+	}
+	invalidEntry.ClientAddress.Feature = util.Ptr(model.AddressFeatureType(100))
+	assert.Nil(d.T(), storage.SaveBinding(ski, invalidEntry))
+
+	// an entry which is rejected by the authorization, as the server feature allows only one binding, is kept
+	otherFeature, _ := createRemoteEntityAndFeature(remoteDevice, 2, model.FeatureTypeTypeLoadControl, model.FunctionTypeLoadControlLimitListData)
+	rejectedEntry := api.StorageEntry{
+		ClientAddress:     *otherFeature.Address(),
+		ServerAddress:     *localFeature.Address(),
+		ServerFeatureType: model.FeatureTypeTypeLoadControl,
+	}
+	assert.Nil(d.T(), storage.SaveBinding(ski, rejectedEntry))
+
+	assert.Equal(d.T(), 0, len(sut.SubscriptionManager().Subscriptions(remoteDevice)))
+	assert.False(d.T(), sut.BindingManager().HasLocalFeatureRemoteBinding(localFeature.Address(), remoteFeature.Address()))
+
+	// receiving the detailed discovery data restores the entries
+	nodeManagement := remoteDevice.FeatureByEntityTypeAndRole(
+		remoteDevice.Entity(NewAddressEntityType([]uint{DeviceInformationEntityId})), model.FeatureTypeTypeNodeManagement, model.RoleTypeSpecial)
+	receiveDetailedDiscovery := func() {
+		sut.Events().Publish(api.EventPayload{
+			Ski:        ski,
+			EventType:  api.EventTypeDeviceChange,
+			ChangeType: api.ElementChangeAdd,
+			Device:     remoteDevice,
+			Feature:    nodeManagement,
+			Data:       &model.NodeManagementDetailedDiscoveryDataType{},
+		})
+		assert.Nil(d.T(), sut.backgroundTasks.wait(context.Background()))
+	}
+	receiveDetailedDiscovery()
+
+	assert.Equal(d.T(), 1, len(sut.SubscriptionManager().Subscriptions(remoteDevice)))
+	assert.True(d.T(), sut.BindingManager().HasLocalFeatureRemoteBinding(localFeature.Address(), remoteFeature.Address()))
+	assert.False(d.T(), sut.BindingManager().HasLocalFeatureRemoteBinding(localFeature.Address(), otherFeature.Address()))
+	bindings, _ = storage.Bindings(ski)
+	if assert.Equal(d.T(), 2, len(bindings)) {
+		assert.Equal(d.T(), *remoteFeature.Address(), bindings[0].ClientAddress)
+		assert.Equal(d.T(), rejectedEntry, bindings[1])
+	}
+
+	// restoring again, e.g. for a repeated detailed discovery reply, keeps the active entries
+	receiveDetailedDiscovery()
+
+	assert.Equal(d.T(), 1, len(sut.SubscriptionManager().Subscriptions(remoteDevice)))
+	assert.True(d.T(), sut.BindingManager().HasLocalFeatureRemoteBinding(localFeature.Address(), remoteFeature.Address()))
+	subscriptions, _ = storage.Subscriptions(ski)
+	assert.Equal(d.T(), 1, len(subscriptions))
+	bindings, _ = storage.Bindings(ski)
+	assert.Equal(d.T(), 2, len(bindings))
+
+	// removing a subscription removes the persisted entry
+	err = sut.SubscriptionManager().RemoveSubscription(model.SubscriptionManagementDeleteCallType{
+		ClientAddress: remoteFeature.Address(),
+		ServerAddress: localFeature.Address(),
+	}, remoteDevice)
+	assert.Nil(d.T(), err)
+	subscriptions, _ = storage.Subscriptions(ski)
+	assert.Equal(d.T(), 0, len(subscriptions))
+}
+
+func (d *DeviceLocalTestSuite) Test_AccessControl() {
+	sut := NewDeviceLocal("brand", "model", "serial", "code", "address", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	localEntity := NewEntityLocal(sut, model.EntityTypeTypeCEM, NewAddressEntityType([]uint{1}), time.Second*4)
+	sut.AddEntity(localEntity)
+	localFeature := localEntity.GetOrAddFeature(model.FeatureTypeTypeBill, model.RoleTypeServer)
+	localFeature.AddFunctionType(model.FunctionTypeBillListData, true, false)
+	localFeature.AddFunctionType(model.FunctionTypeBillDescriptionListData, true, false)
+
+	acl := NewAccessControlList(true)
+	acl.AddRule(AccessRule{
+		Ski:        "test",
+		Function:   util.Ptr(model.FunctionTypeBillListData),
+		Operations: []api.AccessOperationType{api.AccessOperationRead, api.AccessOperationSubscribe},
+		Allow:      false,
+	})
+	sut.SetAccessControl(acl)
+	assert.Equal(d.T(), acl, sut.AccessControl())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := sut.Events().SubscribeChannel(ctx, api.EventFilter{EventType: util.Ptr(api.EventTypeAccessDenied)})
+
+	ski := "test"
+	writeHandler := &WriteMessageHandler{}
+	_ = sut.SetupRemoteDevice(ski, writeHandler)
+	remote := sut.RemoteDeviceForSki(ski)
+	remoteEntity := NewEntityRemote(remote, model.EntityTypeTypeCEM, []model.AddressEntityType{1})
+	remoteFeature := NewFeatureRemote(1, remoteEntity, model.FeatureTypeTypeBill, model.RoleTypeClient)
+	remoteEntity.AddFeature(remoteFeature)
+	remote.AddEntity(remoteEntity)
+
+	datagram := model.DatagramType{
+		Header: model.HeaderType{
+			AddressSource:      remoteFeature.Address(),
+			AddressDestination: localFeature.Address(),
+			MsgCounter:         util.Ptr(model.MsgCounterType(1)),
+			CmdClassifier:      util.Ptr(model.CmdClassifierTypeRead),
+		},
+		Payload: model.PayloadType{
+			Cmd: []model.CmdType{
+				{
+					BillListData: &model.BillListDataType{},
+				},
+			},
+		},
+	}
+
+	// read is denied
+	err := sut.ProcessCmd(datagram, remote)
+	assert.NotNil(d.T(), err)
+	msg := writeHandler.ResultWithReference(datagram.Header.MsgCounter)
+	assert.Contains(d.T(), string(msg), `"errorNumber":7`)
+
+	select {
+	case payload := <-events:
+		assert.Equal(d.T(), ski, payload.Ski)
+		assert.Equal(d.T(), remoteFeature, payload.Feature)
+		assert.Equal(d.T(), localFeature, payload.LocalFeature)
+		assert.Equal(d.T(), model.FunctionTypeBillListData, payload.Function)
+		assert.Equal(d.T(), api.AccessOperationRead, payload.Data)
+	case <-time.After(time.Second):
+		d.T().Fatal("access denied event was not published")
+	}
+
+	// read of other functions is allowed
+	datagram.Header.MsgCounter = util.Ptr(model.MsgCounterType(2))
+	datagram.Payload.Cmd = []model.CmdType{{BillDescriptionListData: &model.BillDescriptionListDataType{}}}
+	err = sut.ProcessCmd(datagram, remote)
+	assert.Nil(d.T(), err)
+	msg = writeHandler.MessageWithReference(datagram.Header.MsgCounter)
+	assert.Contains(d.T(), string(msg), `"cmdClassifier":"reply"`)
+
+	// the subscription is allowed, but notifies of denied functions are not sent
+	err = sut.SubscriptionManager().AddSubscription(remote, model.SubscriptionManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeBill),
+	})
+	assert.Nil(d.T(), err)
+
+	sentMessages := len(writeHandler.sentMessages)
+	sut.NotifySubscribers(localFeature.Address(), model.CmdType{BillListData: &model.BillListDataType{}})
+	assert.Equal(d.T(), sentMessages, len(writeHandler.sentMessages))
+	sut.NotifySubscribers(localFeature.Address(), model.CmdType{BillDescriptionListData: &model.BillDescriptionListDataType{}})
+	assert.Equal(d.T(), sentMessages+1, len(writeHandler.sentMessages))
+
+	// subscriptions can be denied for the whole feature
+	acl.AddRule(AccessRule{
+		Operations: []api.AccessOperationType{api.AccessOperationSubscribe},
+		Allow:      false,
+	})
+	err = sut.SubscriptionManager().RemoveSubscription(model.SubscriptionManagementDeleteCallType{
+		ClientAddress: remoteFeature.Address(),
+		ServerAddress: localFeature.Address(),
+	}, remote)
+	assert.Nil(d.T(), err)
+	err = sut.SubscriptionManager().AddSubscription(remote, model.SubscriptionManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     localFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeBill),
+	})
+	assert.ErrorIs(d.T(), err, ErrRequestRejected)
+}
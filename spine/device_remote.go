@@ -3,7 +3,9 @@ package spine
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
 	shipapi "github.com/enbility/ship-go/api"
@@ -23,6 +25,8 @@ type DeviceRemote struct {
 	sender api.SenderInterface
 
 	localDevice api.DeviceLocalInterface
+
+	inboundQueue *inboundQueue // optional, nil if datagrams are processed on the SHIP connection goroutine
 }
 
 func NewDeviceRemote(localDevice api.DeviceLocalInterface, ski string, sender api.SenderInterface) *DeviceRemote {
@@ -49,11 +53,40 @@ var _ shipapi.ShipConnectionDataReaderInterface = (*DeviceRemote)(nil)
 
 // processing incoming SPINE message from the associated SHIP connection
 func (d *DeviceRemote) HandleShipPayloadMessage(message []byte) {
+	// the queue is closed once the local device is shut down, the messages are rejected directly instead
+	if d.inboundQueue != nil && !d.localDeviceShutdown() {
+		d.inboundQueue.enqueue(message)
+		return
+	}
+
+	d.handleShipPayloadMessage(message)
+}
+
+func (d *DeviceRemote) handleShipPayloadMessage(message []byte) {
 	if _, err := d.HandleSpineMesssage(message); err != nil {
 		logging.Log().Errorf("error handling spine message", err)
 	}
 }
 
+// process the incoming messages in order on a worker of this device,
+// has to be called before the first message is received
+func (d *DeviceRemote) startInboundQueue(size uint) {
+	d.inboundQueue = newInboundQueue(size, d.handleShipPayloadMessage)
+}
+
+// stop processing queued messages, as the device is disconnected
+func (d *DeviceRemote) stopInboundQueue() {
+	if d.inboundQueue != nil {
+		d.inboundQueue.close()
+	}
+}
+
+// returns true if the local device was shut down
+func (d *DeviceRemote) localDeviceShutdown() bool {
+	localDevice, ok := d.localDevice.(*DeviceLocal)
+	return ok && localDevice.shutdownStarted()
+}
+
 var _ api.DeviceRemoteInterface = (*DeviceRemote)(nil)
 
 /* DeviceRemoteInterface */
@@ -110,12 +143,12 @@ func (d *DeviceRemote) Entity(id []model.AddressEntityType) api.EntityRemoteInte
 	return nil
 }
 
-// Return all entities of this device
+// Return a copy of all entities of this device
 func (d *DeviceRemote) Entities() []api.EntityRemoteInterface {
 	d.entitiesMutex.Lock()
 	defer d.entitiesMutex.Unlock()
 
-	return d.entities
+	return slices.Clone(d.entities)
 }
 
 // Return the feature for a given address
@@ -129,14 +162,7 @@ func (d *DeviceRemote) FeatureByAddress(address *model.FeatureAddressType) api.F
 
 // Get the feature for a given entity, feature type and feature role
 func (r *DeviceRemote) FeatureByEntityTypeAndRole(entity api.EntityRemoteInterface, featureType model.FeatureTypeType, role model.RoleType) api.FeatureRemoteInterface {
-	if len(r.entities) < 1 {
-		return nil
-	}
-
-	r.entitiesMutex.Lock()
-	defer r.entitiesMutex.Unlock()
-
-	for _, e := range r.entities {
+	for _, e := range r.Entities() {
 		if entity != e {
 			continue
 		}
@@ -151,11 +177,47 @@ func (r *DeviceRemote) FeatureByEntityTypeAndRole(entity api.EntityRemoteInterfa
 }
 
 func (d *DeviceRemote) HandleSpineMesssage(message []byte) (*model.MsgCounterType, error) {
+	if recorder := d.localDevice.DatagramRecorder(); recorder != nil {
+		recorder.RecordDatagram(api.DatagramDirectionIncoming, d.ski, message)
+	}
+
 	datagram := model.Datagram{}
 	if err := json.Unmarshal([]byte(message), &datagram); err != nil {
 		return nil, err
 	}
 
+	if localDevice, ok := d.localDevice.(*DeviceLocal); ok {
+		if !localDevice.interceptInbound(d, &datagram.Datagram) {
+			return datagram.Datagram.Header.MsgCounter, nil
+		}
+	}
+
+	// invalid datagrams are answered with a result error and not processed
+	if err := validateDatagram(&datagram.Datagram); err != nil {
+		header := &datagram.Datagram.Header
+		if canReplyToDatagram(header) {
+			_ = d.sender.ResultError(header, header.AddressDestination, err)
+		}
+
+		return header.MsgCounter, fmt.Errorf("%w: %s", ErrInvalidDatagram, err.String())
+	}
+
+	// a device which is shut down does not process any datagrams anymore
+	if d.localDeviceShutdown() {
+		header := &datagram.Datagram.Header
+		if canReplyToDatagram(header) {
+			_ = d.sender.ResultError(header, header.AddressDestination,
+				model.NewErrorType(model.ErrorNumberTypeCommandRejected, "device is shut down"))
+		}
+
+		return header.MsgCounter, ErrDeviceShutdown
+	}
+
+	// datagrams for other devices are forwarded by routers
+	if localDevice, ok := d.localDevice.(*DeviceLocal); ok && localDevice.routeDatagram(d, &datagram.Datagram) {
+		return datagram.Datagram.Header.MsgCounter, nil
+	}
+
 	if datagram.Datagram.Header.MsgCounterReference != nil {
 		d.sender.ProcessResponseForMsgCounterReference(datagram.Datagram.Header.MsgCounterReference)
 	}
@@ -168,6 +230,14 @@ func (d *DeviceRemote) HandleSpineMesssage(message []byte) (*model.MsgCounterTyp
 	return datagram.Datagram.Header.MsgCounter, nil
 }
 
+func (d *DeviceRemote) InboundQueueMetrics() api.InboundQueueMetrics {
+	if d.inboundQueue == nil {
+		return api.InboundQueueMetrics{}
+	}
+
+	return d.inboundQueue.metrics()
+}
+
 func (d *DeviceRemote) Sender() api.SenderInterface {
 	return d.sender
 }
@@ -187,15 +257,7 @@ func (d *DeviceRemote) UseCases() []model.UseCaseInformationDataType {
 
 func (d *DeviceRemote) UpdateDevice(description *model.NetworkManagementDeviceDescriptionDataType) {
 	if description != nil {
-		if description.DeviceAddress != nil && description.DeviceAddress.Device != nil {
-			d.address = description.DeviceAddress.Device
-		}
-		if description.DeviceType != nil {
-			d.dType = description.DeviceType
-		}
-		if description.NetworkFeatureSet != nil {
-			d.featureSet = description.NetworkFeatureSet
-		}
+		d.setDescription(description)
 	}
 }
 
@@ -211,6 +273,10 @@ func (d *DeviceRemote) AddEntityAndFeatures(initialData bool, data *model.NodeMa
 
 		entity := d.Entity(entityAddress)
 		if entity == nil {
+			if ei.Description.EntityType == nil {
+				return nil, errors.New("nodemanagement.replyDetailedDiscoveryData: invalid EntityInformation.Description.EntityType")
+			}
+
 			entity = d.addNewEntity(*ei.Description.EntityType, entityAddress)
 			rEntites = append(rEntites, entity)
 		}
@@ -229,7 +295,8 @@ func (d *DeviceRemote) AddEntityAndFeatures(initialData bool, data *model.NodeMa
 		entity.RemoveAllFeatures()
 
 		for _, fi := range data.FeatureInformation {
-			if reflect.DeepEqual(fi.Description.FeatureAddress.Entity, entityAddress) {
+			if fi.Description != nil && fi.Description.FeatureAddress != nil &&
+				reflect.DeepEqual(fi.Description.FeatureAddress.Entity, entityAddress) {
 				if f, ok := unmarshalFeature(entity, fi); ok {
 					entity.AddFeature(f)
 				}
@@ -276,7 +343,8 @@ func unmarshalFeature(entity api.EntityRemoteInterface,
 
 	fid := featureData.Description
 
-	if fid == nil {
+	if fid == nil || fid.FeatureAddress == nil || fid.FeatureAddress.Feature == nil ||
+		fid.FeatureType == nil || fid.Role == nil {
 		return nil, false
 	}
 
@@ -20,6 +20,9 @@ type Entity struct {
 	fIdGenerator func() uint
 
 	muxGenerator sync.Mutex
+
+	// the address and description of remote entities are updated while other connections read them
+	descriptionMux sync.RWMutex
 }
 
 var _ api.EntityInterface = (*Entity)(nil)
@@ -44,18 +47,39 @@ func NewEntity(eType model.EntityTypeType, deviceAddress *model.AddressDeviceTyp
 }
 
 func (r *Entity) Address() *model.EntityAddressType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.address
 }
 
+// replace the address with one of another device address,
+// the previous address is not modified as it may still be in use
+func (r *Entity) setDeviceAddress(deviceAddress *model.AddressDeviceType) {
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
+	r.address = &model.EntityAddressType{
+		Device: deviceAddress,
+		Entity: r.address.Entity,
+	}
+}
+
 func (r *Entity) EntityType() model.EntityTypeType {
 	return r.eType
 }
 
 func (r *Entity) Description() *model.DescriptionType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.description
 }
 
 func (r *Entity) SetDescription(d *model.DescriptionType) {
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
 	r.description = d
 }
 
@@ -1,6 +1,7 @@
 package spine
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -115,11 +116,12 @@ func (r *EntityLocal) FeatureOfAddress(addressFeature *model.AddressFeatureType)
 	return nil
 }
 
+// Returns a copy of the features list, which may be used while features are added
 func (r *EntityLocal) Features() []api.FeatureLocalInterface {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	return r.features
+	return slices.Clone(r.features)
 }
 
 // add a new usecase
@@ -231,14 +233,14 @@ func (r *EntityLocal) RemoveAllUseCaseSupports() {
 
 // Remove all subscriptions
 func (r *EntityLocal) RemoveAllSubscriptions() {
-	for _, item := range r.features {
+	for _, item := range r.Features() {
 		item.RemoveAllRemoteSubscriptions()
 	}
 }
 
 // Remove all bindings
 func (r *EntityLocal) RemoveAllBindings() {
-	for _, item := range r.features {
+	for _, item := range r.Features() {
 		item.RemoveAllRemoteBindings()
 	}
 }
@@ -1,6 +1,7 @@
 package spine
 
 import (
+	"slices"
 	"sync"
 
 	"github.com/enbility/spine-go/api"
@@ -31,7 +32,7 @@ func (r *EntityRemote) Device() api.DeviceRemoteInterface {
 }
 
 func (r *EntityRemote) UpdateDeviceAddress(address model.AddressDeviceType) {
-	r.address.Device = &address
+	r.setDeviceAddress(&address)
 }
 
 func (r *EntityRemote) AddFeature(f api.FeatureRemoteInterface) {
@@ -75,9 +76,10 @@ func (r *EntityRemote) FeatureOfAddress(addressFeature *model.AddressFeatureType
 	return nil
 }
 
+// Returns a copy of the features list, which may be used while the features are updated
 func (r *EntityRemote) Features() []api.FeatureRemoteInterface {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
-	return r.features
+	return slices.Clone(r.features)
 }
@@ -1,11 +1,18 @@
 package spine
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"sync"
 
 	"github.com/enbility/spine-go/api"
 )
 
+// Global event dispatcher
+//
+// Deprecated: use the event dispatcher of the local device via DeviceLocalInterface.Events().
+// This only exists for compatibility: it receives the events of all local devices of the process.
 var Events events
 
 type eventHandlerItem struct {
@@ -17,7 +24,67 @@ type events struct {
 	mu       sync.Mutex
 	muHandle sync.Mutex
 
-	handlers []eventHandlerItem // event handling outside of the core stack
+	handlers    []eventHandlerItem // event handling outside of the core stack
+	subscribers []*eventSubscriber // filtered subscriptions with ordered delivery
+
+	forward *events // optional dispatcher all published events are forwarded to
+
+	inFlight inFlightCounter // application handler invocations which are running or queued
+}
+
+// Counts running operations and allows waiting until none is running anymore
+type inFlightCounter struct {
+	count int
+	idle  chan struct{} // closed once count drops to 0
+
+	mux sync.Mutex
+}
+
+func (c *inFlightCounter) add() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.count == 0 {
+		c.idle = make(chan struct{})
+	}
+	c.count++
+}
+
+func (c *inFlightCounter) done() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.count--
+	if c.count == 0 {
+		close(c.idle)
+	}
+}
+
+// wait until no operation is running anymore or the context is done
+func (c *inFlightCounter) wait(ctx context.Context) error {
+	c.mux.Lock()
+	if c.count == 0 {
+		c.mux.Unlock()
+		return nil
+	}
+	idle := c.idle
+	c.mux.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var _ api.EventsInterface = (*events)(nil)
+
+// Create a new event dispatcher, which forwards all events to the global Events dispatcher
+func newEvents() *events {
+	return &events{
+		forward: &Events,
+	}
 }
 
 // will be used in EEBUS core directly to access the level EventHandlerLevelCore
@@ -49,6 +116,67 @@ func (r *events) Subscribe(handler api.EventHandlerInterface) error {
 	return r.subscribe(api.EventHandlerLevelApplication, handler)
 }
 
+// Subscribe to events matching the filter of the options and handle them in the
+// EventHandlerInterface implementation
+//
+// The events are delivered in the order they were published, using a bounded queue
+// and the overflow policy defined in the options
+func (r *events) SubscribeWithOptions(handler api.EventHandlerInterface, options api.EventSubscriptionOptions) error {
+	if handler == nil {
+		return errors.New("handler may not be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, newEventSubscriber(handler, options, &r.inFlight))
+
+	return nil
+}
+
+// Subscribe to events matching the filter and receive them in the order they were published
+// via the returned channel
+//
+// The channel is closed once the context is done, a nil context keeps the subscription
+// like context.Background. If the channel is not read fast enough, new events are dropped
+func (r *events) SubscribeChannel(ctx context.Context, filter api.EventFilter) <-chan api.EventPayload {
+	subscriber := newEventSubscriber(nil, api.EventSubscriptionOptions{Filter: filter}, &r.inFlight)
+
+	r.mu.Lock()
+	r.subscribers = append(r.subscribers, subscriber)
+	r.mu.Unlock()
+
+	// without a context, the subscription is kept as long as the dispatcher exists
+	if ctx == nil {
+		return subscriber.queue
+	}
+
+	go func() {
+		<-ctx.Done()
+		r.removeSubscribers(func(item *eventSubscriber) bool { return item == subscriber })
+	}()
+
+	return subscriber.queue
+}
+
+// remove and close all subscribers matching the filter
+func (r *events) removeSubscribers(match func(item *eventSubscriber) bool) {
+	r.mu.Lock()
+	var removed []*eventSubscriber
+	r.subscribers = slices.DeleteFunc(r.subscribers, func(item *eventSubscriber) bool {
+		if match(item) {
+			removed = append(removed, item)
+			return true
+		}
+		return false
+	})
+	r.mu.Unlock()
+
+	for _, item := range removed {
+		item.close()
+	}
+}
+
 // will be used in EEBUS core directly to access the level EventHandlerLevelCore
 func (r *events) unsubscribe(level api.EventHandlerLevel, handler api.EventHandlerInterface) error {
 	r.mu.Lock()
@@ -66,16 +194,18 @@ func (r *events) unsubscribe(level api.EventHandlerLevel, handler api.EventHandl
 	return nil
 }
 
-// Unsubscribe from getting events
+// Unsubscribe from getting events, including subscriptions with options
 func (r *events) Unsubscribe(handler api.EventHandlerInterface) error {
+	r.removeSubscribers(func(item *eventSubscriber) bool { return item.handler == handler })
+
 	return r.unsubscribe(api.EventHandlerLevelApplication, handler)
 }
 
 // Publish an event to all subscribers
 func (r *events) Publish(payload api.EventPayload) {
 	r.mu.Lock()
-	var handler []eventHandlerItem
-	copy(r.handlers, handler)
+	handlers := slices.Clone(r.handlers)
+	subscribers := slices.Clone(r.subscribers)
 	r.mu.Unlock()
 
 	// Use different locks, so unpublish is possible in the event handlers
@@ -87,7 +217,7 @@ func (r *events) Publish(payload api.EventPayload) {
 	}
 
 	for _, level := range handlerLevels {
-		for _, item := range r.handlers {
+		for _, item := range handlers {
 			if item.Level != level {
 				continue
 			}
@@ -97,9 +227,31 @@ func (r *events) Publish(payload api.EventPayload) {
 				// and expected actions are taken
 				item.Handler.HandleEvent(payload)
 			} else {
-				go item.Handler.HandleEvent(payload)
+				r.inFlight.add()
+				go func(handler api.EventHandlerInterface) {
+					defer r.inFlight.done()
+					handler.HandleEvent(payload)
+				}(item.Handler)
 			}
 		}
 	}
+
 	r.muHandle.Unlock()
+
+	// subscribers with ordered delivery, enqueued without holding the handling lock,
+	// so a subscriber with a full queue and the block policy only blocks this publisher
+	for _, item := range subscribers {
+		item.enqueue(payload)
+	}
+
+	if r.forward != nil {
+		r.forward.Publish(payload)
+	}
+}
+
+// wait until all application handlers processed the published events or the context is done
+//
+// events forwarded to another dispatcher are not waited for
+func (r *events) wait(ctx context.Context) error {
+	return r.inFlight.wait(ctx)
 }
@@ -0,0 +1,202 @@
+package spine
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+const defaultEventQueueSize = 100
+
+// A subscription with a filter and ordered event delivery via a bounded queue
+//
+// If a handler is set, the events are delivered to it from a single goroutine,
+// otherwise the queue itself is provided to the subscriber as a channel
+type eventSubscriber struct {
+	handler api.EventHandlerInterface
+	filter  api.EventFilter
+	policy  api.EventOverflowPolicy
+
+	queue chan api.EventPayload
+	done  chan struct{}
+
+	inFlight *inFlightCounter // counts the queued and running handler invocations
+
+	closed    bool
+	closeOnce sync.Once
+	mux       sync.Mutex
+}
+
+func newEventSubscriber(handler api.EventHandlerInterface, options api.EventSubscriptionOptions, inFlight *inFlightCounter) *eventSubscriber {
+	queueSize := options.QueueSize
+	if queueSize == 0 {
+		queueSize = defaultEventQueueSize
+	}
+
+	res := &eventSubscriber{
+		handler:  handler,
+		filter:   options.Filter,
+		policy:   options.OverflowPolicy,
+		queue:    make(chan api.EventPayload, queueSize),
+		done:     make(chan struct{}),
+		inFlight: inFlight,
+	}
+
+	if handler != nil {
+		go res.run()
+	}
+
+	return res
+}
+
+// deliver the queued events to the handler
+func (s *eventSubscriber) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case payload := <-s.queue:
+			s.handler.HandleEvent(payload)
+			s.inFlight.done()
+		}
+	}
+}
+
+// add an event to the queue, if it matches the filter
+func (s *eventSubscriber) enqueue(payload api.EventPayload) {
+	if !eventFilterMatch(s.filter, payload) {
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	// only events delivered to a handler are in flight, a channel is read by the subscriber itself
+	if s.handler != nil {
+		s.inFlight.add()
+	}
+
+	if !s.send(payload) {
+		s.dropped()
+	}
+}
+
+// add an event to the queue according to the overflow policy, returns false if it was dropped
+//
+// mux has to be locked by the caller
+func (s *eventSubscriber) send(payload api.EventPayload) bool {
+	switch s.policy {
+	case api.EventOverflowPolicyBlock:
+		select {
+		case s.queue <- payload:
+			return true
+		case <-s.done:
+			return false
+		}
+	case api.EventOverflowPolicyDropOldest:
+		for {
+			select {
+			case s.queue <- payload:
+				return true
+			default:
+			}
+
+			// queue is full, remove the oldest event
+			select {
+			case <-s.queue:
+				s.dropped()
+			default:
+			}
+		}
+	default:
+		select {
+		case s.queue <- payload:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// an event will not be delivered to the handler
+func (s *eventSubscriber) dropped() {
+	if s.handler != nil {
+		s.inFlight.done()
+	}
+}
+
+// stop the delivery of events
+func (s *eventSubscriber) close() {
+	s.closeOnce.Do(func() {
+		// unblock a publisher waiting for space in the queue
+		close(s.done)
+
+		s.mux.Lock()
+		defer s.mux.Unlock()
+
+		s.closed = true
+		if s.handler == nil {
+			close(s.queue)
+			return
+		}
+
+		// the queued events are not delivered anymore
+		for {
+			select {
+			case <-s.queue:
+				s.dropped()
+			default:
+				return
+			}
+		}
+	})
+}
+
+// check if an event matches all set fields of a filter
+func eventFilterMatch(filter api.EventFilter, payload api.EventPayload) bool {
+	if len(filter.Ski) > 0 && filter.Ski != payload.Ski {
+		return false
+	}
+
+	if filter.EventType != nil && *filter.EventType != payload.EventType {
+		return false
+	}
+
+	if filter.Function != nil && *filter.Function != payload.Function {
+		return false
+	}
+
+	if filter.FeatureType != nil {
+		var feature api.FeatureInterface
+		if payload.Feature != nil {
+			feature = payload.Feature
+		} else if payload.LocalFeature != nil {
+			feature = payload.LocalFeature
+		}
+
+		if feature == nil || feature.Type() != *filter.FeatureType {
+			return false
+		}
+	}
+
+	if filter.Entity != nil {
+		var entityAddress *model.EntityAddressType
+		if payload.Entity != nil {
+			entityAddress = payload.Entity.Address()
+		} else if payload.Feature != nil {
+			entityAddress = payload.Feature.Entity().Address()
+		}
+
+		if entityAddress == nil || !slices.Equal(entityAddress.Entity, filter.Entity) {
+			return false
+		}
+	}
+
+	return true
+}
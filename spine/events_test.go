@@ -1,11 +1,15 @@
 package spine
 
 import (
+	"context"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -88,9 +92,364 @@ func (s *EventsTestSuite) Test_Publish_Application() {
 
 	Events.Publish(api.EventPayload{})
 
-	time.Sleep(time.Millisecond * 200)
-	assert.True(s.T(), s.isHandlerInvoked())
+	assert.Eventually(s.T(), s.isHandlerInvoked, time.Second, time.Millisecond*10)
+
+	err = Events.Unsubscribe(s)
+	assert.Nil(s.T(), err)
+}
+
+func (s *EventsTestSuite) Test_DeviceEvents() {
+	device1, _ := createLocalDeviceAndEntity(1)
+	device2, _ := createLocalDeviceAndEntity(1)
+
+	err := device1.Events().Subscribe(s)
+	assert.Nil(s.T(), err)
+
+	// events of other devices are not received
+	device2.Events().Publish(api.EventPayload{})
+
+	assert.Nil(s.T(), device1.Events().(*events).wait(context.Background()))
+	assert.False(s.T(), s.isHandlerInvoked())
+
+	device1.Events().Publish(api.EventPayload{})
+
+	assert.Eventually(s.T(), s.isHandlerInvoked, time.Second, time.Millisecond*10)
+
+	err = device1.Events().Unsubscribe(s)
+	assert.Nil(s.T(), err)
+
+	// the global dispatcher receives the events of all devices
+	s.setHandlerInvoked(false)
+	err = Events.Subscribe(s)
+	assert.Nil(s.T(), err)
+
+	device2.Events().Publish(api.EventPayload{})
+
+	assert.Eventually(s.T(), s.isHandlerInvoked, time.Second, time.Millisecond*10)
 
 	err = Events.Unsubscribe(s)
 	assert.Nil(s.T(), err)
 }
+
+type orderedEventHandler struct {
+	block chan struct{}
+
+	received []int
+	mux      sync.Mutex
+}
+
+func (h *orderedEventHandler) HandleEvent(event api.EventPayload) {
+	if h.block != nil {
+		<-h.block
+	}
+
+	// other events of the stack are ignored
+	value, ok := event.Data.(int)
+	if !ok {
+		return
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.received = append(h.received, value)
+}
+
+func (h *orderedEventHandler) Received() []int {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return slices.Clone(h.received)
+}
+
+func (s *EventsTestSuite) Test_SubscribeWithOptions() {
+	sut := newEvents()
+	sut.forward = nil
+
+	err := sut.SubscribeWithOptions(nil, api.EventSubscriptionOptions{})
+	assert.NotNil(s.T(), err)
+
+	handler := &orderedEventHandler{}
+	filteredHandler := &orderedEventHandler{}
+
+	err = sut.SubscribeWithOptions(handler, api.EventSubscriptionOptions{})
+	assert.Nil(s.T(), err)
+
+	err = sut.SubscribeWithOptions(filteredHandler, api.EventSubscriptionOptions{
+		Filter: api.EventFilter{
+			Ski:       "ski",
+			EventType: util.Ptr(api.EventTypeDataChange),
+		},
+	})
+	assert.Nil(s.T(), err)
+
+	for i := 0; i < 50; i++ {
+		payload := api.EventPayload{
+			Ski:       "ski",
+			EventType: api.EventTypeDataChange,
+			Data:      i,
+		}
+		if i%2 == 0 {
+			payload.EventType = api.EventTypeDeviceChange
+		}
+		sut.Publish(payload)
+	}
+
+	assert.Eventually(s.T(), func() bool { return len(handler.Received()) == 50 }, time.Second, time.Millisecond*10)
+	assert.Eventually(s.T(), func() bool { return len(filteredHandler.Received()) == 25 }, time.Second, time.Millisecond*10)
+
+	// events are delivered in order
+	for i, value := range handler.Received() {
+		assert.Equal(s.T(), i, value)
+	}
+	for i, value := range filteredHandler.Received() {
+		assert.Equal(s.T(), i*2+1, value)
+	}
+
+	err = sut.Unsubscribe(handler)
+	assert.Nil(s.T(), err)
+	err = sut.Unsubscribe(filteredHandler)
+	assert.Nil(s.T(), err)
+
+	sut.Publish(api.EventPayload{Data: 50})
+	assert.Nil(s.T(), sut.wait(context.Background()))
+	assert.Equal(s.T(), 50, len(handler.Received()))
+}
+
+func (s *EventsTestSuite) Test_SubscribeWithOptions_Overflow() {
+	sut := newEvents()
+	sut.forward = nil
+
+	dropNewest := &orderedEventHandler{block: make(chan struct{})}
+	dropOldest := &orderedEventHandler{block: make(chan struct{})}
+
+	err := sut.SubscribeWithOptions(dropNewest, api.EventSubscriptionOptions{QueueSize: 2})
+	assert.Nil(s.T(), err)
+	err = sut.SubscribeWithOptions(dropOldest, api.EventSubscriptionOptions{
+		QueueSize:      2,
+		OverflowPolicy: api.EventOverflowPolicyDropOldest,
+	})
+	assert.Nil(s.T(), err)
+
+	// the first event is taken from the queue and blocks the handler
+	sut.Publish(api.EventPayload{Data: 0})
+	assert.Eventually(s.T(), func() bool {
+		return len(sut.subscribers[0].queue) == 0 && len(sut.subscribers[1].queue) == 0
+	}, time.Second, time.Millisecond*10)
+
+	for i := 1; i < 5; i++ {
+		sut.Publish(api.EventPayload{Data: i})
+	}
+
+	close(dropNewest.block)
+	close(dropOldest.block)
+
+	assert.Eventually(s.T(), func() bool { return len(dropNewest.Received()) == 3 }, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), []int{0, 1, 2}, dropNewest.Received())
+	assert.Eventually(s.T(), func() bool { return len(dropOldest.Received()) == 3 }, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), []int{0, 3, 4}, dropOldest.Received())
+
+	// blocking publisher is released on unsubscribe
+	blocking := &orderedEventHandler{block: make(chan struct{})}
+	err = sut.SubscribeWithOptions(blocking, api.EventSubscriptionOptions{
+		QueueSize:      1,
+		OverflowPolicy: api.EventOverflowPolicyBlock,
+	})
+	assert.Nil(s.T(), err)
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			sut.Publish(api.EventPayload{Data: i})
+		}
+		close(published)
+	}()
+
+	select {
+	case <-published:
+		s.T().Fatal("publish should block")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	close(blocking.block)
+	assert.Eventually(s.T(), func() bool { return len(blocking.Received()) == 3 }, time.Second, time.Millisecond*10)
+	<-published
+
+	_ = sut.Unsubscribe(dropNewest)
+	_ = sut.Unsubscribe(dropOldest)
+	_ = sut.Unsubscribe(blocking)
+}
+
+func (s *EventsTestSuite) Test_SubscribeWithOptions_BlockOtherPublishers() {
+	sut := newEvents()
+	sut.forward = nil
+
+	err := sut.subscribe(api.EventHandlerLevelCore, s)
+	assert.Nil(s.T(), err)
+
+	blocking := &orderedEventHandler{block: make(chan struct{})}
+	err = sut.SubscribeWithOptions(blocking, api.EventSubscriptionOptions{
+		Filter:         api.EventFilter{Ski: "slow"},
+		QueueSize:      1,
+		OverflowPolicy: api.EventOverflowPolicyBlock,
+	})
+	assert.Nil(s.T(), err)
+
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			sut.Publish(api.EventPayload{Ski: "slow", Data: i})
+		}
+		close(published)
+	}()
+
+	assert.Eventually(s.T(), func() bool { return len(sut.subscribers[0].queue) == 1 }, time.Second, time.Millisecond*10)
+	s.setHandlerInvoked(false)
+
+	// events of other remote devices are still processed by the core handlers
+	otherPublished := make(chan struct{})
+	go func() {
+		sut.Publish(api.EventPayload{Ski: "other"})
+		close(otherPublished)
+	}()
+
+	select {
+	case <-otherPublished:
+	case <-time.After(time.Second):
+		s.T().Fatal("publish for another remote device is blocked")
+	}
+	assert.True(s.T(), s.isHandlerInvoked())
+
+	close(blocking.block)
+	<-published
+	assert.Eventually(s.T(), func() bool { return len(blocking.Received()) == 3 }, time.Second, time.Millisecond*10)
+
+	_ = sut.unsubscribe(api.EventHandlerLevelCore, s)
+	_ = sut.Unsubscribe(blocking)
+}
+
+func (s *EventsTestSuite) Test_SubscribeChannel() {
+	device, _ := createLocalDeviceAndEntity(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := device.Events().SubscribeChannel(ctx, api.EventFilter{Ski: "ski"})
+
+	device.Events().Publish(api.EventPayload{Ski: "other", Data: 0})
+	device.Events().Publish(api.EventPayload{Ski: "ski", Data: 1})
+	device.Events().Publish(api.EventPayload{Ski: "ski", Data: 2})
+
+	payload := <-ch
+	assert.Equal(s.T(), 1, payload.Data)
+	payload = <-ch
+	assert.Equal(s.T(), 2, payload.Data)
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		assert.False(s.T(), ok)
+	case <-time.After(time.Second):
+		s.T().Fatal("channel was not closed")
+	}
+
+	// publishing after the channel was closed is fine
+	device.Events().Publish(api.EventPayload{Ski: "ski", Data: 3})
+}
+
+func (s *EventsTestSuite) Test_SubscribeChannel_NilContext() {
+	device, _ := createLocalDeviceAndEntity(1)
+
+	ch := device.Events().SubscribeChannel(nil, api.EventFilter{Ski: "ski"})
+
+	device.Events().Publish(api.EventPayload{Ski: "ski", Data: 1})
+
+	payload := <-ch
+	assert.Equal(s.T(), 1, payload.Data)
+}
+
+func (s *EventsTestSuite) Test_EventFilterMatch() {
+	device, entity := createLocalDeviceAndEntity(1)
+	remoteDevice := createRemoteDevice(device, "ski", nil)
+	_, remoteFeature := createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeMeasurement, model.FunctionTypeMeasurementListData)
+	localFeature, _ := createLocalFeatures(entity, model.FeatureTypeTypeLoadControl, "")
+
+	payload := api.EventPayload{
+		Ski:       "ski",
+		EventType: api.EventTypeDataChange,
+		Feature:   remoteFeature,
+		Function:  model.FunctionTypeMeasurementListData,
+	}
+
+	assert.True(s.T(), eventFilterMatch(api.EventFilter{}, payload))
+	assert.True(s.T(), eventFilterMatch(api.EventFilter{
+		Ski:         "ski",
+		EventType:   util.Ptr(api.EventTypeDataChange),
+		FeatureType: util.Ptr(model.FeatureTypeTypeMeasurement),
+		Function:    util.Ptr(model.FunctionTypeMeasurementListData),
+		Entity:      []model.AddressEntityType{1},
+	}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{Ski: "other"}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{EventType: util.Ptr(api.EventTypeDeviceChange)}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{FeatureType: util.Ptr(model.FeatureTypeTypeLoadControl)}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{Function: util.Ptr(model.FunctionTypeMeasurementDescriptionListData)}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{Entity: []model.AddressEntityType{2}}, payload))
+
+	// the local feature is used if there is no remote feature
+	payload = api.EventPayload{
+		LocalFeature: localFeature,
+	}
+	assert.True(s.T(), eventFilterMatch(api.EventFilter{FeatureType: util.Ptr(model.FeatureTypeTypeLoadControl)}, payload))
+	assert.False(s.T(), eventFilterMatch(api.EventFilter{Entity: []model.AddressEntityType{1}}, payload))
+}
+
+func (s *EventsTestSuite) Test_Wait() {
+	sut := newEvents()
+	sut.forward = nil
+
+	// nothing is in flight
+	assert.Nil(s.T(), sut.wait(context.Background()))
+
+	handler := &orderedEventHandler{block: make(chan struct{})}
+	err := sut.Subscribe(handler)
+	assert.Nil(s.T(), err)
+
+	ordered := &orderedEventHandler{block: make(chan struct{})}
+	err = sut.SubscribeWithOptions(ordered, api.EventSubscriptionOptions{QueueSize: 2})
+	assert.Nil(s.T(), err)
+
+	// the events of a channel subscription are not waited for
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = sut.SubscribeChannel(ctx, api.EventFilter{})
+
+	// the queue of the ordered subscription overflows, so the last events are dropped
+	for i := 0; i < 4; i++ {
+		sut.Publish(api.EventPayload{Data: i})
+	}
+
+	timeoutCtx, timeoutCancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer timeoutCancel()
+	assert.Equal(s.T(), context.DeadlineExceeded, sut.wait(timeoutCtx))
+
+	close(handler.block)
+	close(ordered.block)
+
+	assert.Nil(s.T(), sut.wait(context.Background()))
+	assert.Equal(s.T(), 4, len(handler.Received()))
+	assert.Equal(s.T(), []int{0, 1}, ordered.Received()[:2])
+
+	// queued events of a removed subscription are not waited for
+	blocked := &orderedEventHandler{block: make(chan struct{})}
+	err = sut.SubscribeWithOptions(blocked, api.EventSubscriptionOptions{QueueSize: 2})
+	assert.Nil(s.T(), err)
+
+	sut.Publish(api.EventPayload{Data: 0})
+	sut.Publish(api.EventPayload{Data: 1})
+	assert.Eventually(s.T(), func() bool { return len(sut.subscribers[2].queue) == 1 }, time.Second, time.Millisecond*10)
+
+	err = sut.Unsubscribe(blocked)
+	assert.Nil(s.T(), err)
+	close(blocked.block)
+
+	assert.Nil(s.T(), sut.wait(context.Background()))
+}
@@ -2,6 +2,7 @@ package spine
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/enbility/spine-go/api"
 	"github.com/enbility/spine-go/model"
@@ -14,6 +15,10 @@ type Feature struct {
 	description *model.DescriptionType
 	role        model.RoleType
 	operations  map[model.FunctionType]api.OperationsInterface
+
+	// the operations map is replaced instead of modified,
+	// so a returned map may be read while functions are added
+	descriptionMux sync.RWMutex
 }
 
 var _ api.FeatureInterface = (*Feature)(nil)
@@ -41,19 +46,28 @@ func (r *Feature) Role() model.RoleType {
 }
 
 func (r *Feature) Operations() map[model.FunctionType]api.OperationsInterface {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.operations
 }
 
 func (r *Feature) Description() *model.DescriptionType {
+	r.descriptionMux.RLock()
+	defer r.descriptionMux.RUnlock()
+
 	return r.description
 }
 
 func (r *Feature) SetDescription(d *model.DescriptionType) {
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
 	r.description = d
 }
 
 func (r *Feature) SetDescriptionString(s string) {
-	r.description = util.Ptr(model.DescriptionType(s))
+	r.SetDescription(util.Ptr(model.DescriptionType(s)))
 }
 
 func (r *Feature) String() string {
@@ -3,7 +3,9 @@ package spine
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -19,21 +21,65 @@ type FeatureLocal struct {
 	entity              api.EntityLocalInterface
 	functionDataMap     map[model.FunctionType]api.FunctionDataCmdInterface
 	muxResponseCB       sync.Mutex
-	responseMsgCallback map[model.MsgCounterType][]func(result api.ResponseMessage)
+	responseMsgCallback map[model.MsgCounterType]*pendingResponse
+	responseCallbackID  uint64 // the id of the last added response callback
 	resultCallbacks     []func(result api.ResponseMessage)
 
+	// responses received while requests are being sent, as their callbacks
+	// can only be added once the msgCounter of the sent request is known
+	sendingRequests int
+	earlyResponses  map[model.MsgCounterType]api.ResponseMessage
+
 	writeTimeout           time.Duration
 	writeApprovalCallbacks []api.WriteApprovalCallbackFunc
 	muxWriteReceived       sync.Mutex
-	writeApprovalReceived  map[string]map[model.MsgCounterType]int
-	pendingWriteApprovals  map[string]map[model.MsgCounterType]*time.Timer
+	writeApprovalReceived  map[string]map[writeApprovalKey]int
+	pendingWriteApprovals  map[string]map[writeApprovalKey]*pendingWriteApproval
 
-	bindings      []*model.FeatureAddressType // bindings to remote features
-	subscriptions []*model.FeatureAddressType // subscriptions to remote features
+	bindings       []*model.FeatureAddressType // pending and active bindings to remote features
+	subscriptions  []*model.FeatureAddressType // pending and active subscriptions to remote features
+	outboundStates map[outboundStateKey]*outboundState
 
 	mux sync.Mutex
 }
 
+// a callback added via AddResponseCallback
+type responseCallback struct {
+	id       uint64
+	function func(result api.ResponseMessage)
+}
+
+// callbacks waiting for the response of a sent request
+type pendingResponse struct {
+	callbacks []responseCallback
+
+	// the following are only known for requests sent by this feature
+	sender        api.SenderInterface
+	featureRemote api.FeatureRemoteInterface
+	destination   *model.FeatureAddressType
+
+	timer *time.Timer // expires the callbacks if no response is received, only set for sent requests
+}
+
+// stop the expiry of the callbacks
+func (r *pendingResponse) stop() {
+	if r.timer != nil {
+		r.timer.Stop()
+	}
+}
+
+// identifies a write cmd waiting for an approval, all cmds of a datagram have the same msgCounter
+type writeApprovalKey struct {
+	msgCounter model.MsgCounterType
+	cmdIndex   int
+}
+
+// a write waiting for the approval of the application
+type pendingWriteApproval struct {
+	msg   *api.Message
+	timer *time.Timer // denies the write if it is not approved in time
+}
+
 func NewFeatureLocal(id uint, entity api.EntityLocalInterface, ftype model.FeatureTypeType, role model.RoleType) *FeatureLocal {
 	res := &FeatureLocal{
 		Feature: NewFeature(
@@ -42,10 +88,12 @@ func NewFeatureLocal(id uint, entity api.EntityLocalInterface, ftype model.Featu
 			role),
 		entity:                entity,
 		functionDataMap:       make(map[model.FunctionType]api.FunctionDataCmdInterface),
-		responseMsgCallback:   make(map[model.MsgCounterType][]func(result api.ResponseMessage)),
-		writeApprovalReceived: make(map[string]map[model.MsgCounterType]int),
-		pendingWriteApprovals: make(map[string]map[model.MsgCounterType]*time.Timer),
+		responseMsgCallback:   make(map[model.MsgCounterType]*pendingResponse),
+		earlyResponses:        make(map[model.MsgCounterType]api.ResponseMessage),
+		writeApprovalReceived: make(map[string]map[writeApprovalKey]int),
+		pendingWriteApprovals: make(map[string]map[writeApprovalKey]*pendingWriteApproval),
 		writeTimeout:          defaultMaxResponseDelay,
+		outboundStates:        make(map[outboundStateKey]*outboundState),
 	}
 
 	for _, fd := range CreateFunctionData[api.FunctionDataCmdInterface](ftype) {
@@ -73,7 +121,10 @@ func (r *FeatureLocal) AddFunctionType(function model.FunctionType, read, write
 	if r.role != model.RoleTypeServer && r.role != model.RoleTypeSpecial {
 		return
 	}
+
+	r.descriptionMux.Lock()
 	if r.operations[function] != nil {
+		r.descriptionMux.Unlock()
 		return
 	}
 	writePartial := false
@@ -83,8 +134,11 @@ func (r *FeatureLocal) AddFunctionType(function model.FunctionType, read, write
 			writePartial = fctData.SupportsPartialWrite()
 		}
 	}
-	// partial reads are currently not supported!
-	r.operations[function] = NewOperations(read, false, write, writePartial)
+	// partial reads have to be enabled explicitly via EnablePartialRead
+	operations := maps.Clone(r.operations)
+	operations[function] = NewOperations(read, false, write, writePartial)
+	r.operations = operations
+	r.descriptionMux.Unlock()
 
 	if r.role == model.RoleTypeServer &&
 		r.ftype == model.FeatureTypeTypeDeviceDiagnosis &&
@@ -94,10 +148,35 @@ func (r *FeatureLocal) AddFunctionType(function model.FunctionType, read, write
 	}
 }
 
+// Enable partial reads for a function type added with read support to a server feature
+func (r *FeatureLocal) EnablePartialRead(function model.FunctionType) error {
+	// partial reads are handled for server features only, the NodeManagement processes reads itself
+	if r.role != model.RoleTypeServer {
+		return errors.New("only allowed on a server feature")
+	}
+	if r.functionData(function) == nil {
+		return errors.New("function data not found")
+	}
+
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
+	current := r.operations[function]
+	if current == nil || !current.Read() {
+		return errors.New("function is not readable")
+	}
+
+	operations := maps.Clone(r.operations)
+	operations[function] = NewOperations(true, true, current.Write(), current.WritePartial())
+	r.operations = operations
+
+	return nil
+}
+
 func (r *FeatureLocal) Functions() []model.FunctionType {
 	var fcts []model.FunctionType
 
-	for key := range r.operations {
+	for key := range r.Operations() {
 		fcts = append(fcts, key)
 	}
 
@@ -106,35 +185,205 @@ func (r *FeatureLocal) Functions() []model.FunctionType {
 
 // Add a callback function to be invoked when SPINE message comes in with a given msgCounterReference value
 //
-// Returns an error if there is already a callback for the msgCounter set
+// For requests sent via this feature, the callback is invoked with a ResultDataType
+// containing ErrorNumberTypeTimeout or ErrorNumberTypeDestinationUnreachable,
+// if no response is received within the maximum response delay of the remote feature
+// or the remote device disconnects
+//
+// Returns an error if the callback is nil
 func (r *FeatureLocal) AddResponseCallback(msgCounterReference model.MsgCounterType, function func(msg api.ResponseMessage)) error {
+	_, err := r.AddResponseCallbackWithHandle(msgCounterReference, function)
+	return err
+}
+
+// Add a callback function like AddResponseCallback
+//
+// Returns a handle for removing the callback again, or an error if the callback is nil
+func (r *FeatureLocal) AddResponseCallbackWithHandle(msgCounterReference model.MsgCounterType, function func(msg api.ResponseMessage)) (api.ResponseCallbackHandle, error) {
+	if function == nil {
+		return api.ResponseCallbackHandle{}, errors.New("callback is missing")
+	}
+
 	r.muxResponseCB.Lock()
 	defer r.muxResponseCB.Unlock()
 
-	if _, ok := r.responseMsgCallback[msgCounterReference]; ok {
-		for _, cb := range r.responseMsgCallback[msgCounterReference] {
-			if reflect.ValueOf(cb).Pointer() == reflect.ValueOf(function).Pointer() {
-				return errors.New("callback already set")
-			}
+	// the response was received before the msgCounter of the request was known to the caller
+	if response, ok := r.earlyResponses[msgCounterReference]; ok {
+		r.responseCallbackID++
+		go function(response)
+
+		return api.ResponseCallbackHandle{MsgCounterReference: msgCounterReference, ID: r.responseCallbackID}, nil
+	}
+
+	pending := r.pendingResponse(msgCounterReference)
+
+	r.responseCallbackID++
+	pending.callbacks = append(pending.callbacks, responseCallback{
+		id:       r.responseCallbackID,
+		function: function,
+	})
+
+	return api.ResponseCallbackHandle{MsgCounterReference: msgCounterReference, ID: r.responseCallbackID}, nil
+}
+
+// Remove a callback function added via AddResponseCallbackWithHandle
+func (r *FeatureLocal) RemoveResponseCallback(handle api.ResponseCallbackHandle) {
+	r.muxResponseCB.Lock()
+	defer r.muxResponseCB.Unlock()
+
+	pending, ok := r.responseMsgCallback[handle.MsgCounterReference]
+	if !ok {
+		return
+	}
+
+	pending.callbacks = slices.DeleteFunc(pending.callbacks, func(cb responseCallback) bool {
+		return cb.id == handle.ID
+	})
+
+	if len(pending.callbacks) == 0 {
+		pending.stop()
+		delete(r.responseMsgCallback, handle.MsgCounterReference)
+	}
+}
+
+// hold back the responses received until the returned function is called,
+// so callbacks added once a request was sent also receive a fast response
+//
+// has to be called before a request is sent
+func (r *FeatureLocal) holdResponses() (release func()) {
+	r.muxResponseCB.Lock()
+	r.sendingRequests++
+	r.muxResponseCB.Unlock()
+
+	return sync.OnceFunc(func() {
+		r.muxResponseCB.Lock()
+		defer r.muxResponseCB.Unlock()
+
+		r.sendingRequests--
+		if r.sendingRequests == 0 {
+			clear(r.earlyResponses)
 		}
+	})
+}
+
+// return the pending response for a msgCounter, creates a new one if none exists
+//
+// muxResponseCB has to be locked by the caller
+func (r *FeatureLocal) pendingResponse(msgCounterReference model.MsgCounterType) *pendingResponse {
+	if pending, ok := r.responseMsgCallback[msgCounterReference]; ok {
+		return pending
 	}
 
-	r.responseMsgCallback[msgCounterReference] = append(r.responseMsgCallback[msgCounterReference], function)
+	pending := &pendingResponse{}
+	r.responseMsgCallback[msgCounterReference] = pending
 
-	return nil
+	return pending
+}
+
+// set the destination and deadline of a sent request, so response callbacks can expire
+func (r *FeatureLocal) addPendingRequest(
+	msgCounter model.MsgCounterType,
+	sender api.SenderInterface,
+	featureRemote api.FeatureRemoteInterface,
+	destinationAddress *model.FeatureAddressType,
+	maxDelay time.Duration) {
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxResponseDelay
+	}
+
+	r.muxResponseCB.Lock()
+	defer r.muxResponseCB.Unlock()
+
+	// the response was received already
+	if _, ok := r.earlyResponses[msgCounter]; ok {
+		return
+	}
+
+	pending := r.pendingResponse(msgCounter)
+	pending.sender = sender
+	pending.featureRemote = featureRemote
+	pending.destination = destinationAddress
+
+	if pending.timer != nil {
+		pending.timer.Reset(maxDelay)
+		return
+	}
+
+	pending.timer = time.AfterFunc(maxDelay, func() {
+		r.expirePendingResponse(msgCounter, pending, model.ErrorNumberTypeTimeout, "no response received in time")
+	})
+}
+
+// invoke all callbacks of a pending response with an error, as no response will be received
+func (r *FeatureLocal) expirePendingResponse(msgCounterReference model.MsgCounterType, pending *pendingResponse, errorNumber model.ErrorNumberType, description string) {
+	r.muxResponseCB.Lock()
+	// the response may have been received in the meantime
+	if current, ok := r.responseMsgCallback[msgCounterReference]; !ok || current != pending {
+		r.muxResponseCB.Unlock()
+		return
+	}
+	pending.stop()
+	delete(r.responseMsgCallback, msgCounterReference)
+	r.muxResponseCB.Unlock()
+
+	// the request will never be answered, so it has to be removed from the senders cache
+	if pending.sender != nil {
+		pending.sender.ProcessResponseForMsgCounterReference(&msgCounterReference)
+	}
+
+	responseMsg := api.ResponseMessage{
+		MsgCounterReference: msgCounterReference,
+		Data: &model.ResultDataType{
+			ErrorNumber: util.Ptr(errorNumber),
+			Description: util.Ptr(model.DescriptionType(description)),
+		},
+		FeatureLocal:  r,
+		FeatureRemote: pending.featureRemote,
+	}
+	if pending.featureRemote != nil {
+		responseMsg.EntityRemote = pending.featureRemote.Entity()
+		responseMsg.DeviceRemote = pending.featureRemote.Device()
+	}
+
+	for _, cb := range pending.callbacks {
+		go cb.function(responseMsg)
+	}
+}
+
+// expire all pending responses of requests sent to remote features matching the filter
+func (r *FeatureLocal) expirePendingResponsesForDestination(match func(address *model.FeatureAddressType) bool, errorNumber model.ErrorNumberType, description string) {
+	r.muxResponseCB.Lock()
+	expired := make(map[model.MsgCounterType]*pendingResponse)
+	for msgCounter, pending := range r.responseMsgCallback {
+		if pending.destination != nil && match(pending.destination) {
+			expired[msgCounter] = pending
+		}
+	}
+	r.muxResponseCB.Unlock()
+
+	for msgCounter, pending := range expired {
+		r.expirePendingResponse(msgCounter, pending, errorNumber, description)
+	}
 }
 
 func (r *FeatureLocal) processResponseMsgCallbacks(msgCounterReference model.MsgCounterType, msg api.ResponseMessage) {
 	r.muxResponseCB.Lock()
 	defer r.muxResponseCB.Unlock()
 
-	cbs, ok := r.responseMsgCallback[msgCounterReference]
+	// callbacks of requests being sent right now may not be added yet
+	if r.sendingRequests > 0 {
+		r.earlyResponses[msgCounterReference] = msg
+	}
+
+	pending, ok := r.responseMsgCallback[msgCounterReference]
 	if !ok {
 		return
 	}
 
-	for _, cb := range cbs {
-		go cb(msg)
+	pending.stop()
+
+	for _, cb := range pending.callbacks {
+		go cb.function(msg)
 	}
 
 	delete(r.responseMsgCallback, msgCounterReference)
@@ -188,39 +437,62 @@ func (r *FeatureLocal) addPendingApproval(msg *api.Message) {
 	}
 
 	ski := msg.DeviceRemote.Ski()
+	key := writeApprovalKey{
+		msgCounter: *msg.RequestHeader.MsgCounter,
+		cmdIndex:   msg.CmdIndex,
+	}
+	pending := &pendingWriteApproval{
+		msg: msg,
+	}
 
-	newTimer := time.AfterFunc(r.writeTimeout, func() {
+	r.muxResponseCB.Lock()
+	defer r.muxResponseCB.Unlock()
+
+	pending.timer = time.AfterFunc(r.writeTimeout, func() {
 		r.muxResponseCB.Lock()
-		delete(r.pendingWriteApprovals[ski], *msg.RequestHeader.MsgCounter)
+		// the write may have been approved or denied in the meantime
+		if r.pendingWriteApprovals[ski][key] != pending {
+			r.muxResponseCB.Unlock()
+			return
+		}
+		delete(r.pendingWriteApprovals[ski], key)
 		r.muxResponseCB.Unlock()
 
+		r.muxWriteReceived.Lock()
+		delete(r.writeApprovalReceived[ski], key)
+		r.muxWriteReceived.Unlock()
+
 		err := model.NewErrorTypeFromString("write not approved in time by application")
-		_ = msg.FeatureRemote.Device().Sender().ResultError(msg.RequestHeader, r.Address(), err)
+		r.writeResult(msg, err)
 	})
 
-	r.muxResponseCB.Lock()
 	if _, ok := r.pendingWriteApprovals[ski]; !ok {
-		r.pendingWriteApprovals[ski] = make(map[model.MsgCounterType]*time.Timer)
+		r.pendingWriteApprovals[ski] = make(map[writeApprovalKey]*pendingWriteApproval)
 	}
-	r.pendingWriteApprovals[ski][*msg.RequestHeader.MsgCounter] = newTimer
-	r.muxResponseCB.Unlock()
+	r.pendingWriteApprovals[ski][key] = pending
 }
 
 func (r *FeatureLocal) ApproveOrDenyWrite(msg *api.Message, err model.ErrorType) {
 	if r.Role() != model.RoleTypeServer ||
-		msg.DeviceRemote == nil {
+		msg.DeviceRemote == nil ||
+		msg.RequestHeader == nil ||
+		msg.RequestHeader.MsgCounter == nil {
 		return
 	}
 
 	ski := msg.DeviceRemote.Ski()
+	key := writeApprovalKey{
+		msgCounter: *msg.RequestHeader.MsgCounter,
+		cmdIndex:   msg.CmdIndex,
+	}
 
 	r.muxResponseCB.Lock()
-	timer, ok := r.pendingWriteApprovals[ski][*msg.RequestHeader.MsgCounter]
+	pending, ok := r.pendingWriteApprovals[ski][key]
 	count := len(r.writeApprovalCallbacks)
 	r.muxResponseCB.Unlock()
 
 	// if there is no timer running, we are too late and error has already been sent
-	if !ok || timer == nil {
+	if !ok || pending == nil {
 		return
 	}
 
@@ -228,33 +500,33 @@ func (r *FeatureLocal) ApproveOrDenyWrite(msg *api.Message, err model.ErrorType)
 	r.muxWriteReceived.Lock()
 	defer r.muxWriteReceived.Unlock()
 	if count > 1 && err.ErrorNumber == 0 {
-		amount, ok := r.writeApprovalReceived[ski][*msg.RequestHeader.MsgCounter]
-		if ok {
-			r.writeApprovalReceived[ski][*msg.RequestHeader.MsgCounter] = amount + 1
-		} else {
-			r.writeApprovalReceived[ski] = make(map[model.MsgCounterType]int)
-			r.writeApprovalReceived[ski][*msg.RequestHeader.MsgCounter] = 1
+		if _, ok := r.writeApprovalReceived[ski]; !ok {
+			r.writeApprovalReceived[ski] = make(map[writeApprovalKey]int)
 		}
+		r.writeApprovalReceived[ski][key]++
 		// do we have enough approve messages, if not exit
-		if r.writeApprovalReceived[ski][*msg.RequestHeader.MsgCounter] < count {
+		if r.writeApprovalReceived[ski][key] < count {
 			return
 		}
 	}
 
-	timer.Stop()
-
-	delete(r.writeApprovalReceived[ski], *msg.RequestHeader.MsgCounter)
+	delete(r.writeApprovalReceived[ski], key)
 
 	r.muxResponseCB.Lock()
-	defer r.muxResponseCB.Unlock()
-	delete(r.pendingWriteApprovals[ski], *msg.RequestHeader.MsgCounter)
+	// the write may have been answered in the meantime, e.g. by a timeout or a denial
+	if r.pendingWriteApprovals[ski][key] != pending || !pending.timer.Stop() {
+		r.muxResponseCB.Unlock()
+		return
+	}
+	delete(r.pendingWriteApprovals[ski], key)
+	r.muxResponseCB.Unlock()
 
 	if err.ErrorNumber == 0 {
 		r.processWrite(msg)
 		return
 	}
 
-	_ = msg.FeatureRemote.Device().Sender().ResultError(msg.RequestHeader, r.Address(), &err)
+	r.writeResult(msg, &err)
 }
 
 func (r *FeatureLocal) SetWriteApprovalTimeout(duration time.Duration) {
@@ -269,6 +541,37 @@ func (r *FeatureLocal) CleanWriteApprovalCaches(ski string) {
 	delete(r.writeApprovalReceived, ski)
 }
 
+// Fail all pending response callbacks and deny all writes waiting for an approval,
+// used if the local device shuts down
+func (r *FeatureLocal) shutdown() {
+	r.muxResponseCB.Lock()
+	pendingResponses := maps.Clone(r.responseMsgCallback)
+
+	var pendingApprovals []*pendingWriteApproval
+	for _, approvals := range r.pendingWriteApprovals {
+		for _, pending := range approvals {
+			// a stopped timer denied the write already
+			if pending.timer.Stop() {
+				pendingApprovals = append(pendingApprovals, pending)
+			}
+		}
+	}
+	clear(r.pendingWriteApprovals)
+	r.muxResponseCB.Unlock()
+
+	r.muxWriteReceived.Lock()
+	clear(r.writeApprovalReceived)
+	r.muxWriteReceived.Unlock()
+
+	for msgCounter, pending := range pendingResponses {
+		r.expirePendingResponse(msgCounter, pending, model.ErrorNumberTypeGeneralError, "local device shut down")
+	}
+
+	for _, pending := range pendingApprovals {
+		r.writeResult(pending.msg, model.NewErrorTypeFromString("local device shut down"))
+	}
+}
+
 // Remove subscriptions and bindings from local cache for a remote device
 // used if a remote device is getting disconnected
 func (r *FeatureLocal) CleanRemoteDeviceCaches(remoteAddress *model.DeviceAddressType) {
@@ -277,30 +580,13 @@ func (r *FeatureLocal) CleanRemoteDeviceCaches(remoteAddress *model.DeviceAddres
 		return
 	}
 
-	r.mux.Lock()
-	defer r.mux.Unlock()
-
-	var subscriptions []*model.FeatureAddressType
-
-	for _, item := range r.subscriptions {
-		if item.Device == nil ||
-			*item.Device != *remoteAddress.Device {
-			subscriptions = append(subscriptions, item)
-		}
-	}
-
-	r.subscriptions = subscriptions
-
-	var bindings []*model.FeatureAddressType
-
-	for _, item := range r.bindings {
-		if item.Device == nil ||
-			*item.Device != *remoteAddress.Device {
-			bindings = append(bindings, item)
-		}
-	}
+	r.removeOutboundMatching(func(address *model.FeatureAddressType) bool {
+		return address.Device != nil && *address.Device == *remoteAddress.Device
+	})
 
-	r.bindings = bindings
+	r.expirePendingResponsesForDestination(func(address *model.FeatureAddressType) bool {
+		return address.Device != nil && *address.Device == *remoteAddress.Device
+	}, model.ErrorNumberTypeDestinationUnreachable, "remote device disconnected")
 }
 
 // Remove subscriptions and bindings from local cache for a remote entity
@@ -312,32 +598,16 @@ func (r *FeatureLocal) CleanRemoteEntityCaches(remoteAddress *model.EntityAddres
 		return
 	}
 
-	r.mux.Lock()
-	defer r.mux.Unlock()
-
-	var subscriptions []*model.FeatureAddressType
-
-	for _, item := range r.subscriptions {
-		if item.Device == nil || item.Entity == nil ||
-			*item.Device != *remoteAddress.Device ||
-			!reflect.DeepEqual(item.Entity, remoteAddress.Entity) {
-			subscriptions = append(subscriptions, item)
-		}
-	}
-
-	r.subscriptions = subscriptions
-
-	var bindings []*model.FeatureAddressType
-
-	for _, item := range r.bindings {
-		if item.Device == nil || item.Entity == nil ||
-			*item.Device != *remoteAddress.Device ||
-			!reflect.DeepEqual(item.Entity, remoteAddress.Entity) {
-			bindings = append(bindings, item)
-		}
-	}
+	r.removeOutboundMatching(func(address *model.FeatureAddressType) bool {
+		return address.Device != nil && address.Entity != nil &&
+			*address.Device == *remoteAddress.Device &&
+			reflect.DeepEqual(address.Entity, remoteAddress.Entity)
+	})
 
-	r.bindings = bindings
+	r.expirePendingResponsesForDestination(func(address *model.FeatureAddressType) bool {
+		return address.Device != nil && *address.Device == *remoteAddress.Device &&
+			reflect.DeepEqual(address.Entity, remoteAddress.Entity)
+	}, model.ErrorNumberTypeDestinationUnreachable, "remote entity removed")
 }
 
 func (r *FeatureLocal) DataCopy(function model.FunctionType) any {
@@ -353,25 +623,27 @@ func (r *FeatureLocal) DataCopy(function model.FunctionType) any {
 }
 
 func (r *FeatureLocal) SetData(function model.FunctionType, data any) {
-	fctData, err := r.updateData(false, function, data, nil, nil)
+	fctData, previousData, err := r.updateData(false, function, data, nil, nil)
 
 	if err != nil {
 		logging.Log().Debug(err.String())
 	}
 
 	if fctData != nil && err == nil {
-		r.Device().NotifySubscribers(r.Address(), fctData.NotifyOrWriteCmdType(nil, nil, false, nil))
+		r.notifyChanges(fctData, previousData)
 	}
 }
 
 func (r *FeatureLocal) UpdateData(function model.FunctionType, data any, filterPartial *model.FilterType, filterDelete *model.FilterType) *model.ErrorType {
-	fctData, err := r.updateData(false, function, data, filterPartial, filterDelete)
+	fctData, previousData, err := r.updateData(false, function, data, filterPartial, filterDelete)
 
 	if err != nil {
 		logging.Log().Debug(err.String())
 	}
 
-	if fctData != nil && err == nil {
+	if fctData != nil && err == nil && filterPartial == nil && filterDelete == nil {
+		r.notifyChanges(fctData, previousData)
+	} else if fctData != nil && err == nil {
 		var deleteSelector, deleteElements, partialSelector any
 
 		if filterDelete != nil {
@@ -397,18 +669,51 @@ func (r *FeatureLocal) UpdateData(function model.FunctionType, data any, filterP
 	return err
 }
 
-func (r *FeatureLocal) updateData(remoteWrite bool, function model.FunctionType, data any, filterPartial *model.FilterType, filterDelete *model.FilterType) (api.FunctionDataCmdInterface, *model.ErrorType) {
+// update the data of a function and return the function data and a copy of the previous data
+func (r *FeatureLocal) updateData(remoteWrite bool, function model.FunctionType, data any, filterPartial *model.FilterType, filterDelete *model.FilterType) (api.FunctionDataCmdInterface, any, *model.ErrorType) {
 	r.mux.Lock()
 	defer r.mux.Unlock()
 
 	fctData := r.functionData(function)
 	if fctData == nil {
-		return nil, model.NewErrorTypeFromString("data not found")
+		return nil, nil, model.NewErrorTypeFromString("data not found")
 	}
 
+	previousData := fctData.DataCopyAny()
+
 	_, err := fctData.UpdateDataAny(remoteWrite, true, data, filterPartial, filterDelete)
 
-	return fctData, err
+	return fctData, previousData, err
+}
+
+// get the cmd of a full notify with the current data of a function
+func (r *FeatureLocal) notifyCmdType(function model.FunctionType) (model.CmdType, bool) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	fctData := r.functionData(function)
+	if fctData == nil {
+		return model.CmdType{}, false
+	}
+
+	return fctData.NotifyOrWriteCmdType(nil, nil, false, nil), true
+}
+
+// notify the subscribers about the changes of the function data
+//
+// if possible, only changed items are sent via partial notifies and removed items
+// via delete notifies, nothing is sent if the data did not change
+func (r *FeatureLocal) notifyChanges(fctData api.FunctionDataCmdInterface, previousData any) {
+	cmds, ok := fctData.NotifyDiffCmdTypes(previousData)
+	if !ok {
+		cmds = []model.CmdType{fctData.NotifyOrWriteCmdType(nil, nil, false, nil)}
+	}
+
+	if len(cmds) == 0 {
+		return
+	}
+
+	r.Device().NotifySubscribers(r.Address(), cmds...)
 }
 
 func (r *FeatureLocal) RequestRemoteData(
@@ -432,14 +737,34 @@ func (r *FeatureLocal) RequestRemoteDataBySenderAddress(
 	deviceSki string,
 	destinationAddress *model.FeatureAddressType,
 	maxDelay time.Duration) (*model.MsgCounterType, *model.ErrorType) {
+	release := r.holdResponses()
+	defer release()
+
 	msgCounter, err := sender.Request(model.CmdClassifierTypeRead, r.Address(), destinationAddress, false, []model.CmdType{cmd})
 	if err == nil {
+		if msgCounter != nil {
+			var featureRemote api.FeatureRemoteInterface
+			if remoteDevice := r.Device().RemoteDeviceForSki(deviceSki); remoteDevice != nil {
+				featureRemote = remoteDevice.FeatureByAddress(destinationAddress)
+			}
+			r.addPendingRequest(*msgCounter, sender, featureRemote, destinationAddress, maxDelay)
+		}
+
 		return msgCounter, nil
 	}
 
 	return msgCounter, model.NewErrorType(model.ErrorNumberTypeGeneralError, err.Error())
 }
 
+// the outbound entries of the local device, nil if the device does not remember them
+func (r *FeatureLocal) outboundEntries() *outboundEntries {
+	if device, ok := r.Device().(*DeviceLocal); ok {
+		return device.outboundEntries
+	}
+
+	return nil
+}
+
 // check if there already is a subscription to a remote feature
 func (r *FeatureLocal) HasSubscriptionToRemote(remoteAddress *model.FeatureAddressType) bool {
 	r.mux.Lock()
@@ -468,6 +793,10 @@ func (r *FeatureLocal) SubscribeToRemote(remoteAddress *model.FeatureAddressType
 		return nil, model.NewErrorTypeFromString(fmt.Sprintf("the server feature '%s' cannot request a subscription", r.Feature.String()))
 	}
 
+	// the result may be received before the request is tracked
+	release := r.holdOutboundResults()
+	defer release()
+
 	msgCounter, err := remoteDevice.Sender().Subscribe(r.Address(), remoteAddress, r.ftype)
 	if err != nil {
 		return nil, model.NewErrorTypeFromString(err.Error())
@@ -477,6 +806,13 @@ func (r *FeatureLocal) SubscribeToRemote(remoteAddress *model.FeatureAddressType
 	r.subscriptions = append(r.subscriptions, remoteAddress)
 	r.mux.Unlock()
 
+	if entries := r.outboundEntries(); entries != nil {
+		entries.add(api.OutboundEntryTypeSubscription, r, remoteDevice.FeatureByAddress(remoteAddress))
+	}
+
+	// a rejection removes the entry again
+	r.trackOutboundRequest(api.OutboundEntryTypeSubscription, remoteAddress, remoteDevice, msgCounter)
+
 	return msgCounter, nil
 }
 
@@ -485,6 +821,11 @@ func (r *FeatureLocal) RemoveRemoteSubscription(remoteAddress *model.FeatureAddr
 	if remoteAddress.Device == nil {
 		return nil, model.NewErrorTypeFromString("device not found")
 	}
+
+	// the subscription is not restored after a reconnect anymore, even if the remote device is not connected
+	if entries := r.outboundEntries(); entries != nil {
+		entries.remove(api.OutboundEntryTypeSubscription, r, remoteAddress)
+	}
 	remoteDevice := r.entity.Device().RemoteDeviceForAddress(*remoteAddress.Device)
 	if remoteDevice == nil {
 		return nil, model.NewErrorTypeFromString("device not found")
@@ -495,27 +836,23 @@ func (r *FeatureLocal) RemoveRemoteSubscription(remoteAddress *model.FeatureAddr
 		return nil, model.NewErrorTypeFromString("device not found")
 	}
 
-	var subscriptions []*model.FeatureAddressType
-
 	r.mux.Lock()
-	defer r.mux.Unlock()
-
-	for _, item := range r.subscriptions {
-		if reflect.DeepEqual(item, remoteAddress) {
-			continue
-		}
-
-		subscriptions = append(subscriptions, item)
-	}
+	r.subscriptions = withoutFeatureAddress(r.subscriptions, remoteAddress)
+	event := r.updateOutboundState(api.OutboundEntryTypeSubscription, remoteAddress, api.OutboundStateRemoved, nil)
+	r.mux.Unlock()
 
-	r.subscriptions = subscriptions
+	r.publishOutboundStates(event)
 
 	return msgCounter, nil
 }
 
 // Remove all subscriptions to remote features
 func (r *FeatureLocal) RemoveAllRemoteSubscriptions() {
-	for _, item := range r.subscriptions {
+	r.mux.Lock()
+	subscriptions := slices.Clone(r.subscriptions)
+	r.mux.Unlock()
+
+	for _, item := range subscriptions {
 		_, _ = r.RemoveRemoteSubscription(item)
 	}
 }
@@ -548,6 +885,10 @@ func (r *FeatureLocal) BindToRemote(remoteAddress *model.FeatureAddressType) (*m
 		return nil, model.NewErrorTypeFromString(fmt.Sprintf("the server feature '%s' cannot request a binding", r.Feature.String()))
 	}
 
+	// the result may be received before the request is tracked
+	release := r.holdOutboundResults()
+	defer release()
+
 	msgCounter, err := remoteDevice.Sender().Bind(r.Address(), remoteAddress, r.ftype)
 	if err != nil {
 		return nil, model.NewErrorTypeFromString(err.Error())
@@ -557,6 +898,13 @@ func (r *FeatureLocal) BindToRemote(remoteAddress *model.FeatureAddressType) (*m
 	r.bindings = append(r.bindings, remoteAddress)
 	r.mux.Unlock()
 
+	if entries := r.outboundEntries(); entries != nil {
+		entries.add(api.OutboundEntryTypeBinding, r, remoteDevice.FeatureByAddress(remoteAddress))
+	}
+
+	// a rejection removes the entry again
+	r.trackOutboundRequest(api.OutboundEntryTypeBinding, remoteAddress, remoteDevice, msgCounter)
+
 	return msgCounter, nil
 }
 
@@ -565,6 +913,11 @@ func (r *FeatureLocal) RemoveRemoteBinding(remoteAddress *model.FeatureAddressTy
 	if remoteAddress.Device == nil {
 		return nil, model.NewErrorTypeFromString("device not found")
 	}
+
+	// the binding is not restored after a reconnect anymore, even if the remote device is not connected
+	if entries := r.outboundEntries(); entries != nil {
+		entries.remove(api.OutboundEntryTypeBinding, r, remoteAddress)
+	}
 	remoteDevice := r.entity.Device().RemoteDeviceForAddress(*remoteAddress.Device)
 	if remoteDevice == nil {
 		return nil, model.NewErrorTypeFromString("device not found")
@@ -575,27 +928,23 @@ func (r *FeatureLocal) RemoveRemoteBinding(remoteAddress *model.FeatureAddressTy
 		return nil, model.NewErrorTypeFromString(err.Error())
 	}
 
-	var bindings []*model.FeatureAddressType
-
 	r.mux.Lock()
-	defer r.mux.Unlock()
-
-	for _, item := range r.bindings {
-		if reflect.DeepEqual(item, remoteAddress) {
-			continue
-		}
-
-		bindings = append(bindings, item)
-	}
+	r.bindings = withoutFeatureAddress(r.bindings, remoteAddress)
+	event := r.updateOutboundState(api.OutboundEntryTypeBinding, remoteAddress, api.OutboundStateRemoved, nil)
+	r.mux.Unlock()
 
-	r.bindings = bindings
+	r.publishOutboundStates(event)
 
 	return msgCounter, nil
 }
 
 // Remove all subscriptions to remote features
 func (r *FeatureLocal) RemoveAllRemoteBindings() {
-	for _, item := range r.bindings {
+	r.mux.Lock()
+	bindings := slices.Clone(r.bindings)
+	r.mux.Unlock()
+
+	for _, item := range bindings {
 		_, _ = r.RemoveRemoteBinding(item)
 	}
 }
@@ -615,7 +964,7 @@ func (r *FeatureLocal) HandleMessage(message *api.Message) *model.ErrorType {
 			return err
 		}
 	case model.CmdClassifierTypeRead:
-		if err := r.processRead(*cmdData.Function, message.RequestHeader, message.FeatureRemote); err != nil {
+		if err := r.processRead(*cmdData.Function, message.RequestHeader, message.FilterPartial, message.FeatureRemote); err != nil {
 			return err
 		}
 	case model.CmdClassifierTypeReply:
@@ -678,7 +1027,7 @@ func (r *FeatureLocal) processResult(message *api.Message) *model.ErrorType {
 	return nil
 }
 
-func (r *FeatureLocal) processRead(function model.FunctionType, requestHeader *model.HeaderType, featureRemote api.FeatureRemoteInterface) *model.ErrorType {
+func (r *FeatureLocal) processRead(function model.FunctionType, requestHeader *model.HeaderType, filterPartial *model.FilterType, featureRemote api.FeatureRemoteInterface) *model.ErrorType {
 	// is this a read request to a local server/special feature?
 	if r.role == model.RoleTypeClient {
 		// Read requests to a client feature are not allowed
@@ -691,6 +1040,15 @@ func (r *FeatureLocal) processRead(function model.FunctionType, requestHeader *m
 	}
 
 	cmd := fd.ReplyCmdType(false)
+	// restricted function exchange, as defined in SPINE chapter 5.3.4
+	if operations, ok := r.Operations()[function]; ok && operations.ReadPartial() && filterPartial != nil {
+		// an invalid filter must not be answered with the full data
+		if _, err := filterPartial.Data(); err != nil {
+			return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, err.Error())
+		}
+		cmd = fd.PartialReplyCmdType(filterPartial)
+	}
+
 	if err := featureRemote.Device().Sender().Reply(requestHeader, r.Address(), cmd); err != nil {
 		return model.NewErrorTypeFromString(err.Error())
 	}
@@ -722,7 +1080,7 @@ func (r *FeatureLocal) processReply(message *api.Message) *model.ErrorType {
 		CmdClassifier: util.Ptr(model.CmdClassifierTypeReply),
 		Data:          cmdData.Value,
 	}
-	Events.Publish(payload)
+	r.Device().Events().Publish(payload)
 
 	// we don't need to populate this message if there is no MsgCounterReference
 	if message.RequestHeader == nil || message.RequestHeader.MsgCounterReference == nil {
@@ -760,13 +1118,24 @@ func (r *FeatureLocal) processNotify(function model.FunctionType, data any, filt
 		CmdClassifier: util.Ptr(model.CmdClassifierTypeNotify),
 		Data:          data,
 	}
-	Events.Publish(payload)
+	r.Device().Events().Publish(payload)
 
 	return nil
 }
 
 func (r *FeatureLocal) processWrite(msg *api.Message) {
-	if err := r.executeWrite(msg); err != nil {
+	r.writeResult(msg, r.executeWrite(msg))
+}
+
+// report the result of a write cmd, either via the messages result handler
+// or by sending the result message directly
+func (r *FeatureLocal) writeResult(msg *api.Message, err *model.ErrorType) {
+	if msg.ResultHandler != nil {
+		msg.ResultHandler(err)
+		return
+	}
+
+	if err != nil {
 		_ = msg.FeatureRemote.Device().Sender().ResultError(msg.RequestHeader, r.Address(), err)
 	} else if msg.RequestHeader != nil {
 		ackRequest := msg.RequestHeader.AckRequest
@@ -785,14 +1154,14 @@ func (r *FeatureLocal) executeWrite(msg *api.Message) *model.ErrorType {
 		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "No function found for cmd data")
 	}
 
-	fctData, err1 := r.updateData(true, *cmdData.Function, cmdData.Value, msg.FilterPartial, msg.FilterDelete)
+	fctData, previousData, err1 := r.updateData(true, *cmdData.Function, cmdData.Value, msg.FilterPartial, msg.FilterDelete)
 	if err1 != nil {
 		return err1
 	} else if fctData == nil {
 		return model.NewErrorTypeFromString("function not found")
 	}
 
-	r.Device().NotifySubscribers(r.Address(), fctData.NotifyOrWriteCmdType(nil, nil, false, nil))
+	r.notifyChanges(fctData, previousData)
 
 	payload := api.EventPayload{
 		Ski:           msg.FeatureRemote.Device().Ski(),
@@ -806,7 +1175,7 @@ func (r *FeatureLocal) executeWrite(msg *api.Message) *model.ErrorType {
 		CmdClassifier: util.Ptr(model.CmdClassifierTypeWrite),
 		Data:          cmdData.Value,
 	}
-	Events.Publish(payload)
+	r.Device().Events().Publish(payload)
 
 	return nil
 }
@@ -822,7 +1191,7 @@ func (r *FeatureLocal) functionData(function model.FunctionType) api.FunctionDat
 
 func (r *FeatureLocal) Information() *model.NodeManagementDetailedDiscoveryFeatureInformationType {
 	var funs []model.FunctionPropertyType
-	for fun, operations := range r.operations {
+	for fun, operations := range r.Operations() {
 		var functionType = model.FunctionType(fun)
 		sf := model.FunctionPropertyType{
 			Function:           &functionType,
@@ -837,7 +1206,7 @@ func (r *FeatureLocal) Information() *model.NodeManagementDetailedDiscoveryFeatu
 			FeatureAddress:    r.Address(),
 			FeatureType:       &r.ftype,
 			Role:              &r.role,
-			Description:       r.description,
+			Description:       r.Description(),
 			SupportedFunction: funs,
 		},
 	}
@@ -0,0 +1,235 @@
+package spine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Send a read request for a function to a remote feature and wait for the reply
+//
+// Blocks until the reply or a result message is received, the maximum response delay
+// of the remote feature is exceeded or the context is done.
+//
+// Note: the type has to be a pointer to the function data type, e.g. *model.MeasurementListDataType
+func RequestRemoteDataCtx[T any](
+	ctx context.Context,
+	feature api.FeatureLocalInterface,
+	function model.FunctionType,
+	selector any,
+	elements any,
+	remote api.FeatureRemoteInterface) (T, *model.ErrorType) {
+	x := any(*new(T))
+
+	if feature == nil || remote == nil {
+		return x.(T), model.NewErrorTypeFromString("feature not found")
+	}
+
+	msg, err := waitForResponse(ctx, feature, remote.Address(), func() (*model.MsgCounterType, *model.ErrorType) {
+		return feature.RequestRemoteData(function, selector, elements, remote)
+	})
+	if err != nil {
+		return x.(T), err
+	}
+
+	if result, ok := msg.Data.(*model.ResultDataType); ok {
+		if err := errorFromResultData(result); err != nil {
+			return x.(T), err
+		}
+
+		return x.(T), model.NewErrorTypeFromString("no reply data received")
+	}
+
+	data, err1 := dataCopyOfType[T](msg.Data)
+	if err1 != nil {
+		return x.(T), model.NewErrorTypeFromString(fmt.Sprintf("unexpected reply data type %T", msg.Data))
+	}
+
+	return data, nil
+}
+
+// Send a write with one or more cmds to a remote feature and wait for the result
+//
+// Blocks until the result message is received, the maximum response delay
+// of the remote feature is exceeded or the context is done.
+func WriteRemoteDataCtx(
+	ctx context.Context,
+	feature api.FeatureLocalInterface,
+	remote api.FeatureRemoteInterface,
+	cmd ...model.CmdType) *model.ErrorType {
+	if feature == nil || remote == nil {
+		return model.NewErrorTypeFromString("feature not found")
+	}
+
+	return waitForResult(ctx, feature, remote.Address(), func() (*model.MsgCounterType, *model.ErrorType) {
+		msgCounter, err := remote.Device().Sender().Write(feature.Address(), remote.Address(), cmd...)
+		if err != nil {
+			return nil, model.NewErrorTypeFromString(err.Error())
+		}
+
+		return msgCounter, nil
+	})
+}
+
+// Send a subscription request to a remote feature and wait for the result
+//
+// Blocks until the result message is received, the maximum response delay
+// of the remote feature is exceeded or the context is done.
+func SubscribeToRemoteCtx(ctx context.Context, feature api.FeatureLocalInterface, remoteAddress *model.FeatureAddressType) *model.ErrorType {
+	if feature == nil {
+		return model.NewErrorTypeFromString("feature not found")
+	}
+
+	// the subscription request is sent via the NodeManagement feature
+	return waitForResult(ctx, feature.Device().NodeManagement(), remoteAddress, func() (*model.MsgCounterType, *model.ErrorType) {
+		return feature.SubscribeToRemote(remoteAddress)
+	})
+}
+
+// Send a binding request to a remote feature and wait for the result
+//
+// Blocks until the result message is received, the maximum response delay
+// of the remote feature is exceeded or the context is done.
+func BindToRemoteCtx(ctx context.Context, feature api.FeatureLocalInterface, remoteAddress *model.FeatureAddressType) *model.ErrorType {
+	if feature == nil {
+		return model.NewErrorTypeFromString("feature not found")
+	}
+
+	// the binding request is sent via the NodeManagement feature
+	return waitForResult(ctx, feature.Device().NodeManagement(), remoteAddress, func() (*model.MsgCounterType, *model.ErrorType) {
+		return feature.BindToRemote(remoteAddress)
+	})
+}
+
+// send a request and wait for its result message, return its error if there is any
+func waitForResult(
+	ctx context.Context,
+	feature api.FeatureLocalInterface,
+	remoteAddress *model.FeatureAddressType,
+	send func() (*model.MsgCounterType, *model.ErrorType)) *model.ErrorType {
+	msg, err := waitForResponse(ctx, feature, remoteAddress, send)
+	if err != nil {
+		return err
+	}
+
+	if result, ok := msg.Data.(*model.ResultDataType); ok {
+		return errorFromResultData(result)
+	}
+
+	return nil
+}
+
+// implemented by local features, which track the requests they receive responses for
+type requestTracker interface {
+	holdResponses() (release func())
+	addPendingRequest(
+		msgCounter model.MsgCounterType,
+		sender api.SenderInterface,
+		featureRemote api.FeatureRemoteInterface,
+		destinationAddress *model.FeatureAddressType,
+		maxDelay time.Duration)
+}
+
+// send a request to a remote feature and wait for the response message received by the feature
+//
+// the response callback is added before any response is processed and always removed when returning
+func waitForResponse(
+	ctx context.Context,
+	feature api.FeatureLocalInterface,
+	remoteAddress *model.FeatureAddressType,
+	send func() (*model.MsgCounterType, *model.ErrorType)) (api.ResponseMessage, *model.ErrorType) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	remote := remoteFeatureForAddress(feature, remoteAddress)
+	maxDelay := defaultMaxResponseDelay
+	if remote != nil {
+		maxDelay = remote.MaxResponseDelayDuration()
+	}
+
+	tracker, isTracker := feature.(requestTracker)
+	if !isTracker {
+		// without a tracked request, a missing response does not expire the callback
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, maxDelay)
+		defer cancel()
+	}
+
+	responseCh := make(chan api.ResponseMessage, 1)
+	cb := func(msg api.ResponseMessage) {
+		select {
+		case responseCh <- msg:
+		default:
+		}
+	}
+
+	release := func() {}
+	if isTracker {
+		release = tracker.holdResponses()
+	}
+
+	msgCounter, err := send()
+	if err == nil && msgCounter == nil {
+		err = model.NewErrorTypeFromString("msgCounter not found")
+	}
+	if err != nil {
+		release()
+		return api.ResponseMessage{}, err
+	}
+
+	handle, err1 := feature.AddResponseCallbackWithHandle(*msgCounter, cb)
+	if err1 == nil && isTracker {
+		// the callback is invoked with a timeout error, if the remote does not respond in time
+		var sender api.SenderInterface
+		if remote != nil {
+			sender = remote.Device().Sender()
+		}
+		tracker.addPendingRequest(*msgCounter, sender, remote, remoteAddress, maxDelay)
+	}
+	release()
+	if err1 != nil {
+		return api.ResponseMessage{}, model.NewErrorTypeFromString(err1.Error())
+	}
+	defer feature.RemoveResponseCallback(handle)
+
+	select {
+	case msg := <-responseCh:
+		return msg, nil
+	case <-ctx.Done():
+		return api.ResponseMessage{}, model.NewErrorType(
+			model.ErrorNumberTypeTimeout,
+			fmt.Sprintf("no response received for msgCounter %d: %s", *msgCounter, ctx.Err()))
+	}
+}
+
+// return the remote feature with the address, nil if it is not known
+func remoteFeatureForAddress(feature api.FeatureLocalInterface, remoteAddress *model.FeatureAddressType) api.FeatureRemoteInterface {
+	if remoteAddress == nil || remoteAddress.Device == nil {
+		return nil
+	}
+
+	remoteDevice := feature.Device().RemoteDeviceForAddress(*remoteAddress.Device)
+	if remoteDevice == nil {
+		return nil
+	}
+
+	return remoteDevice.FeatureByAddress(remoteAddress)
+}
+
+// convert an error result to an ErrorType, nil if the result is no error
+func errorFromResultData(result *model.ResultDataType) *model.ErrorType {
+	if result == nil || result.ErrorNumber == nil || *result.ErrorNumber == model.ErrorNumberTypeNoError {
+		return nil
+	}
+
+	description := ""
+	if result.Description != nil {
+		description = string(*result.Description)
+	}
+
+	return model.NewErrorType(*result.ErrorNumber, description)
+}
@@ -0,0 +1,251 @@
+package spine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestFeatureLocalCtxSuite(t *testing.T) {
+	suite.Run(t, new(FeatureLocalCtxTestSuite))
+}
+
+type FeatureLocalCtxTestSuite struct {
+	suite.Suite
+
+	senderMock    *mocks.SenderInterface
+	localDevice   *DeviceLocal
+	localFeature  api.FeatureLocalInterface
+	remoteFeature api.FeatureRemoteInterface
+	function      model.FunctionType
+	msgCounter    model.MsgCounterType
+}
+
+func (s *FeatureLocalCtxTestSuite) BeforeTest(suiteName, testName string) {
+	s.senderMock = mocks.NewSenderInterface(s.T())
+	s.function = model.FunctionTypeDeviceClassificationManufacturerData
+	s.msgCounter = model.MsgCounterType(10)
+
+	var localEntity *EntityLocal
+	s.localDevice, localEntity = createLocalDeviceAndEntity(1)
+	s.localFeature, _ = createLocalFeatures(localEntity, model.FeatureTypeTypeDeviceClassification, "")
+
+	remoteDevice := createRemoteDevice(s.localDevice, "ski", s.senderMock)
+	_, s.remoteFeature = createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeDeviceClassification, s.function)
+	s.remoteFeature.SetMaxResponseDelay(util.Ptr(model.MaxResponseDelayType("PT0.2S")))
+	s.localDevice.AddRemoteDeviceForSki(remoteDevice.Ski(), remoteDevice)
+}
+
+// deliver a response message to a local feature while the request is sent,
+// like a remote device answering before the sender returned the msgCounter
+func (s *FeatureLocalCtxTestSuite) respond(feature api.FeatureLocalInterface, classifier model.CmdClassifierType, cmd model.CmdType) {
+	msg := &api.Message{
+		Cmd:           cmd,
+		CmdClassifier: classifier,
+		RequestHeader: &model.HeaderType{
+			MsgCounter:          util.Ptr(model.MsgCounterType(1)),
+			MsgCounterReference: &s.msgCounter,
+		},
+		FeatureRemote: s.remoteFeature,
+		EntityRemote:  s.remoteFeature.Entity(),
+		DeviceRemote:  s.remoteFeature.Device(),
+	}
+	_ = feature.HandleMessage(msg)
+}
+
+func resultCmd(errorNumber model.ErrorNumberType) model.CmdType {
+	return model.CmdType{
+		ResultData: &model.ResultDataType{
+			ErrorNumber: util.Ptr(errorNumber),
+			Description: util.Ptr(model.DescriptionType("description")),
+		},
+	}
+}
+
+func (s *FeatureLocalCtxTestSuite) Test_RequestRemoteDataCtx() {
+	manufacturerData := &model.DeviceClassificationManufacturerDataType{
+		BrandName: util.Ptr(model.DeviceClassificationStringType("brand name")),
+	}
+
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, mock.Anything, mock.Anything, false, mock.Anything).
+		Run(func(model.CmdClassifierType, *model.FeatureAddressType, *model.FeatureAddressType, bool, []model.CmdType) {
+			s.respond(s.localFeature, model.CmdClassifierTypeReply, model.CmdType{DeviceClassificationManufacturerData: manufacturerData})
+		}).Return(&s.msgCounter, nil).Once()
+
+	data, err := RequestRemoteDataCtx[*model.DeviceClassificationManufacturerDataType](
+		context.Background(), s.localFeature, s.function, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), err)
+	if assert.NotNil(s.T(), data) {
+		assert.Equal(s.T(), "brand name", string(*data.BrandName))
+	}
+
+	// error result
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, mock.Anything, mock.Anything, false, mock.Anything).
+		Run(func(model.CmdClassifierType, *model.FeatureAddressType, *model.FeatureAddressType, bool, []model.CmdType) {
+			s.respond(s.localFeature, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeCommandRejected))
+		}).Return(&s.msgCounter, nil).Once()
+
+	data, err = RequestRemoteDataCtx[*model.DeviceClassificationManufacturerDataType](
+		context.Background(), s.localFeature, s.function, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), data)
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+	}
+
+	// unexpected data type
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, mock.Anything, mock.Anything, false, mock.Anything).
+		Run(func(model.CmdClassifierType, *model.FeatureAddressType, *model.FeatureAddressType, bool, []model.CmdType) {
+			s.respond(s.localFeature, model.CmdClassifierTypeReply, model.CmdType{DeviceClassificationManufacturerData: manufacturerData})
+		}).Return(&s.msgCounter, nil).Once()
+
+	wrongData, err := RequestRemoteDataCtx[*model.MeasurementListDataType](
+		context.Background(), s.localFeature, s.function, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), wrongData)
+	assert.NotNil(s.T(), err)
+
+	// timeout, the callback has to be removed
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, mock.Anything, mock.Anything, false, mock.Anything).
+		Return(&s.msgCounter, nil).Once()
+	// the expired request is removed from the senders cache
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Once()
+
+	data, err = RequestRemoteDataCtx[*model.DeviceClassificationManufacturerDataType](
+		context.Background(), s.localFeature, s.function, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), data)
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeTimeout, err.ErrorNumber)
+	}
+	assert.Equal(s.T(), 0, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+
+	// canceled context
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, mock.Anything, mock.Anything, false, mock.Anything).
+		Return(&s.msgCounter, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data, err = RequestRemoteDataCtx[*model.DeviceClassificationManufacturerDataType](
+		ctx, s.localFeature, s.function, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), data)
+	assert.NotNil(s.T(), err)
+	assert.Equal(s.T(), 0, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+
+	// invalid function
+	data, err = RequestRemoteDataCtx[*model.DeviceClassificationManufacturerDataType](
+		context.Background(), s.localFeature, model.FunctionTypeBillListData, nil, nil, s.remoteFeature)
+	assert.Nil(s.T(), data)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *FeatureLocalCtxTestSuite) Test_WriteRemoteDataCtx() {
+	cmd := model.CmdType{
+		DeviceClassificationManufacturerData: &model.DeviceClassificationManufacturerDataType{},
+	}
+
+	s.senderMock.EXPECT().Write(mock.Anything, mock.Anything, cmd).
+		Run(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) {
+			s.respond(s.localFeature, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeNoError))
+		}).Return(&s.msgCounter, nil).Once()
+
+	err := WriteRemoteDataCtx(context.Background(), s.localFeature, s.remoteFeature, cmd)
+	assert.Nil(s.T(), err)
+
+	s.senderMock.EXPECT().Write(mock.Anything, mock.Anything, cmd).
+		Run(func(*model.FeatureAddressType, *model.FeatureAddressType, ...model.CmdType) {
+			s.respond(s.localFeature, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeGeneralError))
+		}).Return(&s.msgCounter, nil).Once()
+
+	err = WriteRemoteDataCtx(context.Background(), s.localFeature, s.remoteFeature, cmd)
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeGeneralError, err.ErrorNumber)
+	}
+
+	s.senderMock.EXPECT().Write(mock.Anything, mock.Anything, cmd).Return(&s.msgCounter, nil).Once()
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Once()
+
+	err = WriteRemoteDataCtx(context.Background(), s.localFeature, s.remoteFeature, cmd)
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeTimeout, err.ErrorNumber)
+	}
+
+	err = WriteRemoteDataCtx(context.Background(), nil, s.remoteFeature, cmd)
+	assert.NotNil(s.T(), err)
+}
+
+func (s *FeatureLocalCtxTestSuite) Test_SubscribeToRemoteCtx() {
+	nodeManagement := s.localDevice.NodeManagement()
+
+	s.senderMock.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(*model.FeatureAddressType, *model.FeatureAddressType, model.FeatureTypeType) {
+			s.respond(nodeManagement, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeNoError))
+		}).Return(&s.msgCounter, nil).Once()
+
+	err := SubscribeToRemoteCtx(context.Background(), s.localFeature, s.remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	s.senderMock.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Once()
+
+	err = SubscribeToRemoteCtx(context.Background(), s.localFeature, s.remoteFeature.Address())
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeTimeout, err.ErrorNumber)
+	}
+
+	err = SubscribeToRemoteCtx(context.Background(), s.localFeature, &model.FeatureAddressType{})
+	assert.NotNil(s.T(), err)
+}
+
+func (s *FeatureLocalCtxTestSuite) Test_SubscribeToRemote_EarlyResult() {
+	nodeManagement := s.localDevice.NodeManagement()
+
+	// the result is received before the sender returned the msgCounter
+	s.senderMock.EXPECT().Subscribe(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(*model.FeatureAddressType, *model.FeatureAddressType, model.FeatureTypeType) {
+			s.respond(nodeManagement, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeNoError))
+		}).Return(&s.msgCounter, nil).Once()
+
+	_, err := s.localFeature.SubscribeToRemote(s.remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	assert.Eventually(s.T(), func() bool {
+		state, _ := s.localFeature.SubscriptionToRemoteState(s.remoteFeature.Address())
+		return state == api.OutboundStateActive
+	}, time.Second, time.Millisecond*10)
+
+	// a binding which is never answered is rejected after the maximum response delay
+	s.senderMock.EXPECT().Bind(mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Once()
+
+	_, err = s.localFeature.BindToRemote(s.remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	assert.Eventually(s.T(), func() bool {
+		state, _ := s.localFeature.BindingToRemoteState(s.remoteFeature.Address())
+		return state == api.OutboundStateRejected
+	}, time.Second, time.Millisecond*10)
+	assert.False(s.T(), s.localFeature.HasBindingToRemote(s.remoteFeature.Address()))
+}
+
+func (s *FeatureLocalCtxTestSuite) Test_BindToRemoteCtx() {
+	nodeManagement := s.localDevice.NodeManagement()
+
+	s.senderMock.EXPECT().Bind(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(*model.FeatureAddressType, *model.FeatureAddressType, model.FeatureTypeType) {
+			s.respond(nodeManagement, model.CmdClassifierTypeResult, resultCmd(model.ErrorNumberTypeCommandRejected))
+		}).Return(&s.msgCounter, nil).Once()
+
+	err := BindToRemoteCtx(context.Background(), s.localFeature, s.remoteFeature.Address())
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+	}
+
+	err = BindToRemoteCtx(context.Background(), nil, s.remoteFeature.Address())
+	assert.NotNil(s.T(), err)
+}
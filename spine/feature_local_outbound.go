@@ -0,0 +1,272 @@
+package spine
+
+import (
+	"reflect"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// identifies a subscription or binding of a local feature to a remote feature
+type outboundStateKey struct {
+	entryType     api.OutboundEntryType
+	remoteAddress string
+}
+
+func newOutboundStateKey(entryType api.OutboundEntryType, remoteAddress *model.FeatureAddressType) outboundStateKey {
+	return outboundStateKey{
+		entryType:     entryType,
+		remoteAddress: remoteAddress.String(),
+	}
+}
+
+// the lifecycle state of a subscription or binding to a remote feature
+type outboundState struct {
+	state        api.OutboundStateType
+	msgCounter   *model.MsgCounterType // of the last request, results of older requests are ignored
+	remoteDevice api.DeviceRemoteInterface
+}
+
+// a state change, which is published once the mux of the feature is unlocked
+type outboundStateEvent struct {
+	change       api.OutboundStateChange
+	remoteDevice api.DeviceRemoteInterface
+}
+
+// Get the state of the subscription to a remote feature, returns false if no subscription was requested
+func (r *FeatureLocal) SubscriptionToRemoteState(remoteAddress *model.FeatureAddressType) (api.OutboundStateType, bool) {
+	return r.outboundState(api.OutboundEntryTypeSubscription, remoteAddress)
+}
+
+// Get the state of the binding to a remote feature, returns false if no binding was requested
+func (r *FeatureLocal) BindingToRemoteState(remoteAddress *model.FeatureAddressType) (api.OutboundStateType, bool) {
+	return r.outboundState(api.OutboundEntryTypeBinding, remoteAddress)
+}
+
+func (r *FeatureLocal) outboundState(entryType api.OutboundEntryType, remoteAddress *model.FeatureAddressType) (api.OutboundStateType, bool) {
+	if remoteAddress == nil {
+		return 0, false
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	current, ok := r.outboundStates[newOutboundStateKey(entryType, remoteAddress)]
+	if !ok {
+		return 0, false
+	}
+
+	return current.state, true
+}
+
+// hold back the results received by the NodeManagement feature until the returned function is called
+//
+// has to be called before a subscription or binding request is sent, so the result of the request
+// is processed by trackOutboundRequest even if it is received before the request is tracked
+func (r *FeatureLocal) holdOutboundResults() (release func()) {
+	if tracker, ok := r.Device().NodeManagement().(requestTracker); ok {
+		return tracker.holdResponses()
+	}
+
+	return func() {}
+}
+
+// set a sent subscription or binding request to pending and track its result
+//
+// the request is sent via the NodeManagement feature, which receives the result,
+// if no result is received within the maximum response delay, the request is rejected
+func (r *FeatureLocal) trackOutboundRequest(
+	entryType api.OutboundEntryType,
+	remoteAddress *model.FeatureAddressType,
+	remoteDevice api.DeviceRemoteInterface,
+	msgCounter *model.MsgCounterType) {
+	// the msgCounter is copied, as the caller may reuse it
+	var counter *model.MsgCounterType
+	if msgCounter != nil {
+		counter = util.Ptr(*msgCounter)
+	}
+
+	r.mux.Lock()
+	r.outboundStates[newOutboundStateKey(entryType, remoteAddress)] = &outboundState{
+		state:        api.OutboundStatePending,
+		msgCounter:   counter,
+		remoteDevice: remoteDevice,
+	}
+	r.mux.Unlock()
+
+	if counter != nil {
+		nodeManagement := r.Device().NodeManagement()
+		err := nodeManagement.AddResponseCallback(*counter, func(msg api.ResponseMessage) {
+			r.processOutboundResult(entryType, remoteAddress, *counter, msg)
+		})
+
+		if tracker, ok := nodeManagement.(requestTracker); ok && err == nil {
+			remoteFeature := remoteDevice.FeatureByAddress(remoteAddress)
+			maxDelay := defaultMaxResponseDelay
+			if remoteFeature != nil {
+				maxDelay = remoteFeature.MaxResponseDelayDuration()
+			}
+			tracker.addPendingRequest(*counter, remoteDevice.Sender(), remoteFeature, remoteAddress, maxDelay)
+		}
+	}
+
+	r.publishOutboundStates(&outboundStateEvent{
+		change: api.OutboundStateChange{
+			Type:          entryType,
+			State:         api.OutboundStatePending,
+			RemoteAddress: remoteAddress,
+		},
+		remoteDevice: remoteDevice,
+	})
+}
+
+// activate a pending subscription or binding, or roll it back if the remote rejected it
+func (r *FeatureLocal) processOutboundResult(
+	entryType api.OutboundEntryType,
+	remoteAddress *model.FeatureAddressType,
+	msgCounter model.MsgCounterType,
+	msg api.ResponseMessage) {
+	var err *model.ErrorType
+	if result, ok := msg.Data.(*model.ResultDataType); ok {
+		err = errorFromResultData(result)
+	}
+
+	r.mux.Lock()
+	// the subscription or binding may have been requested again or removed in the meantime
+	current, ok := r.outboundStates[newOutboundStateKey(entryType, remoteAddress)]
+	if !ok || current.state != api.OutboundStatePending ||
+		current.msgCounter == nil || *current.msgCounter != msgCounter {
+		r.mux.Unlock()
+		return
+	}
+
+	state := api.OutboundStateActive
+	if err != nil {
+		state = api.OutboundStateRejected
+
+		if entryType == api.OutboundEntryTypeBinding {
+			r.bindings = withoutFeatureAddress(r.bindings, remoteAddress)
+		} else {
+			r.subscriptions = withoutFeatureAddress(r.subscriptions, remoteAddress)
+		}
+	}
+	event := r.updateOutboundState(entryType, remoteAddress, state, err)
+	r.mux.Unlock()
+
+	// a rejected subscription or binding is not restored after a reconnect
+	if err != nil {
+		if entries := r.outboundEntries(); entries != nil {
+			entries.remove(entryType, r, remoteAddress)
+		}
+	}
+
+	r.publishOutboundStates(event)
+}
+
+// update the state of a subscription or binding, returns nil if it is not known
+//
+// r.mux has to be locked by the caller, the returned event has to be published afterwards
+func (r *FeatureLocal) updateOutboundState(
+	entryType api.OutboundEntryType,
+	remoteAddress *model.FeatureAddressType,
+	state api.OutboundStateType,
+	err *model.ErrorType) *outboundStateEvent {
+	current, ok := r.outboundStates[newOutboundStateKey(entryType, remoteAddress)]
+	if !ok {
+		return nil
+	}
+
+	current.state = state
+
+	return &outboundStateEvent{
+		change: api.OutboundStateChange{
+			Type:          entryType,
+			State:         state,
+			RemoteAddress: remoteAddress,
+			Error:         err,
+		},
+		remoteDevice: current.remoteDevice,
+	}
+}
+
+// publish state changes of subscriptions and bindings, nil events are skipped
+func (r *FeatureLocal) publishOutboundStates(events ...*outboundStateEvent) {
+	// the node management subscribes while the stack handles an event, so publishing is not possible,
+	// its subscriptions are managed by the stack anyway
+	if r.Type() == model.FeatureTypeTypeNodeManagement {
+		return
+	}
+
+	for _, event := range events {
+		if event == nil || event.remoteDevice == nil {
+			continue
+		}
+
+		changeType := api.ElementChangeUpdate
+		switch event.change.State {
+		case api.OutboundStatePending:
+			changeType = api.ElementChangeAdd
+		case api.OutboundStateRemoved:
+			changeType = api.ElementChangeRemove
+		}
+
+		payload := api.EventPayload{
+			Ski:          event.remoteDevice.Ski(),
+			EventType:    api.EventTypeOutboundStateChange,
+			ChangeType:   changeType,
+			Device:       event.remoteDevice,
+			LocalFeature: r,
+			Data:         event.change,
+		}
+		if remoteFeature := event.remoteDevice.FeatureByAddress(event.change.RemoteAddress); remoteFeature != nil {
+			payload.Feature = remoteFeature
+			payload.Entity = remoteFeature.Entity()
+		}
+		r.Device().Events().Publish(payload)
+	}
+}
+
+// remove the subscriptions and bindings to remote features matching the filter from the local cache
+func (r *FeatureLocal) removeOutboundMatching(match func(address *model.FeatureAddressType) bool) {
+	var events []*outboundStateEvent
+
+	r.mux.Lock()
+
+	var subscriptions []*model.FeatureAddressType
+	for _, item := range r.subscriptions {
+		if match(item) {
+			events = append(events, r.updateOutboundState(api.OutboundEntryTypeSubscription, item, api.OutboundStateRemoved, nil))
+			continue
+		}
+		subscriptions = append(subscriptions, item)
+	}
+	r.subscriptions = subscriptions
+
+	var bindings []*model.FeatureAddressType
+	for _, item := range r.bindings {
+		if match(item) {
+			events = append(events, r.updateOutboundState(api.OutboundEntryTypeBinding, item, api.OutboundStateRemoved, nil))
+			continue
+		}
+		bindings = append(bindings, item)
+	}
+	r.bindings = bindings
+
+	r.mux.Unlock()
+
+	r.publishOutboundStates(events...)
+}
+
+// return the list without the feature address
+func withoutFeatureAddress(list []*model.FeatureAddressType, address *model.FeatureAddressType) []*model.FeatureAddressType {
+	var res []*model.FeatureAddressType
+	for _, item := range list {
+		if reflect.DeepEqual(item, address) {
+			continue
+		}
+		res = append(res, item)
+	}
+
+	return res
+}
@@ -51,6 +51,12 @@ func (s *LocalFeatureTestSuite) BeforeTest(suiteName, testName string) {
 	s.remote2Feature, _ = createRemoteEntityAndFeature(remoteDevice2, 1, s.featureType, s.function)
 }
 
+func (s *LocalFeatureTestSuite) AfterTest(suiteName, testName string) {
+	// expire the unanswered subscription and binding requests, so their timers do not outlive the test
+	s.senderMock.On("ProcessResponseForMsgCounterReference", mock.Anything).Maybe()
+	s.localDevice.NodeManagement().(*NodeManagement).shutdown()
+}
+
 func (s *LocalFeatureTestSuite) TestDeviceClassification_Functions() {
 	fcts := s.localServerFeatureWrite.Functions()
 	assert.NotNil(s.T(), fcts)
@@ -61,15 +67,101 @@ func (s *LocalFeatureTestSuite) TestDeviceClassification_Functions() {
 func (s *LocalFeatureTestSuite) TestDeviceClassification_ResponseCB() {
 	testFct := func(msg api.ResponseMessage) {}
 	msgCounter := model.MsgCounterType(100)
-	err := s.localFeature.AddResponseCallback(msgCounter, testFct)
+	handle1, err := s.localFeature.AddResponseCallbackWithHandle(msgCounter, testFct)
 	assert.Nil(s.T(), err)
 
-	err = s.localFeature.AddResponseCallback(msgCounter, testFct)
+	// distinct callbacks may use the same function
+	handle2, err := s.localFeature.AddResponseCallbackWithHandle(msgCounter, testFct)
+	assert.Nil(s.T(), err)
+	assert.NotEqual(s.T(), handle1, handle2)
+	assert.Equal(s.T(), 2, len(s.localFeature.(*FeatureLocal).responseMsgCallback[msgCounter].callbacks))
+
+	// no request was sent, so the callbacks do not expire
+	assert.Nil(s.T(), s.localFeature.(*FeatureLocal).responseMsgCallback[msgCounter].timer)
+
+	err = s.localFeature.AddResponseCallback(msgCounter, nil)
 	assert.NotNil(s.T(), err)
 
 	s.localFeature.AddResultCallback(testFct)
 }
 
+func (s *LocalFeatureTestSuite) Test_RemoveResponseCallback() {
+	msgCounter := model.MsgCounterType(100)
+	responseCh := make(chan api.ResponseMessage, 1)
+	handle1, err := s.localFeature.AddResponseCallbackWithHandle(msgCounter, func(msg api.ResponseMessage) {})
+	assert.Nil(s.T(), err)
+	handle2, err := s.localFeature.AddResponseCallbackWithHandle(msgCounter, func(msg api.ResponseMessage) {
+		responseCh <- msg
+	})
+	assert.Nil(s.T(), err)
+	assert.NotEqual(s.T(), handle1, handle2)
+
+	// only the callback of the handle is removed
+	s.localFeature.RemoveResponseCallback(handle1)
+	s.localFeature.RemoveResponseCallback(api.ResponseCallbackHandle{MsgCounterReference: 101, ID: handle2.ID})
+
+	msg := &api.Message{
+		Cmd:           model.CmdType{ResultData: &model.ResultDataType{ErrorNumber: util.Ptr(model.ErrorNumberTypeNoError)}},
+		CmdClassifier: model.CmdClassifierTypeResult,
+		RequestHeader: &model.HeaderType{
+			MsgCounterReference: util.Ptr(msgCounter),
+		},
+		FeatureRemote: s.remoteFeature,
+	}
+	err1 := s.localFeature.HandleMessage(msg)
+	assert.Nil(s.T(), err1)
+
+	select {
+	case <-responseCh:
+	case <-time.After(time.Second):
+		s.T().Fatal("response callback was not invoked")
+	}
+
+	// removing the last callback removes the pending response
+	handle3, err := s.localFeature.AddResponseCallbackWithHandle(msgCounter, func(msg api.ResponseMessage) {})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+	s.localFeature.RemoveResponseCallback(handle3)
+	assert.Equal(s.T(), 0, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+}
+
+func (s *LocalFeatureTestSuite) Test_ResponseCallback_ReceivedWhileSending() {
+	msgCounter := model.MsgCounterType(100)
+	feature := s.localFeature.(*FeatureLocal)
+
+	// the response is received before the callback can be added
+	release := feature.holdResponses()
+	msg := &api.Message{
+		Cmd:           model.CmdType{ResultData: &model.ResultDataType{ErrorNumber: util.Ptr(model.ErrorNumberTypeNoError)}},
+		CmdClassifier: model.CmdClassifierTypeResult,
+		RequestHeader: &model.HeaderType{
+			MsgCounterReference: util.Ptr(msgCounter),
+		},
+		FeatureRemote: s.remoteFeature,
+	}
+	err := feature.HandleMessage(msg)
+	assert.Nil(s.T(), err)
+
+	responseCh := make(chan api.ResponseMessage, 1)
+	err1 := feature.AddResponseCallback(msgCounter, func(msg api.ResponseMessage) {
+		responseCh <- msg
+	})
+	assert.Nil(s.T(), err1)
+	release()
+	release()
+
+	select {
+	case msg := <-responseCh:
+		assert.Equal(s.T(), msgCounter, msg.MsgCounterReference)
+	case <-time.After(time.Second):
+		s.T().Fatal("response callback was not invoked")
+	}
+
+	assert.Equal(s.T(), 0, len(feature.responseMsgCallback))
+	assert.Equal(s.T(), 0, len(feature.earlyResponses))
+	assert.Equal(s.T(), 0, feature.sendingRequests)
+}
+
 func (s *LocalFeatureTestSuite) TestDeviceClassification_Request_Reply() {
 	dummyAddress := &model.FeatureAddressType{
 		Device:  util.Ptr(model.AddressDeviceType("")),
@@ -252,6 +344,78 @@ func (s *LocalFeatureTestSuite) TestDeviceClassification_Bindings() {
 	s.localFeature.RemoveAllRemoteBindings()
 }
 
+func (s *LocalFeatureTestSuite) Test_ResponseCallback_Timeout() {
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, s.localFeature.Address(), s.remoteFeature.Address(), false, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Return().Once()
+
+	cmd := model.CmdType{
+		DeviceClassificationManufacturerData: &model.DeviceClassificationManufacturerDataType{},
+	}
+	msgCounter, err := s.localFeature.RequestRemoteDataBySenderAddress(cmd, s.senderMock, "ski", s.remoteFeature.Address(), time.Millisecond*50)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), msgCounter)
+
+	responseCh := make(chan api.ResponseMessage, 1)
+	err1 := s.localFeature.AddResponseCallback(*msgCounter, func(msg api.ResponseMessage) {
+		responseCh <- msg
+	})
+	assert.Nil(s.T(), err1)
+
+	select {
+	case msg := <-responseCh:
+		result, ok := msg.Data.(*model.ResultDataType)
+		if assert.True(s.T(), ok) {
+			assert.Equal(s.T(), model.ErrorNumberTypeTimeout, *result.ErrorNumber)
+		}
+		assert.Equal(s.T(), *msgCounter, msg.MsgCounterReference)
+	case <-time.After(time.Second):
+		s.T().Fatal("response callback was not invoked")
+	}
+
+	assert.Equal(s.T(), 0, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+}
+
+func (s *LocalFeatureTestSuite) Test_ResponseCallback_Disconnect() {
+	s.localDevice.AddRemoteDeviceForSki(s.remoteFeature.Device().Ski(), s.remoteFeature.Device())
+
+	s.senderMock.EXPECT().Request(model.CmdClassifierTypeRead, s.localFeature.Address(), s.remoteServerFeature.Address(), false, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.senderMock.EXPECT().ProcessResponseForMsgCounterReference(&s.msgCounter).Return().Once()
+
+	msgCounter, err := s.localFeature.RequestRemoteData(s.function, nil, nil, s.remoteServerFeature)
+	assert.Nil(s.T(), err)
+	assert.NotNil(s.T(), msgCounter)
+
+	responseCh := make(chan api.ResponseMessage, 1)
+	err1 := s.localFeature.AddResponseCallback(*msgCounter, func(msg api.ResponseMessage) {
+		responseCh <- msg
+	})
+	assert.Nil(s.T(), err1)
+
+	// a disconnect of another device does not expire the callback
+	s.localFeature.CleanRemoteDeviceCaches(&model.DeviceAddressType{
+		Device: util.Ptr(model.AddressDeviceType("other")),
+	})
+	assert.Equal(s.T(), 1, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+
+	s.localFeature.CleanRemoteDeviceCaches(&model.DeviceAddressType{
+		Device: s.remoteServerFeature.Address().Device,
+	})
+
+	select {
+	case msg := <-responseCh:
+		result, ok := msg.Data.(*model.ResultDataType)
+		if assert.True(s.T(), ok) {
+			assert.Equal(s.T(), model.ErrorNumberTypeDestinationUnreachable, *result.ErrorNumber)
+		}
+		assert.Equal(s.T(), s.remoteServerFeature, msg.FeatureRemote)
+		assert.Equal(s.T(), s.remoteServerFeature.Device(), msg.DeviceRemote)
+	case <-time.After(time.Second):
+		s.T().Fatal("response callback was not invoked")
+	}
+
+	assert.Equal(s.T(), 0, len(s.localFeature.(*FeatureLocal).responseMsgCallback))
+}
+
 func (s *LocalFeatureTestSuite) Test_CleanRemoteDeviceCaches() {
 	s.senderMock.On("Subscribe", mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil)
 	s.senderMock.On("Bind", mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil)
@@ -474,6 +638,77 @@ func (s *LocalFeatureTestSuite) Test_Read() {
 	assert.NotNil(s.T(), err)
 }
 
+func (s *LocalFeatureTestSuite) Test_Read_Partial() {
+	data := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{
+				LimitId:           util.Ptr(model.LoadControlLimitIdType(0)),
+				IsLimitChangeable: util.Ptr(true),
+				Value:             model.NewScaledNumberType(16),
+			},
+			{
+				LimitId:           util.Ptr(model.LoadControlLimitIdType(1)),
+				IsLimitChangeable: util.Ptr(true),
+				Value:             model.NewScaledNumberType(32),
+			},
+		},
+	}
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// partial reads are not announced unless enabled
+	assert.False(s.T(), s.localServerFeatureWrite.Operations()[s.serverWriteFunction].ReadPartial())
+	err := s.localServerFeatureWrite.EnablePartialRead(s.serverWriteFunction)
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), s.localServerFeatureWrite.Operations()[s.serverWriteFunction].ReadPartial())
+	assert.True(s.T(), s.localServerFeatureWrite.Operations()[s.serverWriteFunction].WritePartial())
+
+	err = s.localServerFeatureWrite.EnablePartialRead(model.FunctionTypeBillListData)
+	assert.NotNil(s.T(), err)
+	err = s.localFeature.EnablePartialRead(s.function)
+	assert.NotNil(s.T(), err)
+
+	filter := model.NewFilterTypePartial()
+	filter.LoadControlLimitListDataSelectors = &model.LoadControlLimitListDataSelectorsType{
+		LimitId: util.Ptr(model.LoadControlLimitIdType(1)),
+	}
+	filter.LoadControlLimitDataElements = &model.LoadControlLimitDataElementsType{
+		Value: &model.ScaledNumberElementsType{},
+	}
+
+	msg := &api.Message{
+		FeatureRemote: s.remoteSubFeature,
+		CmdClassifier: model.CmdClassifierTypeRead,
+		Cmd: model.CmdType{
+			Function:                 util.Ptr(s.serverWriteFunction),
+			Filter:                   []model.FilterType{*filter},
+			LoadControlLimitListData: &model.LoadControlLimitListDataType{},
+		},
+		FilterPartial: filter,
+	}
+
+	s.senderMock.EXPECT().Reply(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(
+		func(header *model.HeaderType, address *model.FeatureAddressType, cmd model.CmdType) error {
+			assert.Equal(s.T(), 1, len(cmd.Filter))
+			assert.NotNil(s.T(), cmd.Filter[0].CmdControl.Partial)
+			assert.NotNil(s.T(), cmd.LoadControlLimitListData)
+			assert.Equal(s.T(), 1, len(cmd.LoadControlLimitListData.LoadControlLimitData))
+			item := cmd.LoadControlLimitListData.LoadControlLimitData[0]
+			assert.Equal(s.T(), model.LoadControlLimitIdType(1), *item.LimitId)
+			assert.Equal(s.T(), 32.0, item.Value.GetValue())
+			assert.Nil(s.T(), item.IsLimitChangeable)
+			return nil
+		}).Once()
+
+	errType := s.localServerFeatureWrite.HandleMessage(msg)
+	assert.Nil(s.T(), errType)
+
+	// a filter without data is rejected instead of answered with the full data
+	msg.FilterPartial = &model.FilterType{}
+	errType = s.localServerFeatureWrite.HandleMessage(msg)
+	assert.NotNil(s.T(), errType)
+	assert.Equal(s.T(), model.ErrorNumberTypeCommandNotSupported, errType.ErrorNumber)
+}
+
 func (s *LocalFeatureTestSuite) Test_Reply() {
 	msg := &api.Message{
 		FeatureRemote: s.remoteServerFeature,
@@ -861,3 +1096,106 @@ func (s *LocalFeatureTestSuite) Test_Set_Update() {
 	assert.False(s.T(), *modelData.LoadControlLimitData[1].IsLimitChangeable)
 	assert.Nil(s.T(), modelData.LoadControlLimitData[1].TimePeriod)
 }
+
+func (s *LocalFeatureTestSuite) Test_SetData_NotifyChanges() {
+	err := s.localDevice.SubscriptionManager().AddSubscription(s.remoteSubFeature.Device(), model.SubscriptionManagementRequestCallType{
+		ClientAddress:     s.remoteSubFeature.Address(),
+		ServerAddress:     s.localServerFeatureWrite.Address(),
+		ServerFeatureType: util.Ptr(s.subFeatureType),
+	})
+	assert.Nil(s.T(), err)
+
+	data := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(false)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(2)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+
+	// the initial data is sent as a full notify
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			assert.Nil(s.T(), cmd[0].Filter)
+			assert.Equal(s.T(), 2, len(cmd[0].LoadControlLimitListData.LoadControlLimitData))
+		}).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// unchanged data is not sent
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// only the changed item is sent
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(true)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(2)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			assert.Equal(s.T(), filterEmptyPartial(), cmd[0].Filter)
+			assert.Equal(s.T(), data.LoadControlLimitData[:1], cmd[0].LoadControlLimitListData.LoadControlLimitData)
+		}).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// a removed item is sent as a delete notify
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(true)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			assert.Equal(s.T(), 1, len(cmd))
+			assert.NotNil(s.T(), cmd[0].Filter[0].CmdControl.Delete)
+			assert.Equal(s.T(), model.LoadControlLimitIdType(2), *cmd[0].Filter[0].LoadControlLimitListDataSelectors.LimitId)
+		}).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// a removed and a changed item are sent in a single cmd with a delete and a partial filter
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(3)), IsLimitActive: util.Ptr(true)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(4)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(3)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			if assert.Equal(s.T(), 1, len(cmd)) && assert.Equal(s.T(), 2, len(cmd[0].Filter)) {
+				assert.NotNil(s.T(), cmd[0].Filter[0].CmdControl.Delete)
+				assert.Equal(s.T(), model.LoadControlLimitIdType(4), *cmd[0].Filter[0].LoadControlLimitListDataSelectors.LimitId)
+				assert.NotNil(s.T(), cmd[0].Filter[1].CmdControl.Partial)
+				assert.Equal(s.T(), data.LoadControlLimitData, cmd[0].LoadControlLimitListData.LoadControlLimitData)
+			}
+		}).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+
+	// more than one removed item is sent as a full notify
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(5)), IsLimitActive: util.Ptr(false)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(6)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(7)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			if assert.Equal(s.T(), 1, len(cmd)) {
+				assert.Nil(s.T(), cmd[0].Filter)
+				assert.Equal(s.T(), data.LoadControlLimitData, cmd[0].LoadControlLimitListData.LoadControlLimitData)
+			}
+		}).Return(&s.msgCounter, nil).Once()
+	s.localServerFeatureWrite.SetData(s.serverWriteFunction, data)
+}
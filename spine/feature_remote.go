@@ -20,6 +20,8 @@ type FeatureRemote struct {
 	functionDataMap  map[model.FunctionType]api.FunctionDataInterface
 	maxResponseDelay *time.Duration
 
+	genericFunctions bool // the functions of all feature types were added to a Generic feature
+
 	mux sync.Mutex
 }
 
@@ -32,7 +34,8 @@ func NewFeatureRemote(id uint, entity api.EntityRemoteInterface, ftype model.Fea
 		entity:          entity,
 		functionDataMap: make(map[model.FunctionType]api.FunctionDataInterface),
 	}
-	for _, fd := range CreateFunctionData[api.FunctionDataInterface](ftype) {
+	// the functions of Generic features are added once they are used, see functionData
+	for _, fd := range createFunctionDataForFeature[api.FunctionDataInterface](ftype, false) {
 		res.functionDataMap[fd.FunctionType()] = fd
 	}
 
@@ -77,18 +80,23 @@ func (r *FeatureRemote) UpdateData(persist bool, function model.FunctionType, da
 }
 
 func (r *FeatureRemote) SetOperations(functions []model.FunctionPropertyType) {
-	r.operations = make(map[model.FunctionType]api.OperationsInterface)
+	operations := make(map[model.FunctionType]api.OperationsInterface)
 	for _, sf := range functions {
 		if sf.PossibleOperations == nil {
 			continue
 		}
-		r.operations[*sf.Function] = NewOperations(
+		operations[*sf.Function] = NewOperations(
 			sf.PossibleOperations.Read != nil,
 			sf.PossibleOperations.Read != nil && sf.PossibleOperations.Read.Partial != nil,
 			sf.PossibleOperations.Write != nil,
 			sf.PossibleOperations.Write != nil && sf.PossibleOperations.Write.Partial != nil,
 		)
 	}
+
+	r.descriptionMux.Lock()
+	defer r.descriptionMux.Unlock()
+
+	r.operations = operations
 }
 
 func (r *FeatureRemote) SetMaxResponseDelay(delay *model.MaxResponseDelayType) {
@@ -97,21 +105,52 @@ func (r *FeatureRemote) SetMaxResponseDelay(delay *model.MaxResponseDelayType) {
 	}
 	p, err := period.Parse(string(*delay))
 	if err != nil {
-		r.maxResponseDelay = util.Ptr(p.DurationApprox())
-	} else {
 		logging.Log().Debug(err)
+		return
 	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.maxResponseDelay = util.Ptr(p.DurationApprox())
 }
 
 func (r *FeatureRemote) MaxResponseDelayDuration() time.Duration {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
 	if r.maxResponseDelay != nil {
 		return *r.maxResponseDelay
 	}
 	return defaultMaxResponseDelay
 }
 
+// add the functions of all feature types to a Generic feature, if the quirk is enabled for the remote device
+//
+// the quirk is evaluated when a function is used instead of when the feature is created,
+// as rules using the manufacturer data only apply once it was read
+//
+// returns true if functions were added, mux has to be locked by the caller
+func (r *FeatureRemote) addGenericFunctions() bool {
+	if r.ftype != model.FeatureTypeTypeGeneric || r.genericFunctions ||
+		!remoteDeviceHasQuirk(r.Device(), api.QuirkGenericFeatureFunctions) {
+		return false
+	}
+
+	for _, fd := range createFunctionDataForFeature[api.FunctionDataInterface](r.ftype, true) {
+		r.functionDataMap[fd.FunctionType()] = fd
+	}
+	r.genericFunctions = true
+
+	return true
+}
+
+// mux has to be locked by the caller
 func (r *FeatureRemote) functionData(function model.FunctionType) api.FunctionDataInterface {
 	fd, found := r.functionDataMap[function]
+	if !found && r.addGenericFunctions() {
+		fd, found = r.functionDataMap[function]
+	}
 	if !found {
 		logging.Log().Errorf("Data was not found for function '%s'", function)
 		return nil
@@ -57,8 +57,15 @@ func (r *FunctionData[T]) UpdateData(remoteWrite, persist bool, newData *T, filt
 	defer r.mux.Unlock()
 
 	if filterPartial == nil && filterDelete == nil && persist {
-		// just set the data
-		r.data = newData
+		// just set the data, a deep copy of it as later partial updates modify the stored
+		// lists in place while the caller, e.g. an event handler, may still use newData
+		if newData == nil {
+			r.data = nil
+			return nil, nil
+		}
+		copiedData := new(T)
+		util.DeepCopy(newData, copiedData)
+		r.data = copiedData
 		return r.data, nil
 	}
 
@@ -1,6 +1,8 @@
 package spine
 
 import (
+	"reflect"
+
 	"github.com/enbility/spine-go/api"
 	"github.com/enbility/spine-go/model"
 	"github.com/enbility/spine-go/util"
@@ -43,6 +45,25 @@ func (r *FunctionDataCmd[T]) ReplyCmdType(partial bool) model.CmdType {
 	return cmd
 }
 
+func (r *FunctionDataCmd[T]) PartialReplyCmdType(filterPartial *model.FilterType) model.CmdType {
+	if filterPartial == nil {
+		return r.ReplyCmdType(false)
+	}
+
+	data := r.DataCopy()
+	if filterData, err := filterPartial.Data(); err == nil && data != nil {
+		if filteredData, ok := filterData.FilteredData(data).(*T); ok {
+			data = filteredData
+		}
+	}
+
+	cmd := createCmd(r.functionType, data)
+	cmd.Filter = []model.FilterType{*filterPartial}
+	cmd.Function = util.Ptr(model.FunctionType(r.functionType))
+
+	return cmd
+}
+
 func (r *FunctionDataCmd[T]) NotifyOrWriteCmdType(deleteSelector, partialSelector any, partialWithoutSelector bool, deleteElements any) model.CmdType {
 	data := r.DataCopy()
 	cmd := createCmd(r.functionType, data)
@@ -61,6 +82,84 @@ func (r *FunctionDataCmd[T]) NotifyOrWriteCmdType(deleteSelector, partialSelecto
 	return cmd
 }
 
+func (r *FunctionDataCmd[T]) NotifyDiffCmdTypes(previousData any) ([]model.CmdType, bool) {
+	previous, ok := previousData.(*T)
+	current := r.DataCopy()
+	if !ok || previous == nil || current == nil || !r.SupportsPartialWrite() {
+		return nil, false
+	}
+
+	previousList, ok1 := listFieldOfData(previous)
+	currentList, ok2 := listFieldOfData(current)
+	if !ok1 || !ok2 {
+		return nil, false
+	}
+
+	changed, removed, ok := model.DiffList(previousList.Interface(), currentList.Interface())
+	if !ok {
+		return nil, false
+	}
+
+	removedV := reflect.ValueOf(removed)
+	changedV := reflect.ValueOf(changed)
+	if removedV.Len() == 0 && changedV.Len() == 0 {
+		return nil, true
+	}
+
+	// peers only process the first cmd of a datagram, so all changes are sent in a single cmd,
+	// which can only contain one delete and one partial filter
+	if removedV.Len() > 1 {
+		return nil, false
+	}
+
+	data := new(T)
+	var filters []model.FilterType
+
+	if removedV.Len() == 1 {
+		selector := model.SelectorForItem(r.functionType, removedV.Index(0).Interface())
+		if selector == nil {
+			return nil, false
+		}
+
+		filter := model.FilterType{CmdControl: &model.CmdControlType{Delete: &model.ElementTagType{}}}
+		filters = append(filters, addSelectorToFilter(filter, r.functionType, selector))
+	}
+
+	if changedV.Len() > 0 {
+		list, _ := listFieldOfData(data)
+		list.Set(changedV)
+
+		filters = append(filters, *model.NewFilterTypePartial())
+	}
+
+	cmd := createCmd(r.functionType, data)
+	cmd.Filter = filters
+	cmd.Function = util.Ptr(r.functionType)
+
+	return []model.CmdType{cmd}, true
+}
+
+// return the list field of a list data type, which is its only slice field
+func listFieldOfData[T any](data *T) (reflect.Value, bool) {
+	v := reflect.ValueOf(data).Elem()
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	var result reflect.Value
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Kind() != reflect.Slice {
+			continue
+		}
+		if result.IsValid() {
+			return reflect.Value{}, false
+		}
+		result = v.Field(i)
+	}
+
+	return result, result.IsValid()
+}
+
 func filtersForSelectorsElements(functionType model.FunctionType, filters []model.FilterType, deleteSelector, partialSelector any, deleteElements, readElements any) []model.FilterType {
 	if !util.IsNil(deleteSelector) || !util.IsNil(deleteElements) {
 		filter := model.FilterType{CmdControl: &model.CmdControlType{Delete: &model.ElementTagType{}}}
@@ -56,6 +56,61 @@ func (suite *FctDataCmdSuite) TestFunctionDataCmd_ReplyCmd() {
 	assert.Equal(suite.T(), suite.data.DeviceName, readCmd.DeviceClassificationManufacturerData.DeviceName)
 }
 
+func (suite *FctDataCmdSuite) TestFunctionDataCmd_PartialReplyCmd() {
+	replyCmd := suite.sut.PartialReplyCmdType(nil)
+	assert.NotNil(suite.T(), replyCmd.DeviceClassificationManufacturerData)
+	assert.Equal(suite.T(), suite.data.DeviceName, replyCmd.DeviceClassificationManufacturerData.DeviceName)
+	assert.Nil(suite.T(), replyCmd.Filter)
+
+	filter := model.NewFilterTypePartial()
+	filter.DeviceClassificationManufacturerDataElements = &model.DeviceClassificationManufacturerDataElementsType{
+		BrandName: util.Ptr(model.ElementTagType{}),
+	}
+	replyCmd = suite.sut.PartialReplyCmdType(filter)
+	assert.NotNil(suite.T(), replyCmd.DeviceClassificationManufacturerData)
+	assert.Nil(suite.T(), replyCmd.DeviceClassificationManufacturerData.DeviceName)
+	assert.Equal(suite.T(), 1, len(replyCmd.Filter))
+	assert.Equal(suite.T(), suite.function, *replyCmd.Function)
+
+	listSut := NewFunctionDataCmd[model.MeasurementListDataType](model.FunctionTypeMeasurementListData)
+	listData := &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(0)),
+				Value:         model.NewScaledNumberType(1),
+				ValueState:    util.Ptr(model.MeasurementValueStateTypeNormal),
+			},
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+				Value:         model.NewScaledNumberType(2),
+				ValueState:    util.Ptr(model.MeasurementValueStateTypeNormal),
+			},
+		},
+	}
+	_, _ = listSut.UpdateData(false, true, listData, nil, nil)
+
+	filter = model.NewFilterTypePartial()
+	filter.MeasurementListDataSelectors = &model.MeasurementListDataSelectorsType{
+		MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+	}
+	filter.MeasurementDataElements = &model.MeasurementDataElementsType{
+		Value: util.Ptr(model.ElementTagType{}),
+	}
+	replyCmd = listSut.PartialReplyCmdType(filter)
+	assert.NotNil(suite.T(), replyCmd.MeasurementListData)
+	assert.Equal(suite.T(), 1, len(replyCmd.MeasurementListData.MeasurementData))
+	item := replyCmd.MeasurementListData.MeasurementData[0]
+	assert.Equal(suite.T(), model.MeasurementIdType(1), *item.MeasurementId)
+	assert.Equal(suite.T(), 2.0, item.Value.GetValue())
+	assert.Nil(suite.T(), item.ValueState)
+	assert.Equal(suite.T(), model.FunctionTypeMeasurementListData, *replyCmd.Function)
+
+	// the stored data is not modified
+	storedData := listSut.DataCopy()
+	assert.Equal(suite.T(), 2, len(storedData.MeasurementData))
+	assert.NotNil(suite.T(), storedData.MeasurementData[1].ValueState)
+}
+
 func (suite *FctDataCmdSuite) TestFunctionDataCmd_NotifyCmd() {
 	notifyCmd := suite.sut.NotifyOrWriteCmdType(nil, nil, false, nil)
 	assert.NotNil(suite.T(), notifyCmd.DeviceClassificationManufacturerData)
@@ -1143,3 +1198,83 @@ func (suite *FctDataCmdSuite) Test_CreateCmd() {
 	result = createCmd(model.FunctionTypeTimeTableListData, &model.TimeTableListDataType{})
 	assert.NotNil(suite.T(), result)
 }
+
+func (suite *FctDataCmdSuite) Test_NotifyDiffCmdTypes() {
+	// the data type does not contain a list
+	_, ok := suite.sut.NotifyDiffCmdTypes(suite.data)
+	assert.False(suite.T(), ok)
+
+	function := model.FunctionTypeLoadControlLimitListData
+	sut := NewFunctionDataCmd[model.LoadControlLimitListDataType](function)
+
+	_, ok = sut.NotifyDiffCmdTypes(nil)
+	assert.False(suite.T(), ok)
+
+	previous := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(false)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(2)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	_, _ = sut.UpdateData(false, true, previous, nil, nil)
+	previous = sut.DataCopy()
+
+	cmds, ok := sut.NotifyDiffCmdTypes(previous)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), 0, len(cmds))
+
+	data := &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(true)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(3)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	_, _ = sut.UpdateData(false, true, data, nil, nil)
+
+	cmds, ok = sut.NotifyDiffCmdTypes(previous)
+	assert.True(suite.T(), ok)
+	if assert.Equal(suite.T(), 1, len(cmds)) {
+		assert.Equal(suite.T(), function, *cmds[0].Function)
+		if assert.Equal(suite.T(), 2, len(cmds[0].Filter)) {
+			// the removed item is described by the delete filter
+			assert.NotNil(suite.T(), cmds[0].Filter[0].CmdControl.Delete)
+			assert.Equal(suite.T(), model.LoadControlLimitIdType(2), *cmds[0].Filter[0].LoadControlLimitListDataSelectors.LimitId)
+
+			// changed and added items are sent with the partial filter
+			assert.NotNil(suite.T(), cmds[0].Filter[1].CmdControl.Partial)
+		}
+		assert.Equal(suite.T(), data.LoadControlLimitData, cmds[0].LoadControlLimitListData.LoadControlLimitData)
+	}
+
+	// fields can not be removed via a partial notify
+	previous = sut.DataCopy()
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1))},
+		},
+	}
+	_, _ = sut.UpdateData(false, true, data, nil, nil)
+
+	_, ok = sut.NotifyDiffCmdTypes(previous)
+	assert.False(suite.T(), ok)
+
+	// more than one removed item requires a full notify
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(1)), IsLimitActive: util.Ptr(false)},
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(2)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	_, _ = sut.UpdateData(false, true, data, nil, nil)
+	previous = sut.DataCopy()
+
+	data = &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{LimitId: util.Ptr(model.LoadControlLimitIdType(4)), IsLimitActive: util.Ptr(false)},
+		},
+	}
+	_, _ = sut.UpdateData(false, true, data, nil, nil)
+
+	_, ok = sut.NotifyDiffCmdTypes(previous)
+	assert.False(suite.T(), ok)
+}
@@ -8,6 +8,14 @@ import (
 )
 
 func CreateFunctionData[F any](featureType model.FeatureTypeType) []F {
+	return createFunctionDataForFeature[F](featureType, true)
+}
+
+// Create the function data of a feature type
+//
+// genericFunctions defines if Generic features support the functions of all feature types,
+// see api.QuirkGenericFeatureFunctions
+func createFunctionDataForFeature[F any](featureType model.FeatureTypeType, genericFunctions bool) []F {
 	// Some devices use generic for everything (e.g. Vaillant Arotherm heatpump)
 	// or for some things like the SMA HM 2.0 or Elli Wallbox, which uses Generic feature
 	// for Heartbeats, even though that should go into FeatureTypeTypeDeviceDiagnosis
@@ -15,6 +23,10 @@ func CreateFunctionData[F any](featureType model.FeatureTypeType) []F {
 
 	var result []F
 
+	if featureType == model.FeatureTypeTypeGeneric && !genericFunctions {
+		return result
+	}
+
 	if featureType == model.FeatureTypeTypeNodeManagement {
 		result = []F{
 			createFunctionData[model.NodeManagementDestinationListDataType, F](model.FunctionTypeNodeManagementDestinationListData),
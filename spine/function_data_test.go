@@ -40,6 +40,35 @@ func TestFunctionData_UpdateData(t *testing.T) {
 	assert.Equal(t, functionType, sut.FunctionType())
 }
 
+func TestFunctionData_UpdateData_DeepCopy(t *testing.T) {
+	newData := &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+				Value:         model.NewScaledNumberType(10),
+			},
+		},
+	}
+	sut := NewFunctionData[model.MeasurementListDataType](model.FunctionTypeMeasurementListData)
+	_, err := sut.UpdateData(false, true, newData, nil, nil)
+	assert.Nil(t, err)
+
+	// a partial update modifies the stored list in place, which must not change newData
+	partialData := &model.MeasurementListDataType{
+		MeasurementData: []model.MeasurementDataType{
+			{
+				MeasurementId: util.Ptr(model.MeasurementIdType(1)),
+				Value:         model.NewScaledNumberType(20),
+			},
+		},
+	}
+	_, err = sut.UpdateData(false, true, partialData, &model.FilterType{CmdControl: &model.CmdControlType{Partial: &model.ElementTagType{}}}, nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 10.0, newData.MeasurementData[0].Value.GetValue())
+	assert.Equal(t, 20.0, sut.DataCopy().MeasurementData[0].Value.GetValue())
+}
+
 func TestFunctionData_UpdateDataPartial(t *testing.T) {
 	newData := &model.ElectricalConnectionPermittedValueSetListDataType{
 		ElectricalConnectionPermittedValueSetData: []model.ElectricalConnectionPermittedValueSetDataType{
@@ -0,0 +1,156 @@
+package spine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+// add all received datagrams in testdata as seeds
+func addReceivedDatagramSeeds(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*_recv_*.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+}
+
+func FuzzHandleSpineMessage(f *testing.F) {
+	addReceivedDatagramSeeds(f)
+	f.Add([]byte(`{"datagram":{"header":{"cmdClassifier":"reply"},"payload":{"cmd":[{}]}}}`))
+	f.Add([]byte(`{"datagram":{"header":{"cmdClassifier":"result"},"payload":{"cmd":[{"resultData":{}}]}}}`))
+
+	f.Fuzz(func(t *testing.T, message []byte) {
+		localDevice, _ := createLocalDeviceAndEntity(1)
+		remoteDevice := localDevice.SetupRemoteDevice("ski", &WriteMessageHandler{}).(*DeviceRemote)
+		defer localDevice.RemoveRemoteDeviceConnection("ski")
+
+		_, _ = remoteDevice.HandleSpineMesssage(message)
+	})
+}
+
+// check that the entities and features of a remote device have unique addresses
+func checkRemoteDeviceAddresses(t *testing.T, remoteDevice api.DeviceRemoteInterface) {
+	entities := remoteDevice.Entities()
+	for i, entity := range entities {
+		for _, other := range entities[i+1:] {
+			if reflect.DeepEqual(entity.Address().Entity, other.Address().Entity) {
+				t.Errorf("duplicate entity address: %v", entity.Address().Entity)
+			}
+		}
+
+		features := entity.Features()
+		for j, feature := range features {
+			for _, other := range features[j+1:] {
+				if reflect.DeepEqual(feature.Address().Feature, other.Address().Feature) {
+					t.Errorf("duplicate feature address: %v", feature.Address())
+				}
+			}
+		}
+	}
+}
+
+func FuzzDetailedDiscovery(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("testdata", "wallbox_detaileddiscoverydata_recv_*.json"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file) // #nosec G304
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		var datagram model.Datagram
+		if err := json.Unmarshal(data, &datagram); err != nil {
+			f.Fatal(err)
+		}
+
+		cmd := datagram.Datagram.Payload.Cmd[0]
+		seed, err := json.Marshal(cmd.NodeManagementDetailedDiscoveryData)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(seed, *datagram.Datagram.Header.CmdClassifier == model.CmdClassifierTypeNotify, cmd.Filter != nil)
+	}
+
+	initialReply, err := os.ReadFile(wallbox_detaileddiscoverydata_recv_reply_file_path)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte, notify, partial bool) {
+		var discoveryData *model.NodeManagementDetailedDiscoveryDataType
+		if err := json.Unmarshal(data, &discoveryData); err != nil {
+			return
+		}
+
+		localDevice, _ := createLocalDeviceAndEntity(1)
+		remoteDevice := localDevice.SetupRemoteDevice("ski", &WriteMessageHandler{}).(*DeviceRemote)
+		defer localDevice.RemoveRemoteDeviceConnection("ski")
+
+		if _, err := remoteDevice.HandleSpineMesssage(initialReply); err != nil {
+			t.Fatal(err)
+		}
+
+		cmdClassifier := model.CmdClassifierTypeReply
+		if notify {
+			cmdClassifier = model.CmdClassifierTypeNotify
+		}
+
+		cmd := model.CmdType{
+			NodeManagementDetailedDiscoveryData: discoveryData,
+		}
+		if partial {
+			cmd.Filter = []model.FilterType{*model.NewFilterTypePartial()}
+		}
+
+		datagram := model.Datagram{
+			Datagram: model.DatagramType{
+				Header: model.HeaderType{
+					SpecificationVersion: &SpecificationVersion,
+					AddressSource: &model.FeatureAddressType{
+						Device:  remoteDevice.Address(),
+						Entity:  []model.AddressEntityType{0},
+						Feature: util.Ptr(model.AddressFeatureType(0)),
+					},
+					AddressDestination: &model.FeatureAddressType{
+						Device:  localDevice.Address(),
+						Entity:  []model.AddressEntityType{0},
+						Feature: util.Ptr(model.AddressFeatureType(0)),
+					},
+					MsgCounter:          util.Ptr(model.MsgCounterType(100)),
+					MsgCounterReference: util.Ptr(model.MsgCounterType(1)),
+					CmdClassifier:       &cmdClassifier,
+				},
+				Payload: model.PayloadType{
+					Cmd: []model.CmdType{cmd},
+				},
+			},
+		}
+
+		message, err := json.Marshal(datagram)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, _ = remoteDevice.HandleSpineMesssage(message)
+
+		checkRemoteDeviceAddresses(t, remoteDevice)
+	})
+}
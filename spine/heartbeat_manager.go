@@ -116,15 +116,8 @@ func (c *HeartbeatManager) heartbeatData(t time.Time, counter *uint64) *model.De
 	}
 }
 
-func (c *HeartbeatManager) updateHeartbeatData(stopC chan struct{}, d time.Duration) {
-	// Substract two seconds, because some devices (like Elli Connect/Pro) with OPEV/OSCEV interpret
-	// the heartbeat timeout (<= 4s) as the time within which a heartbeat should be received and otherwise
-	// will go into fallback mode.
-	// But other EVSE devices and in LPC (<= 60s), the heartbeat should be considered missing, if it is not
-	// received within twice the heartbeat timeout timeframe.
-	if d > 2*time.Second {
-		d -= 2 * time.Second
-	}
+func (c *HeartbeatManager) updateHeartbeatData(stopC chan struct{}, timeout time.Duration) {
+	d := c.heartbeatInterval(timeout)
 	ticker := time.NewTicker(d)
 	for {
 		select {
@@ -137,12 +130,62 @@ func (c *HeartbeatManager) updateHeartbeatData(stopC chan struct{}, d time.Durat
 			c.localFeature.SetData(model.FunctionTypeDeviceDiagnosisHeartbeatData, heartbeatData)
 			c.mux.Unlock()
 
+			// subscribers may have changed
+			if interval := c.heartbeatInterval(timeout); interval != d {
+				d = interval
+				ticker.Reset(d)
+			}
+
 		case <-stopC:
+			ticker.Stop()
 			return
 		}
 	}
 }
 
+// Return the interval in which heartbeats are sent
+//
+// Substract two seconds, if a subscriber has the api.QuirkHeartbeatEarly quirk, because some devices
+// (like Elli Connect/Pro) with OPEV/OSCEV interpret the heartbeat timeout (<= 4s) as the time within
+// which a heartbeat should be received and otherwise will go into fallback mode.
+// But other EVSE devices and in LPC (<= 60s), the heartbeat should be considered missing, if it is not
+// received within twice the heartbeat timeout timeframe.
+func (c *HeartbeatManager) heartbeatInterval(timeout time.Duration) time.Duration {
+	if timeout > 2*time.Second && c.hasQuirk(api.QuirkHeartbeatEarly) {
+		return timeout - 2*time.Second
+	}
+
+	return timeout
+}
+
+// check if a quirk is enabled by default or for any remote device subscribed to the local heartbeat feature
+func (c *HeartbeatManager) hasQuirk(quirk api.QuirkType) bool {
+	c.mux.Lock()
+	localFeature := c.localFeature
+	c.mux.Unlock()
+
+	if localFeature == nil || localFeature.Device() == nil || localFeature.Address() == nil {
+		return false
+	}
+
+	if localFeature.Device().Quirks().HasQuirk(nil, quirk) {
+		return true
+	}
+
+	subscriptions := localFeature.Device().SubscriptionManager().SubscriptionsOnFeature(*localFeature.Address())
+	for _, subscription := range subscriptions {
+		if subscription.ClientFeature == nil {
+			continue
+		}
+
+		if remoteDeviceHasQuirk(subscription.ClientFeature.Device(), quirk) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *HeartbeatManager) isHeartbeatClosed() bool {
 	select {
 	case <-c.stopHeartbeatC:
@@ -0,0 +1,271 @@
+package spine
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+type HeartbeatMonitor struct {
+	localFeature   api.FeatureLocalInterface
+	remoteFeature  api.FeatureRemoteInterface
+	defaultTimeout time.Duration
+
+	running       bool
+	missing       bool
+	lastHeartbeat *model.DeviceDiagnosisHeartbeatDataType
+	lastReceived  time.Time
+	timer         *time.Timer
+
+	mux sync.Mutex
+}
+
+var _ api.HeartbeatMonitorInterface = (*HeartbeatMonitor)(nil)
+var _ api.EventHandlerInterface = (*HeartbeatMonitor)(nil)
+
+// Create a new heartbeat monitor for a remote DeviceDiagnosis server feature
+//
+// localFeature has to be a DeviceDiagnosis client feature
+// defaultTimeout is used as long as the remote did not provide its heartbeat timeout
+func NewHeartbeatMonitor(
+	localFeature api.FeatureLocalInterface,
+	remoteFeature api.FeatureRemoteInterface,
+	defaultTimeout time.Duration) *HeartbeatMonitor {
+	return &HeartbeatMonitor{
+		localFeature:   localFeature,
+		remoteFeature:  remoteFeature,
+		defaultTimeout: defaultTimeout,
+	}
+}
+
+/* HeartbeatMonitorInterface */
+
+// Subscribe to the remote feature, request the current heartbeat data
+// and start monitoring the heartbeats
+//
+// The monitoring stops if the remote device is disconnected
+func (m *HeartbeatMonitor) Start() error {
+	if m.localFeature == nil || m.remoteFeature == nil {
+		return errors.New("features may not be nil")
+	}
+
+	if m.localFeature.Type() != model.FeatureTypeTypeDeviceDiagnosis ||
+		m.localFeature.Role() != model.RoleTypeClient {
+		return errors.New("local feature has to be a DeviceDiagnosis client")
+	}
+
+	if m.remoteFeature.Type() != model.FeatureTypeTypeDeviceDiagnosis ||
+		m.remoteFeature.Role() != model.RoleTypeServer {
+		return errors.New("remote feature has to be a DeviceDiagnosis server")
+	}
+
+	if m.IsRunning() {
+		return nil
+	}
+
+	// ordered delivery is required to detect a counter running backwards
+	options := api.EventSubscriptionOptions{
+		Filter: api.EventFilter{
+			Ski: m.remoteFeature.Device().Ski(),
+		},
+	}
+	if err := m.localFeature.Device().Events().SubscribeWithOptions(m, options); err != nil {
+		return err
+	}
+
+	if !m.localFeature.HasSubscriptionToRemote(m.remoteFeature.Address()) {
+		if _, err := m.localFeature.SubscribeToRemote(m.remoteFeature.Address()); err != nil {
+			_ = m.localFeature.Device().Events().Unsubscribe(m)
+			return errors.New(err.String())
+		}
+	}
+
+	m.mux.Lock()
+	m.running = true
+	m.missing = false
+	m.timer = time.AfterFunc(m.heartbeatTimeout(), m.heartbeatExpired)
+	m.mux.Unlock()
+
+	if _, err := m.localFeature.RequestRemoteData(model.FunctionTypeDeviceDiagnosisHeartbeatData, nil, nil, m.remoteFeature); err != nil {
+		m.Stop()
+		return errors.New(err.String())
+	}
+
+	return nil
+}
+
+// Stop monitoring the heartbeats
+//
+// Note: The subscription to the remote feature is not removed
+func (m *HeartbeatMonitor) Stop() {
+	m.mux.Lock()
+	m.running = false
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	m.mux.Unlock()
+
+	if m.localFeature == nil || m.localFeature.Device() == nil {
+		return
+	}
+
+	_ = m.localFeature.Device().Events().Unsubscribe(m)
+}
+
+func (m *HeartbeatMonitor) IsRunning() bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.running
+}
+
+func (m *HeartbeatMonitor) IsHeartbeatMissing() bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.missing
+}
+
+func (m *HeartbeatMonitor) LastHeartbeat() (*model.DeviceDiagnosisHeartbeatDataType, time.Time) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if m.lastHeartbeat == nil {
+		return nil, m.lastReceived
+	}
+
+	return util.Ptr(*m.lastHeartbeat), m.lastReceived
+}
+
+/* EventHandlerInterface */
+
+func (m *HeartbeatMonitor) HandleEvent(payload api.EventPayload) {
+	switch payload.EventType {
+	case api.EventTypeDeviceChange:
+		if payload.ChangeType == api.ElementChangeRemove {
+			m.Stop()
+		}
+
+	case api.EventTypeDataChange:
+		if payload.Feature == nil ||
+			payload.Function != model.FunctionTypeDeviceDiagnosisHeartbeatData ||
+			!reflect.DeepEqual(payload.Feature.Address(), m.remoteFeature.Address()) {
+			return
+		}
+
+		data, err := RemoteFeatureDataCopyOfType[*model.DeviceDiagnosisHeartbeatDataType](
+			m.remoteFeature, model.FunctionTypeDeviceDiagnosisHeartbeatData)
+		if err != nil {
+			return
+		}
+
+		m.processHeartbeat(data)
+	}
+}
+
+// evaluate a received heartbeat
+func (m *HeartbeatMonitor) processHeartbeat(data *model.DeviceDiagnosisHeartbeatDataType) {
+	m.mux.Lock()
+
+	if !m.running {
+		m.mux.Unlock()
+		return
+	}
+
+	// a heartbeat with a timestamp which did not advance is not an indication the remote is alive,
+	// e.g. the same data was sent again
+	if m.lastHeartbeat != nil && !timestampAdvanced(m.lastHeartbeat.Timestamp, data.Timestamp) {
+		m.mux.Unlock()
+		m.publish(api.HeartbeatStateTimestampStale)
+		return
+	}
+
+	counterBackwards := m.lastHeartbeat != nil &&
+		m.lastHeartbeat.HeartbeatCounter != nil &&
+		data.HeartbeatCounter != nil &&
+		*data.HeartbeatCounter < *m.lastHeartbeat.HeartbeatCounter
+	recovered := m.missing
+
+	m.lastHeartbeat = data
+	m.lastReceived = time.Now()
+	m.missing = false
+	m.timer.Reset(m.heartbeatTimeout())
+
+	m.mux.Unlock()
+
+	if counterBackwards {
+		m.publish(api.HeartbeatStateCounterBackwards)
+	}
+	if recovered {
+		m.publish(api.HeartbeatStateRecovered)
+	}
+}
+
+// returns false if both timestamps are absolute and the new one is not after the previous one
+//
+// relative or invalid timestamps can not be compared and are treated as advanced
+func timestampAdvanced(previous, current *model.AbsoluteOrRelativeTimeType) bool {
+	if previous == nil || current == nil || previous.IsRelativeTime() || current.IsRelativeTime() {
+		return true
+	}
+
+	previousTime, err := previous.GetTime()
+	if err != nil {
+		return true
+	}
+	currentTime, err := current.GetTime()
+	if err != nil {
+		return true
+	}
+
+	return currentTime.After(previousTime)
+}
+
+// invoked if no heartbeat was received within the timeout
+func (m *HeartbeatMonitor) heartbeatExpired() {
+	m.mux.Lock()
+
+	if !m.running || m.missing {
+		m.mux.Unlock()
+		return
+	}
+
+	m.missing = true
+
+	m.mux.Unlock()
+
+	m.publish(api.HeartbeatStateMissing)
+}
+
+// return the heartbeat timeout provided by the remote, or the default timeout
+//
+// mux has to be locked by the caller
+func (m *HeartbeatMonitor) heartbeatTimeout() time.Duration {
+	if m.lastHeartbeat != nil && m.lastHeartbeat.HeartbeatTimeout != nil {
+		if timeout, err := m.lastHeartbeat.HeartbeatTimeout.GetTimeDuration(); err == nil && timeout > 0 {
+			return timeout
+		}
+	}
+
+	return m.defaultTimeout
+}
+
+func (m *HeartbeatMonitor) publish(state api.HeartbeatStateType) {
+	payload := api.EventPayload{
+		Ski:          m.remoteFeature.Device().Ski(),
+		EventType:    api.EventTypeHeartbeatChange,
+		ChangeType:   api.ElementChangeUpdate,
+		Device:       m.remoteFeature.Device(),
+		Entity:       m.remoteFeature.Entity(),
+		Feature:      m.remoteFeature,
+		LocalFeature: m.localFeature,
+		Function:     model.FunctionTypeDeviceDiagnosisHeartbeatData,
+		Data:         state,
+	}
+	m.localFeature.Device().Events().Publish(payload)
+}
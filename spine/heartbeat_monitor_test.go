@@ -0,0 +1,209 @@
+package spine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestHeartbeatMonitorSuite(t *testing.T) {
+	suite.Run(t, new(HeartbeatMonitorSuite))
+}
+
+type HeartbeatMonitorSuite struct {
+	suite.Suite
+
+	localDevice   *DeviceLocal
+	localFeature  api.FeatureLocalInterface
+	serverFeature api.FeatureLocalInterface
+	remoteFeature api.FeatureRemoteInterface
+
+	events <-chan api.EventPayload
+	cancel context.CancelFunc
+
+	heartbeatTime time.Time
+
+	sut *HeartbeatMonitor
+}
+
+func (s *HeartbeatMonitorSuite) BeforeTest(suiteName, testName string) {
+	var localEntity *EntityLocal
+	s.localDevice, localEntity = createLocalDeviceAndEntity(1)
+	s.localFeature, s.serverFeature = createLocalFeatures(localEntity, model.FeatureTypeTypeDeviceDiagnosis, "")
+
+	remoteDevice := createRemoteDevice(s.localDevice, "ski", NewSender(&WriteMessageHandler{}))
+	_, s.remoteFeature = createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeDeviceDiagnosis, model.FunctionTypeDeviceDiagnosisHeartbeatData)
+	s.localDevice.AddRemoteDeviceForSki(remoteDevice.Ski(), remoteDevice)
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+	s.events = s.localDevice.Events().SubscribeChannel(ctx, api.EventFilter{
+		EventType: util.Ptr(api.EventTypeHeartbeatChange),
+	})
+
+	s.heartbeatTime = time.Now()
+
+	s.sut = NewHeartbeatMonitor(s.localFeature, s.remoteFeature, time.Millisecond*200)
+}
+
+func (s *HeartbeatMonitorSuite) AfterTest(suiteName, testName string) {
+	s.sut.Stop()
+	s.cancel()
+}
+
+// send a heartbeat, the timestamp advances by one second with every heartbeat
+func (s *HeartbeatMonitorSuite) sendHeartbeat(counter uint64, timeout time.Duration) {
+	s.heartbeatTime = s.heartbeatTime.Add(time.Second)
+	s.sendHeartbeatWithTimestamp(counter, timeout, s.heartbeatTime)
+}
+
+func (s *HeartbeatMonitorSuite) sendHeartbeatWithTimestamp(counter uint64, timeout time.Duration, timestamp time.Time) {
+	msg := &api.Message{
+		CmdClassifier: model.CmdClassifierTypeNotify,
+		Cmd: model.CmdType{
+			DeviceDiagnosisHeartbeatData: &model.DeviceDiagnosisHeartbeatDataType{
+				Timestamp:        model.NewAbsoluteOrRelativeTimeTypeFromTime(timestamp),
+				HeartbeatCounter: util.Ptr(counter),
+				HeartbeatTimeout: model.NewDurationType(timeout),
+			},
+		},
+		FeatureRemote: s.remoteFeature,
+		EntityRemote:  s.remoteFeature.Entity(),
+		DeviceRemote:  s.remoteFeature.Device(),
+	}
+
+	err := s.localFeature.HandleMessage(msg)
+	assert.Nil(s.T(), err)
+}
+
+func (s *HeartbeatMonitorSuite) expectState(state api.HeartbeatStateType) {
+	select {
+	case payload := <-s.events:
+		assert.Equal(s.T(), state, payload.Data)
+		assert.Equal(s.T(), s.remoteFeature, payload.Feature)
+	case <-time.After(time.Second):
+		s.T().Fatalf("heartbeat state %d event was not published", state)
+	}
+}
+
+func (s *HeartbeatMonitorSuite) Test_Start_Invalid() {
+	sut := NewHeartbeatMonitor(nil, s.remoteFeature, time.Second)
+	assert.NotNil(s.T(), sut.Start())
+
+	sut = NewHeartbeatMonitor(s.serverFeature, s.remoteFeature, time.Second)
+	assert.NotNil(s.T(), sut.Start())
+
+	// stopping a monitor which could not be started is fine
+	sut = NewHeartbeatMonitor(nil, nil, time.Second)
+	sut.Stop()
+	assert.False(s.T(), sut.IsRunning())
+}
+
+func (s *HeartbeatMonitorSuite) Test_Heartbeat() {
+	err := s.sut.Start()
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), s.sut.IsRunning())
+	assert.True(s.T(), s.localFeature.HasSubscriptionToRemote(s.remoteFeature.Address()))
+
+	// starting twice is fine
+	err = s.sut.Start()
+	assert.Nil(s.T(), err)
+
+	data, _ := s.sut.LastHeartbeat()
+	assert.Nil(s.T(), data)
+
+	s.sendHeartbeat(5, time.Millisecond*100)
+
+	assert.Eventually(s.T(), func() bool {
+		data, _ := s.sut.LastHeartbeat()
+		return data != nil && *data.HeartbeatCounter == 5
+	}, time.Second, time.Millisecond*10)
+	assert.False(s.T(), s.sut.IsHeartbeatMissing())
+
+	// no heartbeat within the timeout provided by the remote
+	s.expectState(api.HeartbeatStateMissing)
+	assert.True(s.T(), s.sut.IsHeartbeatMissing())
+
+	s.sendHeartbeat(6, time.Second*4)
+	s.expectState(api.HeartbeatStateRecovered)
+	assert.False(s.T(), s.sut.IsHeartbeatMissing())
+
+	s.sendHeartbeat(1, time.Second*4)
+	s.expectState(api.HeartbeatStateCounterBackwards)
+
+	data, received := s.sut.LastHeartbeat()
+	if assert.NotNil(s.T(), data) {
+		assert.Equal(s.T(), uint64(1), *data.HeartbeatCounter)
+	}
+	assert.False(s.T(), received.IsZero())
+
+	s.sut.Stop()
+	assert.False(s.T(), s.sut.IsRunning())
+}
+
+func (s *HeartbeatMonitorSuite) Test_TimestampStale() {
+	err := s.sut.Start()
+	assert.Nil(s.T(), err)
+
+	s.sendHeartbeat(1, time.Second*4)
+	assert.Eventually(s.T(), func() bool {
+		data, _ := s.sut.LastHeartbeat()
+		return data != nil && *data.HeartbeatCounter == 1
+	}, time.Second, time.Millisecond*10)
+
+	// the same timestamp again
+	s.sendHeartbeatWithTimestamp(2, time.Second*4, s.heartbeatTime)
+	s.expectState(api.HeartbeatStateTimestampStale)
+
+	// an older timestamp
+	s.sendHeartbeatWithTimestamp(3, time.Second*4, s.heartbeatTime.Add(-time.Minute))
+	s.expectState(api.HeartbeatStateTimestampStale)
+
+	// stale heartbeats are not stored
+	data, _ := s.sut.LastHeartbeat()
+	if assert.NotNil(s.T(), data) {
+		assert.Equal(s.T(), uint64(1), *data.HeartbeatCounter)
+	}
+
+	s.sendHeartbeat(4, time.Second*4)
+	assert.Eventually(s.T(), func() bool {
+		data, _ := s.sut.LastHeartbeat()
+		return data != nil && *data.HeartbeatCounter == 4
+	}, time.Second, time.Millisecond*10)
+}
+
+func (s *HeartbeatMonitorSuite) Test_TimestampAdvanced() {
+	now := time.Now()
+	previous := model.NewAbsoluteOrRelativeTimeTypeFromTime(now)
+	relative := model.NewAbsoluteOrRelativeTimeTypeFromDuration(time.Second)
+
+	assert.True(s.T(), timestampAdvanced(nil, previous))
+	assert.True(s.T(), timestampAdvanced(previous, nil))
+	assert.True(s.T(), timestampAdvanced(previous, relative))
+	assert.True(s.T(), timestampAdvanced(previous, model.NewAbsoluteOrRelativeTimeTypeFromTime(now.Add(time.Second))))
+	assert.False(s.T(), timestampAdvanced(previous, previous))
+	assert.False(s.T(), timestampAdvanced(previous, model.NewAbsoluteOrRelativeTimeTypeFromTime(now.Add(-time.Second))))
+}
+
+func (s *HeartbeatMonitorSuite) Test_NoHeartbeat() {
+	err := s.sut.Start()
+	assert.Nil(s.T(), err)
+
+	// the default timeout is used if the remote did not provide any heartbeat
+	s.expectState(api.HeartbeatStateMissing)
+}
+
+func (s *HeartbeatMonitorSuite) Test_Disconnect() {
+	err := s.sut.Start()
+	assert.Nil(s.T(), err)
+
+	s.localDevice.RemoveRemoteDeviceConnection(s.remoteFeature.Device().Ski())
+
+	assert.Eventually(s.T(), func() bool { return !s.sut.IsRunning() }, time.Second, time.Millisecond*10)
+}
@@ -56,9 +56,8 @@ func (t *WriteMessageHandler) MessageWithReference(msgCounterReference *model.Ms
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	var datagram model.Datagram
-
 	for _, msg := range t.sentMessages {
+		var datagram model.Datagram
 		if err := json.Unmarshal(msg, &datagram); err != nil {
 			return nil
 		}
@@ -82,9 +81,8 @@ func (t *WriteMessageHandler) ResultWithReference(msgCounterReference *model.Msg
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
-	var datagram model.Datagram
-
 	for _, msg := range t.sentMessages {
+		var datagram model.Datagram
 		if err := json.Unmarshal(msg, &datagram); err != nil {
 			return nil
 		}
@@ -0,0 +1,107 @@
+package spine
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+)
+
+// Queues the datagrams received from a remote device and processes them in order
+// on a worker goroutine
+//
+// Enqueueing blocks while the queue is full, so a slow processing
+// slows down the SHIP connection reading the datagrams
+type inboundQueue struct {
+	queue   chan []byte
+	process func(message []byte)
+
+	done      chan struct{}
+	closeOnce sync.Once
+
+	maxDepth  uint
+	processed uint64
+	blocked   uint64
+
+	mux sync.Mutex
+}
+
+func newInboundQueue(size uint, process func(message []byte)) *inboundQueue {
+	q := &inboundQueue{
+		queue:   make(chan []byte, size),
+		process: process,
+		done:    make(chan struct{}),
+	}
+
+	go q.run()
+
+	return q
+}
+
+// add a datagram to the queue, blocks until there is space in the queue or the queue is closed
+func (q *inboundQueue) enqueue(message []byte) {
+	// the SHIP connection may reuse the buffer once this returns
+	message = slices.Clone(message)
+
+	select {
+	case <-q.done:
+		return
+	case q.queue <- message:
+	default:
+		q.mux.Lock()
+		q.blocked++
+		q.mux.Unlock()
+
+		select {
+		case <-q.done:
+			return
+		case q.queue <- message:
+		}
+	}
+
+	q.mux.Lock()
+	q.maxDepth = max(q.maxDepth, uint(len(q.queue)))
+	q.mux.Unlock()
+}
+
+func (q *inboundQueue) run() {
+	for {
+		select {
+		case <-q.done:
+			return
+		case message := <-q.queue:
+			// a closed queue drops the message, even if both channels are ready
+			select {
+			case <-q.done:
+				return
+			default:
+			}
+
+			q.process(message)
+
+			q.mux.Lock()
+			q.processed++
+			q.mux.Unlock()
+		}
+	}
+}
+
+// stop the worker, queued datagrams are dropped
+func (q *inboundQueue) close() {
+	q.closeOnce.Do(func() {
+		close(q.done)
+	})
+}
+
+func (q *inboundQueue) metrics() api.InboundQueueMetrics {
+	q.mux.Lock()
+	defer q.mux.Unlock()
+
+	return api.InboundQueueMetrics{
+		Capacity:  uint(cap(q.queue)),
+		Depth:     uint(len(q.queue)),
+		MaxDepth:  q.maxDepth,
+		Processed: q.processed,
+		Blocked:   q.blocked,
+	}
+}
@@ -0,0 +1,233 @@
+package spine
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestInboundQueueSuite(t *testing.T) {
+	suite.Run(t, new(InboundQueueSuite))
+}
+
+type InboundQueueSuite struct {
+	suite.Suite
+}
+
+// records the processed messages, optionally blocking until released
+type inboundQueueRecorder struct {
+	block chan struct{}
+
+	processed []string
+	mux       sync.Mutex
+}
+
+func (r *inboundQueueRecorder) process(message []byte) {
+	if r.block != nil {
+		<-r.block
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.processed = append(r.processed, string(message))
+}
+
+func (r *inboundQueueRecorder) Processed() []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return slices.Clone(r.processed)
+}
+
+func (s *InboundQueueSuite) Test_Order() {
+	recorder := &inboundQueueRecorder{}
+	sut := newInboundQueue(4, recorder.process)
+	defer sut.close()
+
+	var expected []string
+	for i := 0; i < 100; i++ {
+		expected = append(expected, strconv.Itoa(i))
+		sut.enqueue([]byte(strconv.Itoa(i)))
+	}
+
+	assert.Eventually(s.T(), func() bool {
+		return len(recorder.Processed()) == 100
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), expected, recorder.Processed())
+	assert.Equal(s.T(), uint64(100), sut.metrics().Processed)
+	assert.Equal(s.T(), uint(4), sut.metrics().Capacity)
+}
+
+func (s *InboundQueueSuite) Test_Backpressure() {
+	recorder := &inboundQueueRecorder{block: make(chan struct{})}
+	sut := newInboundQueue(1, recorder.process)
+	defer sut.close()
+
+	// the first message is processed and blocks the worker, the second one is queued
+	sut.enqueue([]byte("1"))
+	assert.Eventually(s.T(), func() bool {
+		return sut.metrics().Depth == 0
+	}, time.Second, time.Millisecond*10)
+	sut.enqueue([]byte("2"))
+	assert.Equal(s.T(), uint(1), sut.metrics().Depth)
+
+	// the queue is full, so the third message has to wait
+	enqueued := make(chan struct{})
+	go func() {
+		sut.enqueue([]byte("3"))
+		close(enqueued)
+	}()
+
+	select {
+	case <-enqueued:
+		s.T().Fatal("enqueue did not block")
+	case <-time.After(time.Millisecond * 50):
+	}
+	assert.Equal(s.T(), uint64(1), sut.metrics().Blocked)
+
+	close(recorder.block)
+	<-enqueued
+
+	assert.Eventually(s.T(), func() bool {
+		return len(recorder.Processed()) == 3
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), []string{"1", "2", "3"}, recorder.Processed())
+	assert.Equal(s.T(), uint(1), sut.metrics().MaxDepth)
+}
+
+func (s *InboundQueueSuite) Test_Close() {
+	recorder := &inboundQueueRecorder{block: make(chan struct{})}
+	sut := newInboundQueue(1, recorder.process)
+
+	sut.enqueue([]byte("1"))
+	sut.enqueue([]byte("2"))
+
+	enqueued := make(chan struct{})
+	go func() {
+		sut.enqueue([]byte("3"))
+		close(enqueued)
+	}()
+
+	// closing releases a blocked enqueue and drops the queued messages
+	sut.close()
+	<-enqueued
+	close(recorder.block)
+
+	time.Sleep(time.Millisecond * 20)
+	assert.LessOrEqual(s.T(), len(recorder.Processed()), 1)
+
+	// closing twice and enqueueing afterwards is possible
+	sut.close()
+	sut.enqueue([]byte("4"))
+}
+
+// records the limit values of data change events, slowly
+type limitValueRecorder struct {
+	values []float64
+	mux    sync.Mutex
+}
+
+func (r *limitValueRecorder) HandleEvent(event api.EventPayload) {
+	time.Sleep(time.Millisecond)
+
+	data, ok := event.Data.(*model.LoadControlLimitListDataType)
+	if !ok || data == nil || len(data.LoadControlLimitData) == 0 || data.LoadControlLimitData[0].Value == nil {
+		return
+	}
+
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.values = append(r.values, data.LoadControlLimitData[0].Value.GetValue())
+}
+
+func (r *limitValueRecorder) Values() []float64 {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return slices.Clone(r.values)
+}
+
+func (s *InboundQueueSuite) Test_DeviceNotifyOrder() {
+	client := NewDeviceLocal("Vendor", "Client", "SerialC", "CodeC", "AddressClient", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	client.SetInboundQueueSize(2)
+	clientEntity := NewEntityLocal(client, model.EntityTypeTypeCEM, []model.AddressEntityType{1}, time.Second*4)
+	client.AddEntity(clientEntity)
+	clientFeature := clientEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+
+	server := NewDeviceLocal("Vendor", "Server", "SerialS", "CodeS", "AddressServer", model.DeviceTypeTypeChargingStation, model.NetworkManagementFeatureSetTypeSmart)
+	serverEntity := NewEntityLocal(server, model.EntityTypeTypeEVSE, []model.AddressEntityType{1}, time.Second*4)
+	server.AddEntity(serverEntity)
+	serverFeature := serverEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	serverFeature.AddFunctionType(model.FunctionTypeLoadControlLimitListData, true, false)
+
+	// a slow application handler blocks the processing of further datagrams
+	recorder := &limitValueRecorder{}
+	err := client.Events().SubscribeWithOptions(recorder, api.EventSubscriptionOptions{
+		Filter: api.EventFilter{
+			EventType: util.Ptr(api.EventTypeDataChange),
+			Function:  util.Ptr(model.FunctionTypeLoadControlLimitListData),
+		},
+		QueueSize:      1,
+		OverflowPolicy: api.EventOverflowPolicyBlock,
+	})
+	assert.Nil(s.T(), err)
+
+	loopback := NewLoopback(client, "skiClient", server, "skiServer", LoopbackOptions{})
+	defer loopback.Close()
+
+	var remoteFeature api.FeatureRemoteInterface
+	assert.Eventually(s.T(), func() bool {
+		if remoteDevice := client.RemoteDeviceForSki("skiServer"); remoteDevice != nil {
+			remoteFeature = remoteDevice.FeatureByAddress(serverFeature.Address())
+		}
+		return remoteFeature != nil
+	}, time.Second, time.Millisecond*10)
+	if remoteFeature == nil {
+		return
+	}
+
+	_, _ = clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Eventually(s.T(), func() bool {
+		return len(server.SubscriptionManager().SubscriptionsOnFeature(*serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+
+	const count = 30
+	for i := 1; i <= count; i++ {
+		serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, &model.LoadControlLimitListDataType{
+			LoadControlLimitData: []model.LoadControlLimitDataType{
+				{
+					LimitId: util.Ptr(model.LoadControlLimitIdType(1)),
+					Value:   model.NewScaledNumberType(float64(i)),
+				},
+			},
+		})
+	}
+
+	assert.Eventually(s.T(), func() bool {
+		values := recorder.Values()
+		return len(values) > 0 && values[len(values)-1] == count
+	}, time.Second*5, time.Millisecond*10)
+
+	values := recorder.Values()
+	assert.True(s.T(), slices.IsSorted(values), fmt.Sprintf("notifies were reordered: %v", values))
+	assert.Len(s.T(), values, count)
+
+	metrics := client.RemoteDeviceForSki("skiServer").InboundQueueMetrics()
+	assert.Equal(s.T(), uint(2), metrics.Capacity)
+	assert.Greater(s.T(), metrics.Processed, uint64(count))
+	assert.Greater(s.T(), metrics.MaxDepth, uint(0))
+
+	// without a queue there are no metrics
+	assert.Equal(s.T(), api.InboundQueueMetrics{}, server.RemoteDeviceForSki("skiClient").InboundQueueMetrics())
+}
@@ -0,0 +1,57 @@
+package spine
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+var ErrDatagramRejected = errors.New("datagram rejected")
+
+// returned by the sender if an outbound interceptor stopped a datagram,
+// so no response will be received for it
+var ErrDatagramStopped = errors.New("datagram stopped")
+
+// An ordered list of interceptors
+type interceptorChain struct {
+	interceptors []api.InterceptorFunc
+
+	mux sync.Mutex
+}
+
+func (c *interceptorChain) add(interceptor api.InterceptorFunc) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.interceptors = append(c.interceptors, interceptor)
+}
+
+// Run the interceptors in order, until one does not continue
+//
+// The error is only set for rejected datagrams
+func (c *interceptorChain) run(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+	c.mux.Lock()
+	interceptors := slices.Clone(c.interceptors)
+	c.mux.Unlock()
+
+	for _, interceptor := range interceptors {
+		action, err := interceptor(ski, datagram)
+
+		switch action {
+		case api.InterceptorActionContinue:
+			continue
+		case api.InterceptorActionReject:
+			if err == nil {
+				err = model.NewErrorType(model.ErrorNumberTypeCommandRejected, "datagram rejected")
+			}
+			return action, err
+		default:
+			return action, nil
+		}
+	}
+
+	return api.InterceptorActionContinue, nil
+}
@@ -0,0 +1,199 @@
+package spine
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestInterceptorSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorSuite))
+}
+
+type InterceptorSuite struct {
+	suite.Suite
+
+	localDevice  *DeviceLocal
+	remoteDevice *DeviceRemote
+	writeHandler *WriteMessageHandler
+}
+
+func (s *InterceptorSuite) BeforeTest(suiteName, testName string) {
+	s.localDevice, _ = createLocalDeviceAndEntity(1)
+	s.writeHandler = &WriteMessageHandler{}
+	s.remoteDevice = s.localDevice.SetupRemoteDevice("ski", s.writeHandler).(*DeviceRemote)
+}
+
+// a read request of the detailed discovery data
+func (s *InterceptorSuite) readMessage(msgCounter model.MsgCounterType) []byte {
+	datagram := model.Datagram{
+		Datagram: model.DatagramType{
+			Header: model.HeaderType{
+				SpecificationVersion: &SpecificationVersion,
+				AddressSource: &model.FeatureAddressType{
+					Device:  util.Ptr(model.AddressDeviceType("Remote")),
+					Entity:  []model.AddressEntityType{0},
+					Feature: util.Ptr(model.AddressFeatureType(0)),
+				},
+				AddressDestination: &model.FeatureAddressType{
+					Device:  s.localDevice.Address(),
+					Entity:  []model.AddressEntityType{0},
+					Feature: util.Ptr(model.AddressFeatureType(0)),
+				},
+				MsgCounter:    util.Ptr(msgCounter),
+				CmdClassifier: util.Ptr(model.CmdClassifierTypeRead),
+			},
+			Payload: model.PayloadType{
+				Cmd: []model.CmdType{
+					{NodeManagementDetailedDiscoveryData: &model.NodeManagementDetailedDiscoveryDataType{}},
+				},
+			},
+		},
+	}
+
+	msg, err := json.Marshal(datagram)
+	assert.Nil(s.T(), err)
+
+	return msg
+}
+
+func (s *InterceptorSuite) Test_Inbound_ObserveAndModify() {
+	var received []model.MsgCounterType
+	s.localDevice.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		assert.Equal(s.T(), "ski", ski)
+		received = append(received, *datagram.Header.MsgCounter)
+		return api.InterceptorActionContinue, nil
+	})
+	s.localDevice.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		datagram.Header.MsgCounter = util.Ptr(model.MsgCounterType(20))
+		return api.InterceptorActionContinue, nil
+	})
+
+	msgCounter, err := s.remoteDevice.HandleSpineMesssage(s.readMessage(10))
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), model.MsgCounterType(20), *msgCounter)
+	assert.Equal(s.T(), []model.MsgCounterType{10}, received)
+
+	// the reply references the modified msgCounter
+	assert.NotNil(s.T(), s.writeHandler.MessageWithReference(util.Ptr(model.MsgCounterType(20))))
+}
+
+func (s *InterceptorSuite) Test_Inbound_Stop() {
+	var called bool
+	s.localDevice.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		return api.InterceptorActionStop, nil
+	})
+	s.localDevice.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		called = true
+		return api.InterceptorActionContinue, nil
+	})
+
+	_, err := s.remoteDevice.HandleSpineMesssage(s.readMessage(10))
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), called)
+
+	msgCounterReference := util.Ptr(model.MsgCounterType(10))
+	assert.Nil(s.T(), s.writeHandler.MessageWithReference(msgCounterReference))
+	assert.Nil(s.T(), s.writeHandler.ResultWithReference(msgCounterReference))
+}
+
+func (s *InterceptorSuite) Test_Inbound_Reject() {
+	s.localDevice.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		return api.InterceptorActionReject, nil
+	})
+
+	_, err := s.remoteDevice.HandleSpineMesssage(s.readMessage(10))
+	assert.Nil(s.T(), err)
+
+	msgCounterReference := util.Ptr(model.MsgCounterType(10))
+	assert.Nil(s.T(), s.writeHandler.MessageWithReference(msgCounterReference))
+
+	msg := s.writeHandler.ResultWithReference(msgCounterReference)
+	if assert.NotNil(s.T(), msg) {
+		var datagram model.Datagram
+		assert.Nil(s.T(), json.Unmarshal(msg, &datagram))
+		resultData := datagram.Datagram.Payload.Cmd[0].ResultData
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, *resultData.ErrorNumber)
+	}
+}
+
+func (s *InterceptorSuite) Test_Outbound() {
+	var order []string
+	s.localDevice.AddOutboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		assert.Equal(s.T(), "ski", ski)
+		order = append(order, "device")
+		datagram.Header.AckRequest = util.Ptr(true)
+		return api.InterceptorActionContinue, nil
+	})
+	s.localDevice.AddOutboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		order = append(order, "second")
+		return api.InterceptorActionContinue, nil
+	})
+	sender := s.remoteDevice.Sender()
+
+	address := &model.FeatureAddressType{
+		Device:  util.Ptr(model.AddressDeviceType("Remote")),
+		Entity:  []model.AddressEntityType{1},
+		Feature: util.Ptr(model.AddressFeatureType(1)),
+	}
+	_, err := sender.Notify(address, address, model.CmdType{})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"device", "second"}, order)
+
+	var datagram model.Datagram
+	assert.Nil(s.T(), json.Unmarshal(s.writeHandler.LastMessage(), &datagram))
+	assert.True(s.T(), *datagram.Datagram.Header.AckRequest)
+}
+
+func (s *InterceptorSuite) Test_Outbound_StopAndReject() {
+	writeHandler := &WriteMessageHandler{}
+	sut := newSender(writeHandler)
+	interceptors := &interceptorChain{}
+	sut.setDeviceInterceptors(interceptors)
+
+	action := api.InterceptorActionStop
+	interceptors.add(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		return action, model.NewErrorTypeFromString("fault injection")
+	})
+
+	address := &model.FeatureAddressType{
+		Device:  util.Ptr(model.AddressDeviceType("Remote")),
+		Entity:  []model.AddressEntityType{1},
+		Feature: util.Ptr(model.AddressFeatureType(1)),
+	}
+
+	// stopped datagrams are not cached
+	msgCounter, err := sut.Notify(address, address, model.CmdType{})
+	assert.True(s.T(), errors.Is(err, ErrDatagramStopped))
+	assert.Nil(s.T(), msgCounter)
+	assert.Nil(s.T(), writeHandler.LastMessage())
+	_, err = sut.DatagramForMsgCounter(model.MsgCounterType(1))
+	assert.NotNil(s.T(), err)
+
+	cmd := []model.CmdType{{DeviceClassificationManufacturerData: &model.DeviceClassificationManufacturerDataType{}}}
+	_, err = sut.Request(model.CmdClassifierTypeRead, address, address, false, cmd)
+	assert.True(s.T(), errors.Is(err, ErrDatagramStopped))
+	assert.Equal(s.T(), 0, len(sut.reqMsgCache))
+
+	// stopped responses are no error
+	requestHeader := &model.HeaderType{
+		AddressSource:      address,
+		AddressDestination: address,
+		MsgCounter:         util.Ptr(model.MsgCounterType(10)),
+	}
+	assert.Nil(s.T(), sut.Reply(requestHeader, address, model.CmdType{}))
+	assert.Nil(s.T(), sut.ResultSuccess(requestHeader, address))
+	assert.Nil(s.T(), writeHandler.LastMessage())
+
+	action = api.InterceptorActionReject
+	_, err = sut.Notify(address, address, model.CmdType{})
+	assert.True(s.T(), errors.Is(err, ErrDatagramRejected))
+	assert.ErrorContains(s.T(), err, "fault injection")
+	assert.Nil(s.T(), writeHandler.LastMessage())
+}
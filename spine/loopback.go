@@ -0,0 +1,201 @@
+package spine
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	shipapi "github.com/enbility/ship-go/api"
+	"github.com/enbility/spine-go/api"
+)
+
+// Defines the transport behaviour of a loopback connection
+//
+// The zero value delivers every message immediately and in order
+type LoopbackOptions struct {
+	// Delay of every message
+	Latency time.Duration
+	// Probability between 0 and 1 that a message is dropped
+	DropRate float64
+	// Probability between 0 and 1 that a message is delayed by ReorderDelay,
+	// so that messages sent afterwards are delivered before it
+	ReorderRate float64
+	// Additional delay of reordered messages, defaults to 10ms
+	ReorderDelay time.Duration
+	// Seed of the random numbers deciding which messages are dropped or reordered,
+	// so that test runs are reproducible
+	Seed uint64
+}
+
+// Connects two local devices in-process, without a SHIP connection
+//
+// Used to run SPINE flows between two local devices end to end in tests
+type Loopback struct {
+	deviceA, deviceB api.DeviceLocalInterface
+	skiA, skiB       string
+
+	writerA, writerB *loopbackWriter
+}
+
+// Connect two local devices
+//
+// skiA is the SKI of deviceA as seen by deviceB and skiB the SKI of deviceB as seen by deviceA.
+// Both devices start with requesting the detailed discovery data of each other
+func NewLoopback(
+	deviceA api.DeviceLocalInterface, skiA string,
+	deviceB api.DeviceLocalInterface, skiB string,
+	options LoopbackOptions) *Loopback {
+	if options.ReorderDelay == 0 {
+		options.ReorderDelay = time.Millisecond * 10
+	}
+
+	r := &Loopback{
+		deviceA: deviceA,
+		deviceB: deviceB,
+		skiA:    skiA,
+		skiB:    skiB,
+		// writerA transports the messages sent by deviceA
+		writerA: newLoopbackWriter(options, rand.New(rand.NewPCG(options.Seed, 1))),
+		writerB: newLoopbackWriter(options, rand.New(rand.NewPCG(options.Seed, 2))),
+	}
+
+	// messages are only queued until both readers are available
+	r.writerB.reader = deviceA.SetupRemoteDevice(skiB, r.writerA)
+	r.writerA.reader = deviceB.SetupRemoteDevice(skiA, r.writerB)
+
+	go r.writerA.run()
+	go r.writerB.run()
+
+	return r
+}
+
+// Stop delivering messages and remove the remote devices from both local devices
+func (r *Loopback) Close() {
+	r.writerA.close()
+	r.writerB.close()
+
+	r.deviceA.RemoveRemoteDeviceConnection(r.skiB)
+	r.deviceB.RemoveRemoteDeviceConnection(r.skiA)
+}
+
+type loopbackMessage struct {
+	data      []byte
+	deliverAt time.Time
+}
+
+// Transports the messages of one direction of a loopback connection
+type loopbackWriter struct {
+	options LoopbackOptions
+	random  *rand.Rand
+	reader  shipapi.ShipConnectionDataReaderInterface
+
+	// sorted by delivery time
+	queue  []loopbackMessage
+	closed bool
+
+	signal chan struct{}
+	done   chan struct{}
+
+	mux sync.Mutex
+}
+
+var _ shipapi.ShipConnectionDataWriterInterface = (*loopbackWriter)(nil)
+
+func newLoopbackWriter(options LoopbackOptions, random *rand.Rand) *loopbackWriter {
+	return &loopbackWriter{
+		options: options,
+		random:  random,
+		signal:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+/* ShipConnectionDataWriterInterface */
+
+func (w *loopbackWriter) WriteShipMessageWithPayload(message []byte) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.closed || w.chance(w.options.DropRate) {
+		return
+	}
+
+	msg := loopbackMessage{
+		data:      slices.Clone(message),
+		deliverAt: time.Now().Add(w.options.Latency),
+	}
+	if w.chance(w.options.ReorderRate) {
+		msg.deliverAt = msg.deliverAt.Add(w.options.ReorderDelay)
+	}
+
+	// messages with the same delivery time keep their order
+	index, _ := slices.BinarySearchFunc(w.queue, msg, func(item, target loopbackMessage) int {
+		if item.deliverAt.After(target.deliverAt) {
+			return 1
+		}
+		return -1
+	})
+	w.queue = slices.Insert(w.queue, index, msg)
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// has to be called with locked mux
+func (w *loopbackWriter) chance(probability float64) bool {
+	return probability > 0 && w.random.Float64() < probability
+}
+
+// Deliver the queued messages in order of their delivery time
+func (w *loopbackWriter) run() {
+	for {
+		w.mux.Lock()
+		if w.closed {
+			w.mux.Unlock()
+			return
+		}
+
+		var wait time.Duration = -1
+		var data []byte
+		if len(w.queue) > 0 {
+			if wait = time.Until(w.queue[0].deliverAt); wait <= 0 {
+				data = w.queue[0].data
+				w.queue = w.queue[1:]
+			}
+		}
+		w.mux.Unlock()
+
+		if data != nil {
+			w.reader.HandleShipPayloadMessage(data)
+			continue
+		}
+
+		var timer <-chan time.Time
+		if wait > 0 {
+			timer = time.After(wait)
+		}
+
+		select {
+		case <-w.done:
+			return
+		case <-w.signal:
+		case <-timer:
+		}
+	}
+}
+
+func (w *loopbackWriter) close() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+
+	if w.closed {
+		return
+	}
+
+	w.closed = true
+	w.queue = nil
+	close(w.done)
+}
@@ -0,0 +1,613 @@
+package spine
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLoopbackSuite(t *testing.T) {
+	suite.Run(t, new(LoopbackSuite))
+}
+
+type LoopbackSuite struct {
+	suite.Suite
+
+	deviceA, deviceB *DeviceLocal
+	clientFeature    api.FeatureLocalInterface
+	serverFeature    api.FeatureLocalInterface
+
+	sut *Loopback
+}
+
+func (s *LoopbackSuite) BeforeTest(suiteName, testName string) {
+	s.deviceA = NewDeviceLocal("Vendor", "DeviceA", "SerialA", "CodeA", "AddressA", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	entityA := NewEntityLocal(s.deviceA, model.EntityTypeTypeCEM, []model.AddressEntityType{1}, time.Second*4)
+	s.deviceA.AddEntity(entityA)
+	s.clientFeature = entityA.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+
+	s.deviceB = NewDeviceLocal("Vendor", "DeviceB", "SerialB", "CodeB", "AddressB", model.DeviceTypeTypeChargingStation, model.NetworkManagementFeatureSetTypeSmart)
+	entityB := NewEntityLocal(s.deviceB, model.EntityTypeTypeEVSE, []model.AddressEntityType{1}, time.Second*4)
+	s.deviceB.AddEntity(entityB)
+	s.serverFeature = entityB.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	s.serverFeature.AddFunctionType(model.FunctionTypeLoadControlLimitListData, true, true)
+}
+
+func (s *LoopbackSuite) AfterTest(suiteName, testName string) {
+	if s.sut != nil {
+		s.sut.Close()
+	}
+}
+
+// wait until deviceA knows the server feature of deviceB
+func (s *LoopbackSuite) remoteServerFeature() api.FeatureRemoteInterface {
+	var feature api.FeatureRemoteInterface
+
+	assert.Eventually(s.T(), func() bool {
+		remoteDevice := s.deviceA.RemoteDeviceForSki("skiB")
+		if remoteDevice == nil {
+			return false
+		}
+		feature = remoteDevice.FeatureByAddress(s.serverFeature.Address())
+		return feature != nil
+	}, time.Second*2, time.Millisecond*10)
+
+	return feature
+}
+
+func (s *LoopbackSuite) limitData(value float64) *model.LoadControlLimitListDataType {
+	return &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{
+				LimitId:           util.Ptr(model.LoadControlLimitIdType(1)),
+				IsLimitChangeable: util.Ptr(true),
+				Value:             model.NewScaledNumberType(value),
+			},
+		},
+	}
+}
+
+func (s *LoopbackSuite) remoteLimitValue(feature api.FeatureRemoteInterface) float64 {
+	data, ok := feature.DataCopy(model.FunctionTypeLoadControlLimitListData).(*model.LoadControlLimitListDataType)
+	if !ok || data == nil || len(data.LoadControlLimitData) == 0 {
+		return -1
+	}
+
+	return data.LoadControlLimitData[0].Value.GetValue()
+}
+
+func (s *LoopbackSuite) Test_Subscription() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{
+		Latency: time.Millisecond * 5,
+	})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	// deviceB knows deviceA as well
+	assert.Eventually(s.T(), func() bool {
+		remoteDevice := s.deviceB.RemoteDeviceForSki("skiA")
+		return remoteDevice != nil && remoteDevice.FeatureByAddress(s.clientFeature.Address()) != nil
+	}, time.Second*2, time.Millisecond*10)
+
+	_, err := s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, s.limitData(16))
+
+	assert.Eventually(s.T(), func() bool {
+		return s.remoteLimitValue(remoteFeature) == 16
+	}, time.Second, time.Millisecond*10)
+}
+
+func (s *LoopbackSuite) Test_BindingAndWrite() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, s.limitData(16))
+
+	_, err := s.clientFeature.BindToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Eventually(s.T(), func() bool {
+		return s.clientFeature.HasBindingToRemote(remoteFeature.Address())
+	}, time.Second, time.Millisecond*10)
+
+	cmd := model.CmdType{
+		LoadControlLimitListData: s.limitData(10),
+	}
+	_, err1 := remoteFeature.Device().Sender().Write(s.clientFeature.Address(), remoteFeature.Address(), cmd)
+	assert.Nil(s.T(), err1)
+
+	assert.Eventually(s.T(), func() bool {
+		data, ok := s.serverFeature.DataCopy(model.FunctionTypeLoadControlLimitListData).(*model.LoadControlLimitListDataType)
+		return ok && data.LoadControlLimitData[0].Value.GetValue() == 10
+	}, time.Second, time.Millisecond*10)
+}
+
+func (s *LoopbackSuite) Test_Drop() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{
+		DropRate: 1,
+	})
+
+	time.Sleep(time.Millisecond * 100)
+
+	remoteDevice := s.deviceA.RemoteDeviceForSki("skiB")
+	if assert.NotNil(s.T(), remoteDevice) {
+		assert.Nil(s.T(), remoteDevice.FeatureByAddress(s.serverFeature.Address()))
+	}
+}
+
+func (s *LoopbackSuite) Test_Reorder() {
+	reader := &loopbackReader{}
+	sut := newLoopbackWriter(LoopbackOptions{ReorderDelay: time.Millisecond * 50}, rand.New(rand.NewPCG(1, 1)))
+	sut.reader = reader
+	go sut.run()
+	defer sut.close()
+
+	// the first message is reordered
+	sut.options.ReorderRate = 1
+	sut.WriteShipMessageWithPayload([]byte("1"))
+	sut.options.ReorderRate = 0
+	sut.WriteShipMessageWithPayload([]byte("2"))
+	sut.WriteShipMessageWithPayload([]byte("3"))
+
+	assert.Eventually(s.T(), func() bool {
+		return reader.messageCount() == 3
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), []string{"2", "3", "1"}, reader.messageList())
+}
+
+func (s *LoopbackSuite) Test_Close() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+	s.remoteServerFeature()
+
+	s.sut.Close()
+	s.sut = nil
+
+	assert.Nil(s.T(), s.deviceA.RemoteDeviceForSki("skiB"))
+	assert.Nil(s.T(), s.deviceB.RemoteDeviceForSki("skiA"))
+}
+
+func (s *LoopbackSuite) Test_Shutdown() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	_, err := s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	_, err = s.clientFeature.BindToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 1 &&
+			len(s.deviceB.BindingManager().BindingsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+
+	// a request which is never answered
+	results := make(chan api.ResponseMessage, 1)
+	err1 := s.clientFeature.AddResponseCallback(model.MsgCounterType(1000), func(msg api.ResponseMessage) {
+		results <- msg
+	})
+	assert.Nil(s.T(), err1)
+
+	// an application event handler which is still running
+	handler := &orderedEventHandler{block: make(chan struct{})}
+	err1 = s.deviceA.Events().Subscribe(handler)
+	assert.Nil(s.T(), err1)
+	s.deviceA.Events().Publish(api.EventPayload{Data: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	assert.Equal(s.T(), context.DeadlineExceeded, s.deviceA.Shutdown(ctx))
+
+	select {
+	case msg := <-results:
+		if data, ok := msg.Data.(*model.ResultDataType); assert.True(s.T(), ok) {
+			assert.Equal(s.T(), model.ErrorNumberTypeGeneralError, *data.ErrorNumber)
+		}
+	case <-time.After(time.Second):
+		s.T().Fatal("response callback was not invoked")
+	}
+
+	// deviceB is informed about the removed entity, the subscription and the binding
+	assert.Eventually(s.T(), func() bool {
+		remoteDevice := s.deviceB.RemoteDeviceForSki("skiA")
+		return remoteDevice != nil && remoteDevice.Entity(s.clientFeature.Address().Entity) == nil &&
+			len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 0 &&
+			len(s.deviceB.BindingManager().BindingsOnFeature(*s.serverFeature.Address())) == 0
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), 1, len(s.deviceA.Entities()))
+
+	close(handler.block)
+	assert.Nil(s.T(), s.deviceA.Shutdown(context.Background()))
+	_ = s.deviceA.Events().Unsubscribe(handler)
+}
+
+func (s *LoopbackSuite) Test_ShutdownStopsTimersAndGoroutines() {
+	goroutines := runtime.NumGoroutine()
+
+	s.deviceB.SetInboundQueueSize(10)
+	s.deviceB.SetNotifyRateLimit(nil, api.NotifyRateLimit{CoalescingWindow: time.Hour})
+	s.deviceB.SetNotifyPriorityFunctions()
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	_, err := s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+
+	// a notify waiting for the rate limit
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, s.limitData(16))
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, s.limitData(10))
+	s.deviceB.notifyLimiter.mux.Lock()
+	assert.Equal(s.T(), 1, len(s.deviceB.notifyLimiter.pending))
+	s.deviceB.notifyLimiter.mux.Unlock()
+
+	assert.Nil(s.T(), s.deviceB.Shutdown(context.Background()))
+
+	// the pending notify is sent instead of waiting for its timer
+	assert.Eventually(s.T(), func() bool {
+		return s.remoteLimitValue(remoteFeature) == 10
+	}, time.Second, time.Millisecond*10)
+	s.deviceB.notifyLimiter.mux.Lock()
+	assert.Equal(s.T(), 0, len(s.deviceB.notifyLimiter.pending))
+	s.deviceB.notifyLimiter.mux.Unlock()
+	assert.Nil(s.T(), s.deviceB.notifyLimiter.wait(context.Background()))
+
+	// the inbound queue is closed
+	remoteDevice, ok := s.deviceB.RemoteDeviceForSki("skiA").(*DeviceRemote)
+	if assert.True(s.T(), ok) && assert.NotNil(s.T(), remoteDevice.inboundQueue) {
+		select {
+		case <-remoteDevice.inboundQueue.done:
+		default:
+			s.T().Error("the inbound queue is not closed")
+		}
+	}
+
+	// restores are not started anymore
+	s.deviceB.runBackgroundTask(func() {
+		s.T().Error("background task started after shutdown")
+	})
+	assert.Nil(s.T(), s.deviceB.backgroundTasks.wait(context.Background()))
+
+	// requests are rejected, the node management remains after the shutdown
+	results := make(chan api.ResponseMessage, 1)
+	s.deviceA.NodeManagement().AddResultCallback(func(msg api.ResponseMessage) {
+		results <- msg
+	})
+	_, err2 := remoteFeature.Device().Sender().Request(model.CmdClassifierTypeRead, s.deviceA.NodeManagement().Address(), s.deviceB.NodeManagement().Address(), false,
+		[]model.CmdType{{NodeManagementUseCaseData: &model.NodeManagementUseCaseDataType{}}})
+	assert.Nil(s.T(), err2)
+
+	select {
+	case msg := <-results:
+		if data, ok := msg.Data.(*model.ResultDataType); assert.True(s.T(), ok) {
+			assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, *data.ErrorNumber)
+		}
+	case <-time.After(time.Second):
+		s.T().Fatal("the request was not rejected")
+	}
+
+	s.sut.Close()
+	s.sut = nil
+	assert.Nil(s.T(), s.deviceA.Shutdown(context.Background()))
+
+	// neither the timers nor any goroutine of the devices remain,
+	// apart from the goroutine evaluating the condition
+	assert.Eventually(s.T(), func() bool {
+		return runtime.NumGoroutine()-1 <= goroutines
+	}, time.Second*2, time.Millisecond*10)
+}
+
+func (s *LoopbackSuite) Test_ShutdownWriteApproval() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	// the write is never approved by the application
+	approvals := make(chan *api.Message, 1)
+	err := s.serverFeature.AddWriteApprovalCallback(func(msg *api.Message) {
+		approvals <- msg
+	})
+	assert.Nil(s.T(), err)
+
+	_, err1 := s.clientFeature.BindToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err1)
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.BindingManager().BindingsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+
+	results := make(chan api.ResponseMessage, 1)
+	s.clientFeature.AddResultCallback(func(msg api.ResponseMessage) {
+		results <- msg
+	})
+
+	cmd := model.CmdType{
+		LoadControlLimitListData: s.limitData(10),
+	}
+	_, err = remoteFeature.Device().Sender().Write(s.clientFeature.Address(), remoteFeature.Address(), cmd)
+	assert.Nil(s.T(), err)
+
+	select {
+	case <-approvals:
+	case <-time.After(time.Second):
+		s.T().Fatal("write approval callback was not invoked")
+	}
+
+	assert.Nil(s.T(), s.deviceB.Shutdown(context.Background()))
+
+	select {
+	case msg := <-results:
+		if data, ok := msg.Data.(*model.ResultDataType); assert.True(s.T(), ok) {
+			assert.NotEqual(s.T(), model.ErrorNumberTypeNoError, *data.ErrorNumber)
+		}
+	case <-time.After(time.Second):
+		s.T().Fatal("the write was not denied")
+	}
+}
+
+func (s *LoopbackSuite) Test_Reconnect() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	_, err := s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	_, err = s.clientFeature.BindToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 1 &&
+			len(s.deviceB.BindingManager().BindingsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), 2, len(s.deviceA.outboundEntries.entriesForSki("skiB")))
+
+	s.sut.Close()
+	assert.Empty(s.T(), s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address()))
+
+	// the subscription and binding are re-established without the application
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	assert.Eventually(s.T(), func() bool {
+		return len(s.deviceB.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())) == 1 &&
+			len(s.deviceB.BindingManager().BindingsOnFeature(*s.serverFeature.Address())) == 1
+	}, time.Second*2, time.Millisecond*10)
+
+	remoteFeature = s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+	assert.True(s.T(), s.clientFeature.HasSubscriptionToRemote(remoteFeature.Address()))
+	assert.True(s.T(), s.clientFeature.HasBindingToRemote(remoteFeature.Address()))
+
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, s.limitData(16))
+	assert.Eventually(s.T(), func() bool {
+		return s.remoteLimitValue(remoteFeature) == 16
+	}, time.Second, time.Millisecond*10)
+
+	// the remote feature does not exist anymore after the next reconnect
+	s.sut.Close()
+	s.deviceB.RemoveEntity(s.serverFeature.Entity())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	failures := s.deviceA.Events().SubscribeChannel(ctx, api.EventFilter{
+		Ski:       "skiB",
+		EventType: util.Ptr(api.EventTypeOutboundRestoreFailed),
+	})
+
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	select {
+	case event := <-failures:
+		data, ok := event.Data.([]api.OutboundEntryRestoreFailure)
+		if assert.True(s.T(), ok) && assert.Equal(s.T(), 2, len(data)) {
+			assert.Equal(s.T(), api.OutboundEntryTypeSubscription, data[0].Entry.Type)
+			assert.Equal(s.T(), api.OutboundEntryTypeBinding, data[1].Entry.Type)
+			for _, failure := range data {
+				assert.Equal(s.T(), model.EntityTypeTypeEVSE, failure.Entry.EntityType)
+				assert.Equal(s.T(), model.FeatureTypeTypeLoadControl, failure.Entry.FeatureType)
+				assert.Equal(s.T(), model.RoleTypeServer, failure.Entry.Role)
+				assert.Equal(s.T(), model.ErrorNumberTypeDestinationUnknown, failure.Error.ErrorNumber)
+			}
+		}
+	case <-time.After(time.Second * 2):
+		s.T().Fatal("no restore failure event received")
+	}
+
+	// entries removed by the application are not restored anymore
+	s.sut.Close()
+	s.sut = nil
+	s.clientFeature.RemoveAllRemoteSubscriptions()
+	s.clientFeature.RemoveAllRemoteBindings()
+	_, _ = s.clientFeature.RemoveRemoteSubscription(remoteFeature.Address())
+	_, _ = s.clientFeature.RemoveRemoteBinding(remoteFeature.Address())
+	assert.Empty(s.T(), s.deviceA.outboundEntries.entriesForSki("skiB"))
+}
+
+// wait for the next outbound state change event
+func (s *LoopbackSuite) nextOutboundState(events <-chan api.EventPayload) api.OutboundStateChange {
+	select {
+	case event := <-events:
+		change, ok := event.Data.(api.OutboundStateChange)
+		assert.True(s.T(), ok)
+		assert.Equal(s.T(), s.clientFeature, event.LocalFeature)
+		return change
+	case <-time.After(time.Second):
+		s.T().Fatal("no outbound state change event received")
+	}
+
+	return api.OutboundStateChange{}
+}
+
+func (s *LoopbackSuite) Test_OutboundStateAfterContextTimeout() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{
+		Latency: time.Millisecond * 200,
+	})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	// the context is done before the result is received
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err := SubscribeToRemoteCtx(ctx, s.clientFeature, remoteFeature.Address())
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeTimeout, err.ErrorNumber)
+	}
+
+	state, ok := s.clientFeature.SubscriptionToRemoteState(remoteFeature.Address())
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), api.OutboundStatePending, state)
+
+	// the result received afterwards still activates the subscription
+	assert.Eventually(s.T(), func() bool {
+		state, _ := s.clientFeature.SubscriptionToRemoteState(remoteFeature.Address())
+		return state == api.OutboundStateActive
+	}, time.Second*2, time.Millisecond*10)
+	assert.True(s.T(), s.clientFeature.HasSubscriptionToRemote(remoteFeature.Address()))
+	assert.Equal(s.T(), 1, len(s.deviceA.outboundEntries.entriesForSki("skiB")))
+}
+
+func (s *LoopbackSuite) Test_OutboundStates() {
+	s.sut = NewLoopback(s.deviceA, "skiA", s.deviceB, "skiB", LoopbackOptions{})
+
+	remoteFeature := s.remoteServerFeature()
+	if remoteFeature == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := s.deviceA.Events().SubscribeChannel(ctx, api.EventFilter{
+		Ski:       "skiB",
+		EventType: util.Ptr(api.EventTypeOutboundStateChange),
+	})
+
+	_, ok := s.clientFeature.SubscriptionToRemoteState(remoteFeature.Address())
+	assert.False(s.T(), ok)
+
+	// the subscription is accepted
+	_, err := s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	change := s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundEntryTypeSubscription, change.Type)
+	assert.Equal(s.T(), api.OutboundStatePending, change.State)
+	change = s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundStateActive, change.State)
+	assert.Nil(s.T(), change.Error)
+
+	state, ok := s.clientFeature.SubscriptionToRemoteState(remoteFeature.Address())
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), api.OutboundStateActive, state)
+
+	// the binding is rejected and rolled back
+	s.deviceB.BindingManager().AddAuthorizationCallback(func(string, api.FeatureRemoteInterface, api.FeatureLocalInterface) error {
+		return errors.New("not allowed")
+	})
+
+	_, err = s.clientFeature.BindToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	change = s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundEntryTypeBinding, change.Type)
+	assert.Equal(s.T(), api.OutboundStatePending, change.State)
+	change = s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundStateRejected, change.State)
+	if assert.NotNil(s.T(), change.Error) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, change.Error.ErrorNumber)
+	}
+
+	state, ok = s.clientFeature.BindingToRemoteState(remoteFeature.Address())
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), api.OutboundStateRejected, state)
+	assert.False(s.T(), s.clientFeature.HasBindingToRemote(remoteFeature.Address()))
+	assert.Equal(s.T(), 1, len(s.deviceA.outboundEntries.entriesForSki("skiB")))
+
+	// the subscription is removed by the application
+	_, err = s.clientFeature.RemoveRemoteSubscription(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+
+	change = s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundEntryTypeSubscription, change.Type)
+	assert.Equal(s.T(), api.OutboundStateRemoved, change.State)
+	assert.False(s.T(), s.clientFeature.HasSubscriptionToRemote(remoteFeature.Address()))
+
+	// the subscription is removed if the remote device disconnects
+	_, err = s.clientFeature.SubscribeToRemote(remoteFeature.Address())
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), api.OutboundStatePending, s.nextOutboundState(events).State)
+	assert.Equal(s.T(), api.OutboundStateActive, s.nextOutboundState(events).State)
+
+	s.sut.Close()
+	s.sut = nil
+
+	change = s.nextOutboundState(events)
+	assert.Equal(s.T(), api.OutboundEntryTypeSubscription, change.Type)
+	assert.Equal(s.T(), api.OutboundStateRemoved, change.State)
+	state, ok = s.clientFeature.SubscriptionToRemoteState(remoteFeature.Address())
+	assert.True(s.T(), ok)
+	assert.Equal(s.T(), api.OutboundStateRemoved, state)
+}
+
+type loopbackReader struct {
+	messages []string
+	mux      sync.Mutex
+}
+
+func (r *loopbackReader) HandleShipPayloadMessage(message []byte) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.messages = append(r.messages, string(message))
+}
+
+func (r *loopbackReader) messageCount() int {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return len(r.messages)
+}
+
+func (r *loopbackReader) messageList() []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	return slices.Clone(r.messages)
+}
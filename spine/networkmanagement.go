@@ -0,0 +1,251 @@
+package spine
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/enbility/ship-go/logging"
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+)
+
+var _ api.NetworkManagementInterface = (*NetworkManagement)(nil)
+
+// A NetworkManagement server feature, which lets remote devices add, remove and scan
+// for nodes of the network behind a gateway
+//
+// Only one process can run at a time, the processes themselves are implemented
+// by the application via api.NetworkManagementHandlerInterface
+type NetworkManagement struct {
+	*FeatureLocal
+
+	handler api.NetworkManagementHandlerInterface
+
+	process    *networkProcess // the running process, nil if no process is running
+	muxProcess sync.Mutex
+}
+
+// a process started by a call of a remote device
+type networkProcess struct {
+	function model.FunctionType
+	timer    *time.Timer // fails the process if it is not finished within the timeout of the call
+}
+
+// Create a NetworkManagement server feature, calls are rejected if no handler is provided
+func NewNetworkManagement(id uint, entity api.EntityLocalInterface, handler api.NetworkManagementHandlerInterface) *NetworkManagement {
+	f := &NetworkManagement{
+		FeatureLocal: NewFeatureLocal(
+			id, entity,
+			model.FeatureTypeTypeNetworkManagement,
+			model.RoleTypeSpecial),
+		handler: handler,
+	}
+
+	f.AddFunctionType(model.FunctionTypeNetworkManagementAddNodeCall, false, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementRemoveNodeCall, false, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementScanNetworkCall, false, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementDiscoverCall, false, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementAbortCall, false, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementProcessStateData, true, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementReportCandidateData, true, false)
+	f.AddFunctionType(model.FunctionTypeNetworkManagementJoiningModeData, true, false)
+
+	return f
+}
+
+/* NetworkManagementInterface */
+
+func (r *NetworkManagement) RunningProcess() *model.FunctionType {
+	r.muxProcess.Lock()
+	defer r.muxProcess.Unlock()
+
+	if r.process == nil {
+		return nil
+	}
+
+	return util.Ptr(r.process.function)
+}
+
+func (r *NetworkManagement) FinishProcess(state model.NetworkManagementProcessStateStateType, description string) error {
+	r.muxProcess.Lock()
+	process := r.process
+	r.muxProcess.Unlock()
+
+	if process == nil || !r.finishProcess(process, state, description) {
+		return errors.New("no process is running")
+	}
+
+	return nil
+}
+
+func (r *NetworkManagement) ReportCandidate(candidate model.NetworkManagementReportCandidateDataType) error {
+	if process := r.RunningProcess(); process == nil || *process != model.FunctionTypeNetworkManagementScanNetworkCall {
+		return errors.New("no network scan is running")
+	}
+
+	r.setDataAndNotify(model.FunctionTypeNetworkManagementReportCandidateData, &candidate)
+
+	return nil
+}
+
+func (r *NetworkManagement) SetJoiningMode(data model.NetworkManagementJoiningModeDataType) {
+	r.SetData(model.FunctionTypeNetworkManagementJoiningModeData, &data)
+}
+
+/* FeatureLocalInterface */
+
+func (r *NetworkManagement) HandleMessage(message *api.Message) *model.ErrorType {
+	if message.CmdClassifier != model.CmdClassifierTypeCall {
+		return r.FeatureLocal.HandleMessage(message)
+	}
+
+	if r.handler == nil {
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, "networkmanagement.Handle: no handler for calls available")
+	}
+
+	ski := message.FeatureRemote.Device().Ski()
+
+	switch {
+	case message.Cmd.NetworkManagementAddNodeCall != nil:
+		data := *message.Cmd.NetworkManagementAddNodeCall
+		return r.startProcess(model.FunctionTypeNetworkManagementAddNodeCall, data.Timeout, func() *model.ErrorType {
+			return r.handler.AddNode(ski, data)
+		})
+
+	case message.Cmd.NetworkManagementRemoveNodeCall != nil:
+		data := *message.Cmd.NetworkManagementRemoveNodeCall
+		return r.startProcess(model.FunctionTypeNetworkManagementRemoveNodeCall, data.Timeout, func() *model.ErrorType {
+			return r.handler.RemoveNode(ski, data)
+		})
+
+	case message.Cmd.NetworkManagementScanNetworkCall != nil:
+		data := *message.Cmd.NetworkManagementScanNetworkCall
+		return r.startProcess(model.FunctionTypeNetworkManagementScanNetworkCall, data.Timeout, func() *model.ErrorType {
+			return r.handler.ScanNetwork(ski, data)
+		})
+
+	case message.Cmd.NetworkManagementDiscoverCall != nil:
+		data := *message.Cmd.NetworkManagementDiscoverCall
+		return r.startProcess(model.FunctionTypeNetworkManagementDiscoverCall, nil, func() *model.ErrorType {
+			return r.handler.Discover(ski, data)
+		})
+
+	case message.Cmd.NetworkManagementAbortCall != nil:
+		return r.abortProcess()
+
+	default:
+		return model.NewErrorType(model.ErrorNumberTypeCommandNotSupported, fmt.Sprintf("networkmanagement.Handle: Cmd data not implemented: %s", message.Cmd.DataName()))
+	}
+}
+
+// start a process, if no other process is running and the application accepts it
+func (r *NetworkManagement) startProcess(
+	function model.FunctionType,
+	timeout *model.NetworkManagementProcessTimeoutType,
+	start func() *model.ErrorType) *model.ErrorType {
+	r.muxProcess.Lock()
+	if r.process != nil {
+		r.muxProcess.Unlock()
+		return model.NewErrorType(model.ErrorNumberTypeCommandRejected, fmt.Sprintf("process %s is running", r.process.function))
+	}
+
+	// the process is set before the application is invoked, so it may finish the process right away
+	process := &networkProcess{function: function}
+	r.process = process
+	r.muxProcess.Unlock()
+
+	if err := start(); err != nil {
+		r.muxProcess.Lock()
+		if r.process == process {
+			r.process = nil
+		}
+		r.muxProcess.Unlock()
+
+		return err
+	}
+
+	if timeout == nil {
+		return nil
+	}
+
+	duration, err := util.Ptr(model.DurationType(*timeout)).GetTimeDuration()
+	if err != nil || duration <= 0 {
+		return nil
+	}
+
+	r.muxProcess.Lock()
+	defer r.muxProcess.Unlock()
+
+	if r.process == process {
+		process.timer = time.AfterFunc(duration, func() {
+			r.muxProcess.Lock()
+			running := r.process == process
+			r.muxProcess.Unlock()
+
+			if running {
+				r.handler.AbortProcess(process.function)
+				r.finishProcess(process, model.NetworkManagementProcessStateStateTypeFailed, "process timed out")
+			}
+		})
+	}
+
+	return nil
+}
+
+// abort the running process on request of a remote device
+func (r *NetworkManagement) abortProcess() *model.ErrorType {
+	r.muxProcess.Lock()
+	process := r.process
+	r.muxProcess.Unlock()
+
+	if process == nil {
+		return model.NewErrorType(model.ErrorNumberTypeCommandRejected, "no process is running")
+	}
+
+	r.handler.AbortProcess(process.function)
+	r.finishProcess(process, model.NetworkManagementProcessStateStateTypeAborted, "process aborted")
+
+	return nil
+}
+
+// finish a process and notify subscribers about its state
+//
+// returns false if the process is not running anymore
+func (r *NetworkManagement) finishProcess(process *networkProcess, state model.NetworkManagementProcessStateStateType, description string) bool {
+	r.muxProcess.Lock()
+	if r.process != process {
+		r.muxProcess.Unlock()
+		return false
+	}
+	if process.timer != nil {
+		process.timer.Stop()
+	}
+	r.process = nil
+	r.muxProcess.Unlock()
+
+	data := &model.NetworkManagementProcessStateDataType{
+		State: util.Ptr(state),
+	}
+	if len(description) > 0 {
+		data.Description = util.Ptr(model.DescriptionType(description))
+	}
+	r.setDataAndNotify(model.FunctionTypeNetworkManagementProcessStateData, data)
+
+	return true
+}
+
+// set the data of a function and always notify subscribers about it,
+// as repeated process states and candidates are reports of their own
+func (r *NetworkManagement) setDataAndNotify(function model.FunctionType, data any) {
+	if _, _, err := r.updateData(false, function, data, nil, nil); err != nil {
+		logging.Log().Debug(err.String())
+		return
+	}
+
+	if cmd, ok := r.notifyCmdType(function); ok {
+		r.Device().NotifySubscribers(r.Address(), cmd)
+	}
+}
@@ -0,0 +1,283 @@
+package spine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNetworkManagementSuite(t *testing.T) {
+	suite.Run(t, new(NetworkManagementSuite))
+}
+
+type NetworkManagementSuite struct {
+	suite.Suite
+
+	senderMock  *mocks.SenderInterface
+	handlerMock *mocks.NetworkManagementHandlerInterface
+
+	localDevice   *DeviceLocal
+	clientFeature api.FeatureRemoteInterface
+	sut           *NetworkManagement
+}
+
+func (s *NetworkManagementSuite) BeforeTest(suiteName, testName string) {
+	s.senderMock = mocks.NewSenderInterface(s.T())
+	s.handlerMock = mocks.NewNetworkManagementHandlerInterface(s.T())
+
+	localDevice, localEntity := createLocalDeviceAndEntity(1)
+	s.localDevice = localDevice
+	s.sut = NewNetworkManagement(localEntity.NextFeatureId(), localEntity, s.handlerMock)
+	localEntity.AddFeature(s.sut)
+
+	remoteDevice := createRemoteDevice(localDevice, "ski", s.senderMock)
+	s.clientFeature, _ = createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeNetworkManagement, "")
+	localDevice.AddRemoteDeviceForSki(remoteDevice.Ski(), remoteDevice)
+
+	err := localDevice.SubscriptionManager().AddSubscription(remoteDevice, model.SubscriptionManagementRequestCallType{
+		ClientAddress:     s.clientFeature.Address(),
+		ServerAddress:     s.sut.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeNetworkManagement),
+	})
+	assert.Nil(s.T(), err)
+}
+
+func (s *NetworkManagementSuite) call(cmd model.CmdType) *model.ErrorType {
+	return s.sut.HandleMessage(&api.Message{
+		Cmd:           cmd,
+		CmdClassifier: model.CmdClassifierTypeCall,
+		FeatureRemote: s.clientFeature,
+		EntityRemote:  s.clientFeature.Entity(),
+		DeviceRemote:  s.clientFeature.Device(),
+	})
+}
+
+// expect a notify of the process state to the subscribed client
+func (s *NetworkManagementSuite) expectProcessState(state model.NetworkManagementProcessStateStateType) {
+	s.senderMock.EXPECT().Notify(s.sut.Address(), s.clientFeature.Address(), mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			if assert.NotNil(s.T(), cmd[0].NetworkManagementProcessStateData) {
+				assert.Equal(s.T(), state, *cmd[0].NetworkManagementProcessStateData.State)
+			}
+		}).Return(nil, nil).Once()
+}
+
+func (s *NetworkManagementSuite) Test_AddNode() {
+	nodeAddress := &model.FeatureAddressType{Device: util.Ptr(model.AddressDeviceType("Node"))}
+	s.handlerMock.EXPECT().AddNode("ski", model.NetworkManagementAddNodeCallType{NodeAddress: nodeAddress}).Return(nil).Once()
+
+	err := s.call(model.CmdType{
+		NetworkManagementAddNodeCall: &model.NetworkManagementAddNodeCallType{NodeAddress: nodeAddress},
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), util.Ptr(model.FunctionTypeNetworkManagementAddNodeCall), s.sut.RunningProcess())
+
+	// only one process can run at a time
+	err = s.call(model.CmdType{
+		NetworkManagementRemoveNodeCall: &model.NetworkManagementRemoveNodeCallType{},
+	})
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+	}
+
+	s.expectProcessState(model.NetworkManagementProcessStateStateTypeSucceeded)
+	assert.Nil(s.T(), s.sut.FinishProcess(model.NetworkManagementProcessStateStateTypeSucceeded, "node added"))
+	assert.Nil(s.T(), s.sut.RunningProcess())
+
+	data := s.sut.DataCopy(model.FunctionTypeNetworkManagementProcessStateData).(*model.NetworkManagementProcessStateDataType)
+	assert.Equal(s.T(), model.NetworkManagementProcessStateStateTypeSucceeded, *data.State)
+	assert.Equal(s.T(), model.DescriptionType("node added"), *data.Description)
+
+	assert.NotNil(s.T(), s.sut.FinishProcess(model.NetworkManagementProcessStateStateTypeSucceeded, ""))
+}
+
+func (s *NetworkManagementSuite) Test_RemoveNode_Rejected() {
+	s.handlerMock.EXPECT().RemoveNode("ski", mock.Anything).
+		Return(model.NewErrorType(model.ErrorNumberTypeCommandRejected, "unknown node")).Once()
+
+	err := s.call(model.CmdType{
+		NetworkManagementRemoveNodeCall: &model.NetworkManagementRemoveNodeCallType{},
+	})
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+	}
+	assert.Nil(s.T(), s.sut.RunningProcess())
+}
+
+func (s *NetworkManagementSuite) Test_ScanNetwork() {
+	candidate := model.NetworkManagementReportCandidateDataType{
+		CandidateSetup: util.Ptr(model.NetworkManagementCandidateSetupType("setup")),
+	}
+	assert.NotNil(s.T(), s.sut.ReportCandidate(candidate))
+
+	s.handlerMock.EXPECT().ScanNetwork("ski", mock.Anything).Return(nil).Once()
+	err := s.call(model.CmdType{
+		NetworkManagementScanNetworkCall: &model.NetworkManagementScanNetworkCallType{},
+	})
+	assert.Nil(s.T(), err)
+
+	// every candidate is reported, even if it equals the previous one
+	s.senderMock.EXPECT().Notify(s.sut.Address(), s.clientFeature.Address(), mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			assert.Equal(s.T(), &candidate, cmd[0].NetworkManagementReportCandidateData)
+		}).Return(nil, nil).Twice()
+	assert.Nil(s.T(), s.sut.ReportCandidate(candidate))
+	assert.Nil(s.T(), s.sut.ReportCandidate(candidate))
+
+	s.handlerMock.EXPECT().AbortProcess(model.FunctionTypeNetworkManagementScanNetworkCall).Once()
+	s.expectProcessState(model.NetworkManagementProcessStateStateTypeAborted)
+	err = s.call(model.CmdType{
+		NetworkManagementAbortCall: &model.NetworkManagementAbortCallType{},
+	})
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), s.sut.RunningProcess())
+
+	err = s.call(model.CmdType{
+		NetworkManagementAbortCall: &model.NetworkManagementAbortCallType{},
+	})
+	assert.NotNil(s.T(), err)
+}
+
+func (s *NetworkManagementSuite) Test_Timeout() {
+	s.handlerMock.EXPECT().AddNode("ski", mock.Anything).Return(nil).Once()
+	s.handlerMock.EXPECT().AbortProcess(model.FunctionTypeNetworkManagementAddNodeCall).Once()
+	notified := make(chan model.CmdType)
+	s.senderMock.EXPECT().Notify(s.sut.Address(), s.clientFeature.Address(), mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			notified <- cmd[0]
+		}).Return(nil, nil).Once()
+
+	timeout := model.NetworkManagementProcessTimeoutType("PT0.1S")
+	err := s.call(model.CmdType{
+		NetworkManagementAddNodeCall: &model.NetworkManagementAddNodeCallType{Timeout: &timeout},
+	})
+	assert.Nil(s.T(), err)
+
+	select {
+	case cmd := <-notified:
+		assert.Equal(s.T(), model.NetworkManagementProcessStateStateTypeFailed, *cmd.NetworkManagementProcessStateData.State)
+		assert.Nil(s.T(), s.sut.RunningProcess())
+	case <-time.After(time.Second):
+		s.T().Error("process did not time out")
+	}
+}
+
+func (s *NetworkManagementSuite) Test_Read() {
+	s.senderMock.EXPECT().Reply(mock.Anything, s.sut.Address(), mock.Anything).
+		Run(func(requestHeader *model.HeaderType, senderAddress *model.FeatureAddressType, cmd model.CmdType) {
+			assert.NotNil(s.T(), cmd.NetworkManagementProcessStateData)
+		}).Return(nil).Once()
+
+	err := s.sut.HandleMessage(&api.Message{
+		Cmd: model.CmdType{
+			NetworkManagementProcessStateData: &model.NetworkManagementProcessStateDataType{},
+		},
+		CmdClassifier: model.CmdClassifierTypeRead,
+		RequestHeader: &model.HeaderType{MsgCounter: util.Ptr(model.MsgCounterType(1))},
+		FeatureRemote: s.clientFeature,
+	})
+	assert.Nil(s.T(), err)
+}
+
+func (s *NetworkManagementSuite) Test_Discover() {
+	discoverAddress := &model.FeatureAddressType{Device: util.Ptr(model.AddressDeviceType("Node"))}
+	s.handlerMock.EXPECT().Discover("ski", model.NetworkManagementDiscoverCallType{DiscoverAddress: discoverAddress}).Return(nil).Once()
+
+	err := s.call(model.CmdType{
+		NetworkManagementDiscoverCall: &model.NetworkManagementDiscoverCallType{DiscoverAddress: discoverAddress},
+	})
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), util.Ptr(model.FunctionTypeNetworkManagementDiscoverCall), s.sut.RunningProcess())
+
+	s.expectProcessState(model.NetworkManagementProcessStateStateTypeSucceeded)
+	assert.Nil(s.T(), s.sut.FinishProcess(model.NetworkManagementProcessStateStateTypeSucceeded, ""))
+}
+
+func (s *NetworkManagementSuite) Test_JoiningMode() {
+	data := model.NetworkManagementJoiningModeDataType{
+		Setup: util.Ptr(model.NetworkManagementSetupType("setup")),
+	}
+
+	// subscribers are notified about the joining mode
+	s.senderMock.EXPECT().Notify(s.sut.Address(), s.clientFeature.Address(), mock.Anything).
+		Run(func(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+			assert.Equal(s.T(), &data, cmd[0].NetworkManagementJoiningModeData)
+		}).Return(nil, nil).Once()
+	s.sut.SetJoiningMode(data)
+
+	s.senderMock.EXPECT().Reply(mock.Anything, s.sut.Address(), mock.Anything).
+		Run(func(requestHeader *model.HeaderType, senderAddress *model.FeatureAddressType, cmd model.CmdType) {
+			assert.Equal(s.T(), &data, cmd.NetworkManagementJoiningModeData)
+		}).Return(nil).Once()
+
+	err := s.sut.HandleMessage(&api.Message{
+		Cmd: model.CmdType{
+			NetworkManagementJoiningModeData: &model.NetworkManagementJoiningModeDataType{},
+		},
+		CmdClassifier: model.CmdClassifierTypeRead,
+		RequestHeader: &model.HeaderType{MsgCounter: util.Ptr(model.MsgCounterType(1))},
+		FeatureRemote: s.clientFeature,
+	})
+	assert.Nil(s.T(), err)
+}
+
+func (s *NetworkManagementSuite) Test_UnsupportedCall() {
+	err := s.call(model.CmdType{
+		NetworkManagementModifyNodeCall: &model.NetworkManagementModifyNodeCallType{},
+	})
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandNotSupported, err.ErrorNumber)
+	}
+}
+
+func (s *NetworkManagementSuite) Test_AccessControl() {
+	acl := NewAccessControlList(true)
+	acl.AddRule(AccessRule{
+		Function:   util.Ptr(model.FunctionTypeNetworkManagementAddNodeCall),
+		Operations: []api.AccessOperationType{api.AccessOperationCall},
+		Allow:      false,
+	})
+	s.localDevice.SetAccessControl(acl)
+
+	datagram := model.DatagramType{
+		Header: model.HeaderType{
+			AddressSource:      s.clientFeature.Address(),
+			AddressDestination: s.sut.Address(),
+			MsgCounter:         util.Ptr(model.MsgCounterType(1)),
+			CmdClassifier:      util.Ptr(model.CmdClassifierTypeCall),
+		},
+		Payload: model.PayloadType{
+			Cmd: []model.CmdType{{
+				NetworkManagementAddNodeCall: &model.NetworkManagementAddNodeCallType{},
+			}},
+		},
+	}
+
+	// the handler is not invoked for a denied call
+	s.senderMock.EXPECT().ResultError(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(requestHeader *model.HeaderType, senderAddress *model.FeatureAddressType, err *model.ErrorType) {
+			assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+		}).Return(nil).Once()
+	err := s.localDevice.ProcessCmd(datagram, s.clientFeature.Device())
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), s.sut.RunningProcess())
+}
+
+func (s *NetworkManagementSuite) Test_NoHandler() {
+	s.sut.handler = nil
+
+	err := s.call(model.CmdType{
+		NetworkManagementAddNodeCall: &model.NetworkManagementAddNodeCallType{},
+	})
+	if assert.NotNil(s.T(), err) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandNotSupported, err.ErrorNumber)
+	}
+	assert.Nil(s.T(), s.sut.RunningProcess())
+}
@@ -67,7 +67,7 @@ func (r *NodeManagement) HandleMessage(message *api.Message) *model.ErrorType {
 
 	case message.Cmd.NodeManagementSubscriptionRequestCall != nil:
 		if err := r.handleMsgSubscriptionRequestCall(message, message.Cmd.NodeManagementSubscriptionRequestCall); err != nil {
-			return model.NewErrorType(model.ErrorNumberTypeGeneralError, err.Error())
+			return errorTypeForRequest(err)
 		}
 
 	case message.Cmd.NodeManagementSubscriptionDeleteCall != nil:
@@ -82,7 +82,7 @@ func (r *NodeManagement) HandleMessage(message *api.Message) *model.ErrorType {
 
 	case message.Cmd.NodeManagementBindingRequestCall != nil:
 		if err := r.handleMsgBindingRequestCall(message, message.Cmd.NodeManagementBindingRequestCall); err != nil {
-			return model.NewErrorType(model.ErrorNumberTypeGeneralError, err.Error())
+			return errorTypeForRequest(err)
 		}
 
 	case message.Cmd.NodeManagementBindingDeleteCall != nil:
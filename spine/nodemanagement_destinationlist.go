@@ -9,26 +9,30 @@ import (
 )
 
 func (r *NodeManagement) RequestDestinationListData(remoteDeviceAddress *model.AddressDeviceType, sender api.SenderInterface) (*model.MsgCounterType, *model.ErrorType) {
-	return nil, model.NewErrorTypeFromString("Not implemented")
+	rfAddress := featureAddressType(NodeManagementFeatureId, EntityAddressType(remoteDeviceAddress, DeviceInformationAddressEntity))
+	cmd := model.CmdType{
+		NodeManagementDestinationListData: &model.NodeManagementDestinationListDataType{},
+	}
+	return r.RequestRemoteDataBySenderAddress(cmd, sender, "", rfAddress, defaultMaxResponseDelay)
 }
 
 func (r *NodeManagement) processReadDestinationListData(featureRemote api.FeatureRemoteInterface, requestHeader *model.HeaderType) error {
-	data := []model.NodeManagementDestinationDataType{
-		r.Device().DestinationData(),
-	}
-	// add other remote devices here
-
-	cmd := model.CmdType{
-		NodeManagementDestinationListData: &model.NodeManagementDestinationListDataType{
-			NodeManagementDestinationData: data,
-		},
+	fd := r.functionData(model.FunctionTypeNodeManagementDestinationListData)
+	if fd == nil {
+		return errors.New("function data not found")
 	}
+	cmd := fd.ReplyCmdType(false)
 
 	return featureRemote.Device().Sender().Reply(requestHeader, r.Address(), cmd)
 }
 
-func (r *NodeManagement) processReplyDestinationListData(_ *api.Message, _ model.NodeManagementDestinationListDataType) error {
-	return errors.New("Not implemented")
+func (r *NodeManagement) processReplyDestinationListData(message *api.Message, data model.NodeManagementDestinationListDataType) error {
+	_, err := message.FeatureRemote.UpdateData(true, model.FunctionTypeNodeManagementDestinationListData, &data, nil, nil)
+	if err != nil {
+		return errors.New(err.String())
+	}
+
+	return nil
 }
 
 func (r *NodeManagement) handleMsgDestinationListData(message *api.Message, data *model.NodeManagementDestinationListDataType) error {
@@ -53,10 +53,10 @@ func (r *NodeManagement) processReadDetailedDiscoveryData(deviceRemote api.Devic
 func (r *NodeManagement) processReplyDetailedDiscoveryData(message *api.Message, data *model.NodeManagementDetailedDiscoveryDataType) error {
 	remoteDevice := message.DeviceRemote
 
-	deviceDescription := data.DeviceInformation.Description
-	if deviceDescription == nil {
+	if data.DeviceInformation == nil || data.DeviceInformation.Description == nil {
 		return errors.New("nodemanagement.replyDetailedDiscoveryData: invalid DeviceInformation.Description")
 	}
+	deviceDescription := data.DeviceInformation.Description
 
 	remoteDevice.UpdateDevice(deviceDescription)
 	entities, err := remoteDevice.AddEntityAndFeatures(true, data)
@@ -73,7 +73,7 @@ func (r *NodeManagement) processReplyDetailedDiscoveryData(message *api.Message,
 		Feature:    message.FeatureRemote,
 		Data:       data,
 	}
-	Events.Publish(payload)
+	r.Device().Events().Publish(payload)
 
 	// publish event for each added remote entity
 	for _, entity := range entities {
@@ -85,7 +85,7 @@ func (r *NodeManagement) processReplyDetailedDiscoveryData(message *api.Message,
 			Entity:     entity,
 			Data:       data,
 		}
-		Events.Publish(payload)
+		r.Device().Events().Publish(payload)
 	}
 
 	return nil
@@ -177,6 +177,10 @@ func (r *NodeManagement) provideDetailedDiscoveryDiffForFullNotify(message *api.
 func (r *NodeManagement) processNotifyDetailedDiscoveryData(message *api.Message, data *model.NodeManagementDetailedDiscoveryDataType) error {
 	// is this a partial request?
 	if message.FilterPartial == nil {
+		if !remoteDeviceHasQuirk(message.FeatureRemote.Device(), api.QuirkDetailedDiscoveryFullNotify) {
+			return errors.New("nodemanagement.notifyDetailedDiscoveryData: full notify is not supported")
+		}
+
 		data = r.provideDetailedDiscoveryDiffForFullNotify(message, data)
 	}
 
@@ -240,7 +244,7 @@ func (r *NodeManagement) processNotifyDetailedDiscoveryData(message *api.Message
 					Entity:     entity,
 					Data:       data,
 				}
-				Events.Publish(payload)
+				r.Device().Events().Publish(payload)
 			}
 		}
 
@@ -282,7 +286,7 @@ func (r *NodeManagement) processNotifyDetailedDiscoveryData(message *api.Message
 					Entity:     removedEntity,
 					Data:       data,
 				}
-				Events.Publish(payload)
+				r.Device().Events().Publish(payload)
 
 				// remove all subscriptions for this entity
 				subscriptionMgr := r.Device().SubscriptionManager()
@@ -1,6 +1,7 @@
 package spine
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -156,3 +157,44 @@ func TestNodemanagement_SubscriptionCalls(t *testing.T) {
 		assert.Nil(t, err)
 	}
 }
+
+func TestNodemanagement_SubscriptionCalls_Rejected(t *testing.T) {
+	const subscriptionEntityId uint = 1
+	const featureType = model.FeatureTypeTypeDeviceDiagnosis
+
+	senderMock := mocks.NewSenderInterface(t)
+
+	localDevice, localEntity := createLocalDeviceAndEntity(subscriptionEntityId)
+	_, serverFeature := createLocalFeatures(localEntity, featureType, "")
+
+	remoteDevice := createRemoteDevice(localDevice, "ski", senderMock)
+	clientFeature, _ := createRemoteEntityAndFeature(remoteDevice, subscriptionEntityId, featureType, "")
+
+	var authorizedSki string
+	localDevice.SubscriptionManager().AddAuthorizationCallback(
+		func(ski string, client api.FeatureRemoteInterface, server api.FeatureLocalInterface) error {
+			authorizedSki = ski
+			assert.Equal(t, clientFeature, client)
+			assert.Equal(t, serverFeature, server)
+			return errors.New("not allowed")
+		})
+
+	requestMsg := api.Message{
+		Cmd: model.CmdType{
+			NodeManagementSubscriptionRequestCall: NewNodeManagementSubscriptionRequestCallType(
+				clientFeature.Address(), serverFeature.Address(), featureType),
+		},
+		CmdClassifier: model.CmdClassifierTypeCall,
+		FeatureRemote: clientFeature,
+	}
+
+	sut := NewNodeManagement(0, serverFeature.Entity())
+
+	// Act
+	err := sut.HandleMessage(&requestMsg)
+	if assert.NotNil(t, err) {
+		assert.Equal(t, model.ErrorNumberTypeCommandRejected, err.ErrorNumber)
+	}
+	assert.Equal(t, "ski", authorizedSki)
+	assert.Equal(t, 0, len(localDevice.SubscriptionManager().Subscriptions(remoteDevice)))
+}
@@ -0,0 +1,251 @@
+package spine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/enbility/ship-go/logging"
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// notifies of these functions are sent immediately by default
+var defaultNotifyPriorityFunctions = []model.FunctionType{
+	model.FunctionTypeLoadControlLimitListData,
+	model.FunctionTypeDeviceDiagnosisHeartbeatData,
+}
+
+// notifies which are waiting for the rate limit of a subscription and function
+type pendingNotify struct {
+	subscription *api.SubscriptionEntry
+	function     model.FunctionType
+	// every entry contains the cmds of one NotifySubscribers call
+	cmds  [][]model.CmdType
+	timer *time.Timer
+}
+
+// Coalesces and rate limits the notifies sent to subscribers
+type notifyLimiter struct {
+	device *DeviceLocal
+
+	defaultLimit      api.NotifyRateLimit
+	featureLimits     map[string]api.NotifyRateLimit
+	priorityFunctions []model.FunctionType
+
+	pending  map[string]*pendingNotify
+	lastSent map[string]time.Time
+	// timers of pending notifies which are armed or running
+	timers inFlightCounter
+	// once stopped, all notifies are sent immediately
+	stopped bool
+
+	mux sync.Mutex
+}
+
+func newNotifyLimiter(device *DeviceLocal) *notifyLimiter {
+	return &notifyLimiter{
+		device:            device,
+		featureLimits:     make(map[string]api.NotifyRateLimit),
+		priorityFunctions: slices.Clone(defaultNotifyPriorityFunctions),
+		pending:           make(map[string]*pendingNotify),
+		lastSent:          make(map[string]time.Time),
+	}
+}
+
+func (n *notifyLimiter) setRateLimit(featureAddress *model.FeatureAddressType, limit api.NotifyRateLimit) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	if featureAddress == nil {
+		n.defaultLimit = limit
+		return
+	}
+
+	n.featureLimits[featureAddress.String()] = limit
+}
+
+func (n *notifyLimiter) setPriorityFunctions(functions []model.FunctionType) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	n.priorityFunctions = slices.Clone(functions)
+}
+
+// has to be called with locked mux
+func (n *notifyLimiter) rateLimit(featureAddress *model.FeatureAddressType, function model.FunctionType) api.NotifyRateLimit {
+	if slices.Contains(n.priorityFunctions, function) {
+		return api.NotifyRateLimit{}
+	}
+
+	if limit, ok := n.featureLimits[featureAddress.String()]; ok {
+		return limit
+	}
+
+	return n.defaultLimit
+}
+
+// the key starts with the SKI of the subscriber, so all entries of a remote device can be found
+func notifyKey(subscription *api.SubscriptionEntry, function model.FunctionType) string {
+	return fmt.Sprintf("%s|%s|%s|%s",
+		subscription.ClientFeature.Device().Ski(),
+		subscription.ServerFeature.Address(),
+		subscription.ClientFeature.Address(),
+		function)
+}
+
+// Send the cmds to the subscriber, or delay them if the rate limit requires it
+func (n *notifyLimiter) notify(subscription *api.SubscriptionEntry, function model.FunctionType, cmds []model.CmdType) {
+	key := notifyKey(subscription, function)
+
+	n.mux.Lock()
+
+	// keep the order of the notifies, even if the rate limit changed in the meantime
+	if pending, ok := n.pending[key]; ok {
+		pending.cmds = append(pending.cmds, cmds)
+		n.mux.Unlock()
+		return
+	}
+
+	limit := n.rateLimit(subscription.ServerFeature.Address(), function)
+
+	now := time.Now()
+	delay := limit.CoalescingWindow
+	if lastSent, ok := n.lastSent[key]; ok && limit.MinInterval > 0 {
+		delay = max(delay, lastSent.Add(limit.MinInterval).Sub(now))
+	}
+
+	if delay <= 0 || n.stopped {
+		if limit.MinInterval > 0 {
+			n.lastSent[key] = now
+		}
+		n.mux.Unlock()
+
+		n.send(subscription, cmds)
+		return
+	}
+
+	n.timers.add()
+	n.pending[key] = &pendingNotify{
+		subscription: subscription,
+		function:     function,
+		cmds:         [][]model.CmdType{cmds},
+		timer: time.AfterFunc(delay, func() {
+			defer n.timers.done()
+			n.flush(key)
+		}),
+	}
+
+	n.mux.Unlock()
+}
+
+// Send the pending notifies of a subscription and function as a single notify
+func (n *notifyLimiter) flush(key string) {
+	n.mux.Lock()
+	pending, ok := n.pending[key]
+	if !ok {
+		n.mux.Unlock()
+		return
+	}
+	delete(n.pending, key)
+	n.lastSent[key] = time.Now()
+	n.mux.Unlock()
+
+	// the subscription may have been removed in the meantime
+	subscriptions := n.device.SubscriptionManager().SubscriptionsOnFeature(*pending.subscription.ServerFeature.Address())
+	if !slices.ContainsFunc(subscriptions, func(item *api.SubscriptionEntry) bool {
+		return item.Id == pending.subscription.Id
+	}) {
+		return
+	}
+
+	n.send(pending.subscription, n.mergeCmds(pending))
+}
+
+// implemented by local features providing a full notify of the current data of a function,
+// including all types embedding *FeatureLocal
+type notifyCmdProvider interface {
+	notifyCmdType(function model.FunctionType) (model.CmdType, bool)
+}
+
+// Merge the cmds of multiple updates into the cmds of a single notify
+//
+// multiple updates are replaced by a full notify of the current data,
+// if the data of the local feature is not available, all cmds are sent in order
+func (n *notifyLimiter) mergeCmds(pending *pendingNotify) []model.CmdType {
+	if len(pending.cmds) == 1 {
+		return pending.cmds[0]
+	}
+
+	if feature, ok := pending.subscription.ServerFeature.(notifyCmdProvider); ok {
+		if cmd, ok := feature.notifyCmdType(pending.function); ok {
+			return []model.CmdType{cmd}
+		}
+	}
+
+	var result []model.CmdType
+	for _, cmds := range pending.cmds {
+		result = append(result, cmds...)
+	}
+
+	return result
+}
+
+func (n *notifyLimiter) send(subscription *api.SubscriptionEntry, cmds []model.CmdType) {
+	if _, err := subscription.ClientFeature.Device().Sender().Notify(subscription.ServerFeature.Address(), subscription.ClientFeature.Address(), cmds...); err != nil {
+		logging.Log().Debug("sending rate limited notify failed:", err)
+	}
+}
+
+// Drop all pending notifies and send times of a remote device
+func (n *notifyLimiter) removeRemoteDevice(ski string) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	prefix := ski + "|"
+
+	for key, pending := range n.pending {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		if pending.timer.Stop() {
+			n.timers.done()
+		}
+		delete(n.pending, key)
+	}
+
+	for key := range n.lastSent {
+		if strings.HasPrefix(key, prefix) {
+			delete(n.lastSent, key)
+		}
+	}
+}
+
+// Stop all timers and send the pending notifies immediately, as the device is shut down
+//
+// Timers which already fired may still be sending, use wait to wait for them
+func (n *notifyLimiter) stop() {
+	n.mux.Lock()
+	n.stopped = true
+	var keys []string
+	for key, pending := range n.pending {
+		if pending.timer.Stop() {
+			n.timers.done()
+			keys = append(keys, key)
+		}
+	}
+	n.mux.Unlock()
+
+	for _, key := range keys {
+		n.flush(key)
+	}
+}
+
+// wait until no timer is armed or running anymore or the context is done
+func (n *notifyLimiter) wait(ctx context.Context) error {
+	return n.timers.wait(ctx)
+}
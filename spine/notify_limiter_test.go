@@ -0,0 +1,190 @@
+package spine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/mocks"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNotifyLimiterSuite(t *testing.T) {
+	suite.Run(t, new(NotifyLimiterSuite))
+}
+
+type NotifyLimiterSuite struct {
+	suite.Suite
+
+	localDevice   *DeviceLocal
+	serverFeature api.FeatureLocalInterface
+	remoteDevice  *DeviceRemote
+	senderMock    *mocks.SenderInterface
+
+	notifies [][]model.CmdType
+	mux      sync.Mutex
+}
+
+func (s *NotifyLimiterSuite) BeforeTest(suiteName, testName string) {
+	s.notifies = nil
+
+	var localEntity *EntityLocal
+	s.localDevice, localEntity = createLocalDeviceAndEntity(1)
+	_, s.serverFeature = createLocalFeatures(localEntity, model.FeatureTypeTypeMeasurement, "")
+	s.serverFeature.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+	s.senderMock = mocks.NewSenderInterface(s.T())
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything).
+		Run(s.recordNotify).Return(util.Ptr(model.MsgCounterType(1)), nil).Maybe()
+	s.senderMock.EXPECT().Notify(mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(s.recordNotify).Return(util.Ptr(model.MsgCounterType(1)), nil).Maybe()
+
+	s.remoteDevice = createRemoteDevice(s.localDevice, "ski", s.senderMock)
+	remoteFeature, _ := createRemoteEntityAndFeature(s.remoteDevice, 1, model.FeatureTypeTypeMeasurement, model.FunctionTypeMeasurementListData)
+	s.localDevice.AddRemoteDeviceForSki(s.remoteDevice.Ski(), s.remoteDevice)
+
+	err := s.localDevice.SubscriptionManager().AddSubscription(s.remoteDevice, model.SubscriptionManagementRequestCallType{
+		ClientAddress:     remoteFeature.Address(),
+		ServerAddress:     s.serverFeature.Address(),
+		ServerFeatureType: util.Ptr(model.FeatureTypeTypeMeasurement),
+	})
+	assert.Nil(s.T(), err)
+}
+
+func (s *NotifyLimiterSuite) recordNotify(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.notifies = append(s.notifies, cmd)
+}
+
+func (s *NotifyLimiterSuite) notifyCount() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return len(s.notifies)
+}
+
+func (s *NotifyLimiterSuite) lastNotify() []model.CmdType {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.notifies[len(s.notifies)-1]
+}
+
+// set the values of the measurements with the ids 1, 2, ...
+func (s *NotifyLimiterSuite) setValues(values ...int64) {
+	data := &model.MeasurementListDataType{}
+	for i, value := range values {
+		data.MeasurementData = append(data.MeasurementData, model.MeasurementDataType{
+			MeasurementId: util.Ptr(model.MeasurementIdType(i + 1)),
+			ValueType:     util.Ptr(model.MeasurementValueTypeTypeValue),
+			Value:         model.NewScaledNumberType(float64(value)),
+		})
+	}
+
+	s.serverFeature.SetData(model.FunctionTypeMeasurementListData, data)
+}
+
+func (s *NotifyLimiterSuite) Test_NoRateLimit() {
+	s.setValues(1)
+	s.setValues(2)
+
+	assert.Equal(s.T(), 2, s.notifyCount())
+}
+
+func (s *NotifyLimiterSuite) Test_CoalescingWindow() {
+	s.localDevice.SetNotifyRateLimit(nil, api.NotifyRateLimit{
+		CoalescingWindow: time.Millisecond * 100,
+	})
+
+	s.setValues(1)
+	s.setValues(1, 2)
+	s.setValues(3, 2)
+	assert.Equal(s.T(), 0, s.notifyCount())
+
+	assert.Eventually(s.T(), func() bool { return s.notifyCount() == 1 }, time.Second, time.Millisecond*10)
+
+	// multiple updates are merged into a full notify of the current data
+	cmds := s.lastNotify()
+	if assert.Equal(s.T(), 1, len(cmds)) {
+		assert.Nil(s.T(), cmds[0].Filter)
+		data := cmds[0].MeasurementListData.MeasurementData
+		if assert.Equal(s.T(), 2, len(data)) {
+			assert.Equal(s.T(), 3.0, data[0].Value.GetValue())
+			assert.Equal(s.T(), 2.0, data[1].Value.GetValue())
+		}
+	}
+}
+
+// a local feature type embedding *FeatureLocal, like the NodeManagement
+type embeddingFeatureLocal struct {
+	*FeatureLocal
+}
+
+func (s *NotifyLimiterSuite) Test_MergeCmds_EmbeddedFeature() {
+	s.setValues(3, 2)
+
+	subscription := &api.SubscriptionEntry{
+		ServerFeature: &embeddingFeatureLocal{s.serverFeature.(*FeatureLocal)},
+	}
+	pending := &pendingNotify{
+		subscription: subscription,
+		function:     model.FunctionTypeMeasurementListData,
+		cmds:         [][]model.CmdType{{{}}, {{}}},
+	}
+
+	// the full data of the feature is used instead of the single cmds
+	cmds := s.localDevice.notifyLimiter.mergeCmds(pending)
+	if assert.Equal(s.T(), 1, len(cmds)) && assert.NotNil(s.T(), cmds[0].MeasurementListData) {
+		assert.Equal(s.T(), 2, len(cmds[0].MeasurementListData.MeasurementData))
+	}
+}
+
+func (s *NotifyLimiterSuite) Test_MinInterval() {
+	s.localDevice.SetNotifyRateLimit(s.serverFeature.Address(), api.NotifyRateLimit{
+		MinInterval: time.Millisecond * 200,
+	})
+
+	// the first notify is sent immediately
+	s.setValues(1)
+	assert.Equal(s.T(), 1, s.notifyCount())
+
+	// a single update within the interval is sent unchanged afterwards
+	s.setValues(2)
+	assert.Equal(s.T(), 1, s.notifyCount())
+
+	assert.Eventually(s.T(), func() bool { return s.notifyCount() == 2 }, time.Second, time.Millisecond*10)
+	cmds := s.lastNotify()
+	if assert.Equal(s.T(), 1, len(cmds)) {
+		assert.Equal(s.T(), filterEmptyPartial(), cmds[0].Filter)
+	}
+}
+
+func (s *NotifyLimiterSuite) Test_PriorityFunction() {
+	s.localDevice.SetNotifyRateLimit(nil, api.NotifyRateLimit{
+		CoalescingWindow: time.Second,
+	})
+	s.localDevice.SetNotifyPriorityFunctions(model.FunctionTypeMeasurementListData)
+
+	s.setValues(1)
+	s.setValues(2)
+	assert.Equal(s.T(), 2, s.notifyCount())
+}
+
+func (s *NotifyLimiterSuite) Test_RemoveRemoteDevice() {
+	s.localDevice.SetNotifyRateLimit(nil, api.NotifyRateLimit{
+		CoalescingWindow: time.Millisecond * 50,
+	})
+
+	s.setValues(1)
+	s.localDevice.RemoveRemoteDevice(s.remoteDevice.Ski())
+
+	time.Sleep(time.Millisecond * 150)
+	assert.Equal(s.T(), 0, s.notifyCount())
+}
@@ -0,0 +1,204 @@
+package spine
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// an outbound entry and the address of the remote feature it was last established with
+type outboundEntry struct {
+	api.OutboundEntry
+
+	remoteAddress *model.FeatureAddressType
+}
+
+// Remembers the subscriptions and bindings of local client features to remote features per SKI,
+// so they can be re-established after a reconnect
+//
+// Entries are only removed if the subscription or binding is removed by the application,
+// not if the remote device disconnects
+type outboundEntries struct {
+	device *DeviceLocal
+
+	entries map[string][]*outboundEntry
+
+	mux sync.Mutex
+}
+
+func newOutboundEntries(device *DeviceLocal) *outboundEntries {
+	return &outboundEntries{
+		device:  device,
+		entries: make(map[string][]*outboundEntry),
+	}
+}
+
+// remember a subscription or binding which was requested for a remote feature
+func (o *outboundEntries) add(entryType api.OutboundEntryType, localFeature api.FeatureLocalInterface, remoteFeature api.FeatureRemoteInterface) {
+	if localFeature == nil || remoteFeature == nil || remoteFeature.Entity() == nil {
+		return
+	}
+
+	// the subscription of the node management is established by the stack after every detailed discovery
+	if localFeature.Type() == model.FeatureTypeTypeNodeManagement {
+		return
+	}
+
+	ski := remoteFeature.Device().Ski()
+	entry := api.OutboundEntry{
+		Type:          entryType,
+		LocalFeature:  localFeature.Address(),
+		EntityAddress: remoteFeature.Address().Entity,
+		EntityType:    remoteFeature.Entity().EntityType(),
+		FeatureType:   remoteFeature.Type(),
+		Role:          remoteFeature.Role(),
+	}
+
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	for _, item := range o.entries[ski] {
+		if reflect.DeepEqual(item.OutboundEntry, entry) {
+			item.remoteAddress = remoteFeature.Address()
+			return
+		}
+	}
+
+	o.entries[ski] = append(o.entries[ski], &outboundEntry{
+		OutboundEntry: entry,
+		remoteAddress: remoteFeature.Address(),
+	})
+}
+
+// forget a subscription or binding which was removed by the application
+func (o *outboundEntries) remove(entryType api.OutboundEntryType, localFeature api.FeatureLocalInterface, remoteAddress *model.FeatureAddressType) {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	for ski, entries := range o.entries {
+		var remaining []*outboundEntry
+		for _, item := range entries {
+			if item.Type == entryType &&
+				reflect.DeepEqual(item.LocalFeature, localFeature.Address()) &&
+				reflect.DeepEqual(item.remoteAddress, remoteAddress) {
+				continue
+			}
+			remaining = append(remaining, item)
+		}
+
+		if len(remaining) == 0 {
+			delete(o.entries, ski)
+			continue
+		}
+		o.entries[ski] = remaining
+	}
+}
+
+// return the remembered entries of a remote device
+func (o *outboundEntries) entriesForSki(ski string) []api.OutboundEntry {
+	o.mux.Lock()
+	defer o.mux.Unlock()
+
+	var res []api.OutboundEntry
+	for _, item := range o.entries[ski] {
+		res = append(res, item.OutboundEntry)
+	}
+
+	return res
+}
+
+// re-establish the remembered subscriptions and bindings with a reconnected remote device
+//
+// publishes an EventTypeOutboundRestoreFailed event with all entries which could not be restored
+func (o *outboundEntries) restore(remoteDevice api.DeviceRemoteInterface) {
+	var failures []api.OutboundEntryRestoreFailure
+
+	for _, entry := range o.entriesForSki(remoteDevice.Ski()) {
+		// the remaining entries are not restored, as the device is shut down
+		if o.device.shutdownStarted() {
+			return
+		}
+
+		if err := o.restoreEntry(remoteDevice, entry); err != nil {
+			failures = append(failures, api.OutboundEntryRestoreFailure{
+				Entry: entry,
+				Error: err,
+			})
+		}
+	}
+
+	if len(failures) == 0 {
+		return
+	}
+
+	payload := api.EventPayload{
+		Ski:        remoteDevice.Ski(),
+		EventType:  api.EventTypeOutboundRestoreFailed,
+		ChangeType: api.ElementChangeUpdate,
+		Device:     remoteDevice,
+		Data:       failures,
+	}
+	o.device.Events().Publish(payload)
+}
+
+func (o *outboundEntries) restoreEntry(remoteDevice api.DeviceRemoteInterface, entry api.OutboundEntry) *model.ErrorType {
+	localFeature := o.device.FeatureByAddress(entry.LocalFeature)
+	if localFeature == nil {
+		return model.NewErrorTypeFromString("local feature not found")
+	}
+
+	remoteFeature := remoteFeatureForEntry(remoteDevice, entry)
+	if remoteFeature == nil {
+		return model.NewErrorType(model.ErrorNumberTypeDestinationUnknown, "remote feature not found")
+	}
+
+	var err *model.ErrorType
+	switch entry.Type {
+	case api.OutboundEntryTypeBinding:
+		if !localFeature.HasBindingToRemote(remoteFeature.Address()) {
+			_, err = localFeature.BindToRemote(remoteFeature.Address())
+		}
+	default:
+		if !localFeature.HasSubscriptionToRemote(remoteFeature.Address()) {
+			_, err = localFeature.SubscribeToRemote(remoteFeature.Address())
+		}
+	}
+
+	return err
+}
+
+// find the remote feature of an entry on the entity with the stored address,
+// or on the only entity of the entity type if no entity with the address exists anymore
+func remoteFeatureForEntry(remoteDevice api.DeviceRemoteInterface, entry api.OutboundEntry) api.FeatureRemoteInterface {
+	var candidates []api.EntityRemoteInterface
+	for _, entity := range remoteDevice.Entities() {
+		if entity.EntityType() != entry.EntityType {
+			continue
+		}
+
+		if reflect.DeepEqual(entity.Address().Entity, entry.EntityAddress) {
+			return entityFeatureForEntry(entity, entry)
+		}
+		candidates = append(candidates, entity)
+	}
+
+	// with several entities of the type it is unknown which one the entry belongs to
+	if len(candidates) != 1 {
+		return nil
+	}
+
+	return entityFeatureForEntry(candidates[0], entry)
+}
+
+// find the first feature of a remote entity matching the feature type and role of an entry
+func entityFeatureForEntry(entity api.EntityRemoteInterface, entry api.OutboundEntry) api.FeatureRemoteInterface {
+	for _, feature := range entity.Features() {
+		if feature.Type() == entry.FeatureType && feature.Role() == entry.Role {
+			return feature
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package spine
+
+import (
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboundEntries_RemoteFeatureForEntry(t *testing.T) {
+	localDevice, _ := createLocalDeviceAndEntity(1)
+	remoteDevice := createRemoteDevice(localDevice, "ski", nil)
+
+	_, serverFeature1 := createRemoteEntityAndFeature(remoteDevice, 1, model.FeatureTypeTypeLoadControl, model.FunctionTypeLoadControlLimitListData)
+
+	entry := api.OutboundEntry{
+		Type:          api.OutboundEntryTypeSubscription,
+		EntityAddress: []model.AddressEntityType{2},
+		EntityType:    model.EntityTypeTypeEVSE,
+		FeatureType:   model.FeatureTypeTypeLoadControl,
+		Role:          model.RoleTypeServer,
+	}
+
+	// the only entity of the type is used if the stored entity does not exist anymore
+	assert.Equal(t, serverFeature1, remoteFeatureForEntry(remoteDevice, entry))
+
+	_, serverFeature2 := createRemoteEntityAndFeature(remoteDevice, 2, model.FeatureTypeTypeLoadControl, model.FunctionTypeLoadControlLimitListData)
+
+	// the entity with the stored address is used, not the first one of the type
+	assert.Equal(t, serverFeature2, remoteFeatureForEntry(remoteDevice, entry))
+
+	entry.EntityAddress = []model.AddressEntityType{1}
+	assert.Equal(t, serverFeature1, remoteFeatureForEntry(remoteDevice, entry))
+
+	// with several entities of the type, an unknown entity address can not be resolved
+	entry.EntityAddress = []model.AddressEntityType{3}
+	assert.Nil(t, remoteFeatureForEntry(remoteDevice, entry))
+
+	entry.FeatureType = model.FeatureTypeTypeMeasurement
+	entry.EntityAddress = []model.AddressEntityType{1}
+	assert.Nil(t, remoteFeatureForEntry(remoteDevice, entry))
+}
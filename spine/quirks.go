@@ -0,0 +1,139 @@
+package spine
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// the quirks enabled for all remote devices by default, the workarounds the stack always used
+var defaultQuirks = []api.QuirkType{
+	api.QuirkHeartbeatEarly,
+	api.QuirkGenericFeatureFunctions,
+	api.QuirkDetailedDiscoveryFullNotify,
+}
+
+// the known devices requiring the built-in quirks
+var defaultQuirkRules = []api.QuirkRule{
+	{BrandName: "Elli", Quirks: []api.QuirkType{api.QuirkHeartbeatEarly, api.QuirkGenericFeatureFunctions}},
+	{BrandName: "Vaillant", Quirks: []api.QuirkType{api.QuirkGenericFeatureFunctions}},
+	{BrandName: "SMA", Quirks: []api.QuirkType{api.QuirkGenericFeatureFunctions}},
+	{BrandName: "Porsche", Quirks: []api.QuirkType{api.QuirkDetailedDiscoveryFullNotify}},
+}
+
+type QuirksRegistry struct {
+	defaultQuirks []api.QuirkType
+	rules         []api.QuirkRule
+
+	mux sync.Mutex
+}
+
+var _ api.QuirksRegistryInterface = (*QuirksRegistry)(nil)
+
+// Create a registry with the built-in quirks enabled for all remote devices,
+// and rules enabling them for the known devices requiring them once the defaults are changed
+func NewQuirksRegistry() *QuirksRegistry {
+	return &QuirksRegistry{
+		defaultQuirks: slices.Clone(defaultQuirks),
+		rules:         slices.Clone(defaultQuirkRules),
+	}
+}
+
+/* QuirksRegistryInterface */
+
+func (r *QuirksRegistry) AddRule(rule api.QuirkRule) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.rules = append(r.rules, rule)
+}
+
+func (r *QuirksRegistry) SetDefaultQuirks(quirks ...api.QuirkType) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.defaultQuirks = slices.Clone(quirks)
+}
+
+func (r *QuirksRegistry) HasQuirk(remoteDevice api.DeviceRemoteInterface, quirk api.QuirkType) bool {
+	r.mux.Lock()
+	defaults := slices.Clone(r.defaultQuirks)
+	rules := slices.Clone(r.rules)
+	r.mux.Unlock()
+
+	if slices.Contains(defaults, quirk) {
+		return true
+	}
+
+	if remoteDevice == nil {
+		return false
+	}
+
+	manufacturerData := remoteManufacturerData(remoteDevice)
+
+	for _, rule := range rules {
+		if slices.Contains(rule.Quirks, quirk) && quirkRuleMatches(rule, remoteDevice.Ski(), manufacturerData) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// a rule matches if all its set fields match, rules without any set field never match
+func quirkRuleMatches(rule api.QuirkRule, ski string, data *model.DeviceClassificationManufacturerDataType) bool {
+	if len(rule.Ski) == 0 && len(rule.BrandName) == 0 && len(rule.DeviceModel) == 0 {
+		return false
+	}
+
+	if len(rule.Ski) > 0 && rule.Ski != ski {
+		return false
+	}
+
+	if len(rule.BrandName) > 0 &&
+		(data == nil || (!classificationStringMatches(data.BrandName, rule.BrandName) &&
+			!classificationStringMatches(data.VendorName, rule.BrandName))) {
+		return false
+	}
+
+	if len(rule.DeviceModel) > 0 &&
+		(data == nil || !classificationStringMatches(data.DeviceName, rule.DeviceModel)) {
+		return false
+	}
+
+	return true
+}
+
+func classificationStringMatches(value *model.DeviceClassificationStringType, expected string) bool {
+	return value != nil && strings.EqualFold(string(*value), expected)
+}
+
+// return the manufacturer data of the first DeviceClassification server feature of a remote device,
+// which has the data available
+func remoteManufacturerData(remoteDevice api.DeviceRemoteInterface) *model.DeviceClassificationManufacturerDataType {
+	for _, entity := range remoteDevice.Entities() {
+		feature := remoteDevice.FeatureByEntityTypeAndRole(entity, model.FeatureTypeTypeDeviceClassification, model.RoleTypeServer)
+		if feature == nil {
+			continue
+		}
+
+		data, ok := feature.DataCopy(model.FunctionTypeDeviceClassificationManufacturerData).(*model.DeviceClassificationManufacturerDataType)
+		if ok && data != nil {
+			return data
+		}
+	}
+
+	return nil
+}
+
+// check if a quirk is enabled for a remote device, using the registry of its local device
+func remoteDeviceHasQuirk(remoteDevice api.DeviceRemoteInterface, quirk api.QuirkType) bool {
+	if device, ok := remoteDevice.(*DeviceRemote); ok && device.localDevice != nil {
+		return device.localDevice.Quirks().HasQuirk(remoteDevice, quirk)
+	}
+
+	return slices.Contains(defaultQuirks, quirk)
+}
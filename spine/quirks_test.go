@@ -0,0 +1,154 @@
+package spine
+
+import (
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestQuirksSuite(t *testing.T) {
+	suite.Run(t, new(QuirksSuite))
+}
+
+type QuirksSuite struct {
+	suite.Suite
+
+	localDevice  *DeviceLocal
+	remoteDevice *DeviceRemote
+	writeHandler *WriteMessageHandler
+}
+
+func (s *QuirksSuite) BeforeTest(suiteName, testName string) {
+	s.localDevice, _ = createLocalDeviceAndEntity(1)
+	s.writeHandler = &WriteMessageHandler{}
+	s.remoteDevice = s.localDevice.SetupRemoteDevice("ski", s.writeHandler).(*DeviceRemote)
+}
+
+// add a remote DeviceClassification server feature with manufacturer data
+func (s *QuirksSuite) addManufacturerData(brandName, deviceName string) {
+	_, serverFeature := createRemoteEntityAndFeature(s.remoteDevice, 1, model.FeatureTypeTypeDeviceClassification, model.FunctionTypeDeviceClassificationManufacturerData)
+
+	data := &model.DeviceClassificationManufacturerDataType{
+		BrandName:  util.Ptr(model.DeviceClassificationStringType(brandName)),
+		DeviceName: util.Ptr(model.DeviceClassificationStringType(deviceName)),
+	}
+	_, err := serverFeature.UpdateData(true, model.FunctionTypeDeviceClassificationManufacturerData, data, nil, nil)
+	assert.Nil(s.T(), err)
+}
+
+func (s *QuirksSuite) Test_Defaults() {
+	sut := s.localDevice.Quirks()
+
+	// the built-in quirks are enabled by default
+	assert.True(s.T(), sut.HasQuirk(nil, api.QuirkHeartbeatEarly))
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkGenericFeatureFunctions))
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkDetailedDiscoveryFullNotify))
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkType("custom")))
+
+	sut.SetDefaultQuirks(api.QuirkHeartbeatEarly)
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkHeartbeatEarly))
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkGenericFeatureFunctions))
+
+	sut.SetDefaultQuirks()
+	assert.False(s.T(), sut.HasQuirk(nil, api.QuirkHeartbeatEarly))
+}
+
+func (s *QuirksSuite) Test_SkiRule() {
+	sut := s.localDevice.Quirks()
+	sut.SetDefaultQuirks()
+
+	custom := api.QuirkType("custom")
+	sut.AddRule(api.QuirkRule{Ski: "other", Quirks: []api.QuirkType{custom}})
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, custom))
+
+	sut.AddRule(api.QuirkRule{Ski: "ski", Quirks: []api.QuirkType{custom}})
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, custom))
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkHeartbeatEarly))
+
+	// rules without any criteria never match
+	sut.AddRule(api.QuirkRule{Quirks: []api.QuirkType{api.QuirkHeartbeatEarly}})
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkHeartbeatEarly))
+}
+
+func (s *QuirksSuite) Test_BrandRule() {
+	sut := s.localDevice.Quirks()
+	sut.SetDefaultQuirks()
+
+	// manufacturer data is not yet known
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkHeartbeatEarly))
+
+	s.addManufacturerData("elli", "Wallbox")
+
+	// built-in rule
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkHeartbeatEarly))
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkGenericFeatureFunctions))
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkDetailedDiscoveryFullNotify))
+
+	sut.AddRule(api.QuirkRule{BrandName: "Elli", DeviceModel: "Other", Quirks: []api.QuirkType{api.QuirkDetailedDiscoveryFullNotify}})
+	assert.False(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkDetailedDiscoveryFullNotify))
+
+	sut.AddRule(api.QuirkRule{BrandName: "Elli", DeviceModel: "Wallbox", Quirks: []api.QuirkType{api.QuirkDetailedDiscoveryFullNotify}})
+	assert.True(s.T(), sut.HasQuirk(s.remoteDevice, api.QuirkDetailedDiscoveryFullNotify))
+}
+
+// check if a remote feature supports the heartbeat function
+func (s *QuirksSuite) supportsHeartbeat(feature api.FeatureRemoteInterface) bool {
+	_, err := feature.UpdateData(true, model.FunctionTypeDeviceDiagnosisHeartbeatData, &model.DeviceDiagnosisHeartbeatDataType{}, nil, nil)
+	return err == nil
+}
+
+func (s *QuirksSuite) Test_GenericFeatureFunctions() {
+	remoteEntity := NewEntityRemote(s.remoteDevice, model.EntityTypeTypeEVSE, []model.AddressEntityType{1})
+	s.remoteDevice.AddEntity(remoteEntity)
+
+	feature := NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeGeneric, model.RoleTypeServer)
+	assert.True(s.T(), s.supportsHeartbeat(feature))
+
+	s.localDevice.Quirks().SetDefaultQuirks()
+	feature = NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeGeneric, model.RoleTypeServer)
+	assert.False(s.T(), s.supportsHeartbeat(feature))
+
+	// the quirk applies to existing features
+	s.localDevice.Quirks().SetDefaultQuirks(api.QuirkGenericFeatureFunctions)
+	assert.True(s.T(), s.supportsHeartbeat(feature))
+
+	// other feature types are not affected
+	s.localDevice.Quirks().SetDefaultQuirks()
+	feature = NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeDeviceDiagnosis, model.RoleTypeServer)
+	assert.True(s.T(), s.supportsHeartbeat(feature))
+}
+
+func (s *QuirksSuite) Test_GenericFeatureFunctions_BrandRule() {
+	s.localDevice.Quirks().SetDefaultQuirks()
+
+	remoteEntity := NewEntityRemote(s.remoteDevice, model.EntityTypeTypeEVSE, []model.AddressEntityType{2})
+	s.remoteDevice.AddEntity(remoteEntity)
+
+	// the feature is created before the manufacturer data is known, like in the detailed discovery
+	feature := NewFeatureRemote(remoteEntity.NextFeatureId(), remoteEntity, model.FeatureTypeTypeGeneric, model.RoleTypeServer)
+	remoteEntity.AddFeature(feature)
+	assert.False(s.T(), s.supportsHeartbeat(feature))
+
+	// the built-in rule applies once the manufacturer data was read
+	s.addManufacturerData("SMA", "Home Manager 2.0")
+	assert.True(s.T(), s.supportsHeartbeat(feature))
+}
+
+func (s *QuirksSuite) Test_DetailedDiscoveryFullNotify() {
+	s.localDevice.Quirks().SetDefaultQuirks()
+
+	_, _ = s.remoteDevice.HandleSpineMesssage(loadFileData(s.T(), wallbox_detaileddiscoverydata_recv_reply_full_file_path))
+	assert.Equal(s.T(), 2, len(s.remoteDevice.Entities()))
+
+	_, _ = s.remoteDevice.HandleSpineMesssage(loadFileData(s.T(), wallbox_detaileddiscoverydata_recv_notify_full_file_path))
+	assert.Equal(s.T(), 2, len(s.remoteDevice.Entities()))
+
+	s.localDevice.Quirks().AddRule(api.QuirkRule{Ski: "ski", Quirks: []api.QuirkType{api.QuirkDetailedDiscoveryFullNotify}})
+
+	_, _ = s.remoteDevice.HandleSpineMesssage(loadFileData(s.T(), wallbox_detaileddiscoverydata_recv_notify_full_file_path))
+	assert.Equal(s.T(), 3, len(s.remoteDevice.Entities()))
+}
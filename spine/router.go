@@ -0,0 +1,150 @@
+package spine
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// check if the local device forwards datagrams to other devices,
+// which is the case for the feature sets router and gateway
+func (r *DeviceLocal) isRouter() bool {
+	featureSet := r.FeatureSet()
+
+	return featureSet != nil &&
+		(*featureSet == model.NetworkManagementFeatureSetTypeRouter ||
+			*featureSet == model.NetworkManagementFeatureSetTypeGateway)
+}
+
+// forward a received datagram, which is addressed to another device
+//
+// returns false if the datagram is addressed to the local device and has to be processed locally
+func (r *DeviceLocal) routeDatagram(remoteDevice api.DeviceRemoteInterface, datagram *model.DatagramType) bool {
+	header := &datagram.Header
+
+	// without a device address it is unknown which datagrams are addressed to other devices
+	if !r.isRouter() || r.Address() == nil || header.AddressDestination == nil || header.AddressDestination.Device == nil ||
+		*header.AddressDestination.Device == *r.Address() {
+		return false
+	}
+
+	// the access control applies to forwarded datagrams as well
+	if !r.isForwardAllowed(remoteDevice, datagram) {
+		err := model.NewErrorType(model.ErrorNumberTypeCommandRejected, "access denied")
+		r.routingFailed(remoteDevice, header, err)
+		return true
+	}
+
+	// datagrams are never sent back to the device they came from
+	destinationDevice := r.RemoteDeviceForAddress(*header.AddressDestination.Device)
+	if destinationDevice == nil || destinationDevice == remoteDevice {
+		r.routingFailed(remoteDevice, header,
+			model.NewErrorType(model.ErrorNumberTypeDestinationUnreachable, "destination device is not connected"))
+		return true
+	}
+
+	// the originator is set by the first router, so responses can be sent to it
+	if header.AddressOriginator == nil && header.AddressSource != nil {
+		originator := *header.AddressSource
+		if originator.Device == nil {
+			originator.Device = remoteDevice.Address()
+		}
+		header.AddressOriginator = &originator
+	}
+
+	if err := destinationDevice.Sender().Forward(*datagram); err != nil {
+		r.routingFailed(remoteDevice, header, model.NewErrorType(model.ErrorNumberTypeDestinationUnreachable, err.Error()))
+	}
+
+	return true
+}
+
+// check if the access control allows forwarding all cmds of a datagram received from the remote device
+//
+// the source feature is used for the check, or the node management of the remote device
+// if the source feature is not known
+func (r *DeviceLocal) isForwardAllowed(remoteDevice api.DeviceRemoteInterface, datagram *model.DatagramType) bool {
+	if r.AccessControl() == nil {
+		return true
+	}
+
+	var sourceFeature api.FeatureRemoteInterface
+	if datagram.Header.AddressSource != nil {
+		sourceFeature = remoteDevice.FeatureByAddress(datagram.Header.AddressSource)
+	}
+	if sourceFeature == nil {
+		sourceFeature = remoteDevice.FeatureByAddress(NodeManagementAddress(remoteDevice.Address()))
+	}
+	if sourceFeature == nil {
+		// the remote device is not discovered yet
+		return false
+	}
+
+	for _, cmd := range datagram.Payload.Cmd {
+		var function model.FunctionType
+		if cmdData, err := cmd.Data(); err == nil && cmdData.Function != nil {
+			function = *cmdData.Function
+		}
+
+		if !r.checkAccess(sourceFeature, nil, function, api.AccessOperationForward) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// answer a datagram which could not be forwarded
+func (r *DeviceLocal) routingFailed(remoteDevice api.DeviceRemoteInterface, header *model.HeaderType, err *model.ErrorType) {
+	if !canReplyToDatagram(header) {
+		return
+	}
+
+	_ = remoteDevice.Sender().ResultError(header, NodeManagementAddress(r.Address()), err)
+}
+
+// return the destination list, containing the local device and,
+// for routers, all connected remote devices
+func (r *DeviceLocal) destinationListData() *model.NodeManagementDestinationListDataType {
+	data := []model.NodeManagementDestinationDataType{
+		r.DestinationData(),
+	}
+
+	if r.isRouter() {
+		var remoteDevices []api.DeviceRemoteInterface
+		for _, remoteDevice := range r.RemoteDevices() {
+			// the device is only known after the detailed discovery data was received
+			if remoteDevice.Address() != nil {
+				remoteDevices = append(remoteDevices, remoteDevice)
+			}
+		}
+
+		// keep the order stable, so unchanged lists do not trigger notifications
+		slices.SortFunc(remoteDevices, func(a, b api.DeviceRemoteInterface) int {
+			return strings.Compare(string(*a.Address()), string(*b.Address()))
+		})
+
+		for _, remoteDevice := range remoteDevices {
+			data = append(data, remoteDevice.DestinationData())
+		}
+	}
+
+	return &model.NodeManagementDestinationListDataType{
+		NodeManagementDestinationData: data,
+	}
+}
+
+// update the destination list of the node management, which notifies all subscribers
+func (r *DeviceLocal) updateDestinationList() {
+	if r.nodeManagement == nil {
+		return
+	}
+
+	if _, ok := r.nodeManagement.Operations()[model.FunctionTypeNodeManagementDestinationListData]; !ok {
+		return
+	}
+
+	r.nodeManagement.SetData(model.FunctionTypeNodeManagementDestinationListData, r.destinationListData())
+}
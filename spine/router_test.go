@@ -0,0 +1,273 @@
+package spine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRouterSuite(t *testing.T) {
+	suite.Run(t, new(RouterSuite))
+}
+
+type RouterSuite struct {
+	suite.Suite
+
+	deviceA, router, deviceB *DeviceLocal
+
+	loopbacks []*Loopback
+}
+
+func (s *RouterSuite) BeforeTest(suiteName, testName string) {
+	s.deviceA = NewDeviceLocal("Vendor", "DeviceA", "SerialA", "CodeA", "AddressA", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	s.router = NewDeviceLocal("Vendor", "Router", "SerialR", "CodeR", "AddressR", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeRouter)
+	s.deviceB = NewDeviceLocal("Vendor", "DeviceB", "SerialB", "CodeB", "AddressB", model.DeviceTypeTypeChargingStation, model.NetworkManagementFeatureSetTypeSmart)
+	s.loopbacks = nil
+}
+
+func (s *RouterSuite) AfterTest(suiteName, testName string) {
+	for _, loopback := range s.loopbacks {
+		loopback.Close()
+	}
+}
+
+// connect deviceA and deviceB to the router and wait until the router knows both
+func (s *RouterSuite) connect() {
+	s.loopbacks = append(s.loopbacks,
+		NewLoopback(s.deviceA, "skiA", s.router, "skiR", LoopbackOptions{}),
+		NewLoopback(s.router, "skiR", s.deviceB, "skiB", LoopbackOptions{}),
+	)
+
+	assert.Eventually(s.T(), func() bool {
+		return s.router.RemoteDeviceForAddress("AddressA") != nil &&
+			s.router.RemoteDeviceForAddress("AddressB") != nil &&
+			s.deviceA.RemoteDeviceForAddress("AddressR") != nil
+	}, time.Second, time.Millisecond*10)
+}
+
+// a read of the destination list of deviceB, sent by deviceA to the router
+func (s *RouterSuite) readDestinationList(destinationDevice model.AddressDeviceType) *model.MsgCounterType {
+	remoteRouter := s.deviceA.RemoteDeviceForSki("skiR")
+	destination := &model.FeatureAddressType{
+		Device:  util.Ptr(destinationDevice),
+		Entity:  []model.AddressEntityType{0},
+		Feature: util.Ptr(model.AddressFeatureType(0)),
+	}
+	cmd := model.CmdType{
+		NodeManagementDestinationListData: &model.NodeManagementDestinationListDataType{},
+	}
+
+	msgCounter, err := remoteRouter.Sender().Request(model.CmdClassifierTypeRead, s.deviceA.NodeManagement().Address(), destination, false, []model.CmdType{cmd})
+	assert.Nil(s.T(), err)
+
+	return msgCounter
+}
+
+// record all datagrams received by a device
+func (s *RouterSuite) recordInbound(device *DeviceLocal) func() []model.DatagramType {
+	var datagrams []model.DatagramType
+	var mux sync.Mutex
+
+	device.AddInboundInterceptor(func(ski string, datagram *model.DatagramType) (api.InterceptorActionType, *model.ErrorType) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		datagrams = append(datagrams, *datagram)
+		return api.InterceptorActionContinue, nil
+	})
+
+	return func() []model.DatagramType {
+		mux.Lock()
+		defer mux.Unlock()
+
+		return append([]model.DatagramType(nil), datagrams...)
+	}
+}
+
+func findDatagram(datagrams []model.DatagramType, match func(datagram model.DatagramType) bool) *model.DatagramType {
+	for _, datagram := range datagrams {
+		if match(datagram) {
+			return &datagram
+		}
+	}
+
+	return nil
+}
+
+func (s *RouterSuite) Test_Forward() {
+	s.connect()
+	receivedB := s.recordInbound(s.deviceB)
+	receivedA := s.recordInbound(s.deviceA)
+
+	msgCounter := s.readDestinationList("AddressB")
+
+	// the read is forwarded to deviceB with deviceA as originator
+	var request *model.DatagramType
+	assert.Eventually(s.T(), func() bool {
+		request = findDatagram(receivedB(), func(datagram model.DatagramType) bool {
+			return datagram.Payload.Cmd[0].NodeManagementDestinationListData != nil &&
+				*datagram.Header.CmdClassifier == model.CmdClassifierTypeRead
+		})
+		return request != nil
+	}, time.Second, time.Millisecond*10)
+	if request == nil {
+		return
+	}
+	assert.Equal(s.T(), *msgCounter, *request.Header.MsgCounter)
+	if assert.NotNil(s.T(), request.Header.AddressOriginator) {
+		assert.Equal(s.T(), model.AddressDeviceType("AddressA"), *request.Header.AddressOriginator.Device)
+	}
+
+	// the reply of deviceB is routed back to deviceA
+	var reply *model.DatagramType
+	assert.Eventually(s.T(), func() bool {
+		reply = findDatagram(receivedA(), func(datagram model.DatagramType) bool {
+			return datagram.Header.MsgCounterReference != nil && *datagram.Header.MsgCounterReference == *msgCounter
+		})
+		return reply != nil
+	}, time.Second, time.Millisecond*10)
+	if reply == nil {
+		return
+	}
+	assert.Equal(s.T(), model.CmdClassifierTypeReply, *reply.Header.CmdClassifier)
+	assert.Equal(s.T(), model.AddressDeviceType("AddressB"), *reply.Header.AddressSource.Device)
+	assert.Equal(s.T(), model.AddressDeviceType("AddressA"), *reply.Header.AddressDestination.Device)
+}
+
+func (s *RouterSuite) Test_DestinationUnreachable() {
+	s.connect()
+	receivedA := s.recordInbound(s.deviceA)
+
+	msgCounter := s.readDestinationList("AddressUnknown")
+
+	var result *model.DatagramType
+	assert.Eventually(s.T(), func() bool {
+		result = findDatagram(receivedA(), func(datagram model.DatagramType) bool {
+			return datagram.Header.MsgCounterReference != nil && *datagram.Header.MsgCounterReference == *msgCounter
+		})
+		return result != nil
+	}, time.Second, time.Millisecond*10)
+	if result == nil {
+		return
+	}
+
+	resultData := result.Payload.Cmd[0].ResultData
+	if assert.NotNil(s.T(), resultData) {
+		assert.Equal(s.T(), model.ErrorNumberTypeDestinationUnreachable, *resultData.ErrorNumber)
+	}
+}
+
+func (s *RouterSuite) Test_ForwardAccessDenied() {
+	acl := NewAccessControlList(true)
+	acl.AddRule(AccessRule{
+		Ski:        "skiA",
+		Operations: []api.AccessOperationType{api.AccessOperationForward},
+		Allow:      false,
+	})
+	s.router.SetAccessControl(acl)
+
+	s.connect()
+	receivedA := s.recordInbound(s.deviceA)
+	receivedB := s.recordInbound(s.deviceB)
+
+	msgCounter := s.readDestinationList("AddressB")
+
+	var result *model.DatagramType
+	assert.Eventually(s.T(), func() bool {
+		result = findDatagram(receivedA(), func(datagram model.DatagramType) bool {
+			return datagram.Header.MsgCounterReference != nil && *datagram.Header.MsgCounterReference == *msgCounter
+		})
+		return result != nil
+	}, time.Second, time.Millisecond*10)
+	if result == nil {
+		return
+	}
+
+	resultData := result.Payload.Cmd[0].ResultData
+	if assert.NotNil(s.T(), resultData) {
+		assert.Equal(s.T(), model.ErrorNumberTypeCommandRejected, *resultData.ErrorNumber)
+	}
+
+	// the datagram was not forwarded
+	assert.Nil(s.T(), findDatagram(receivedB(), func(datagram model.DatagramType) bool {
+		return datagram.Payload.Cmd[0].NodeManagementDestinationListData != nil &&
+			*datagram.Header.CmdClassifier == model.CmdClassifierTypeRead
+	}))
+}
+
+func (s *RouterSuite) Test_NoAddress() {
+	sut := NewDeviceLocal("Vendor", "Router", "SerialR", "CodeR", "AddressR", model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeRouter)
+	sut.address = nil
+	remoteDevice := createRemoteDevice(sut, "ski", NewSender(&WriteMessageHandler{}))
+
+	// without an address of the local device datagrams are not treated as addressed to other devices
+	datagram := &model.DatagramType{
+		Header: model.HeaderType{
+			AddressDestination: &model.FeatureAddressType{
+				Device:  util.Ptr(model.AddressDeviceType("Other")),
+				Entity:  []model.AddressEntityType{0},
+				Feature: util.Ptr(model.AddressFeatureType(0)),
+			},
+		},
+	}
+	assert.False(s.T(), sut.routeDatagram(remoteDevice, datagram))
+}
+
+func (s *RouterSuite) Test_NoRouter() {
+	// deviceB is connected directly and does not forward datagrams
+	s.loopbacks = append(s.loopbacks, NewLoopback(s.deviceA, "skiA", s.deviceB, "skiR", LoopbackOptions{}))
+	assert.Eventually(s.T(), func() bool {
+		return s.deviceA.RemoteDeviceForAddress("AddressB") != nil
+	}, time.Second, time.Millisecond*10)
+
+	receivedA := s.recordInbound(s.deviceA)
+	msgCounter := s.readDestinationList("AddressUnknown")
+
+	// the datagram is processed by deviceB itself
+	var reply *model.DatagramType
+	assert.Eventually(s.T(), func() bool {
+		reply = findDatagram(receivedA(), func(datagram model.DatagramType) bool {
+			return datagram.Header.MsgCounterReference != nil && *datagram.Header.MsgCounterReference == *msgCounter
+		})
+		return reply != nil
+	}, time.Second, time.Millisecond*10)
+	if reply != nil {
+		assert.Equal(s.T(), model.CmdClassifierTypeReply, *reply.Header.CmdClassifier)
+	}
+}
+
+func (s *RouterSuite) destinationAddresses(device *DeviceLocal) []model.AddressDeviceType {
+	data, ok := device.NodeManagement().DataCopy(model.FunctionTypeNodeManagementDestinationListData).(*model.NodeManagementDestinationListDataType)
+	if !ok || data == nil {
+		return nil
+	}
+
+	var addresses []model.AddressDeviceType
+	for _, item := range data.NodeManagementDestinationData {
+		addresses = append(addresses, *item.DeviceDescription.DeviceAddress.Device)
+	}
+
+	return addresses
+}
+
+func (s *RouterSuite) Test_DestinationList() {
+	assert.Equal(s.T(), []model.AddressDeviceType{"AddressR"}, s.destinationAddresses(s.router))
+
+	s.connect()
+	assert.Eventually(s.T(), func() bool {
+		return len(s.destinationAddresses(s.router)) == 3
+	}, time.Second, time.Millisecond*10)
+	assert.Equal(s.T(), []model.AddressDeviceType{"AddressR", "AddressA", "AddressB"}, s.destinationAddresses(s.router))
+
+	// devices without routing only list themselves
+	assert.Equal(s.T(), []model.AddressDeviceType{"AddressA"}, s.destinationAddresses(s.deviceA))
+
+	s.router.RemoveRemoteDeviceConnection("skiB")
+	assert.Equal(s.T(), []model.AddressDeviceType{"AddressR", "AddressA"}, s.destinationAddresses(s.router))
+}
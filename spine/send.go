@@ -9,6 +9,7 @@ import (
 	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	shipapi "github.com/enbility/ship-go/api"
 	"github.com/enbility/ship-go/logging"
@@ -18,7 +19,19 @@ import (
 	"github.com/golanguzb70/lrucache"
 )
 
-type reqMsgCacheData map[model.MsgCounterType]string
+// requests not answered within this duration are removed from the request cache,
+// as they will never be answered
+const reqMsgCacheTimeout = defaultMaxResponseDelay
+
+// number of msgCounters reserved with a single write to the storage
+const msgCounterReserveBlock = 100
+
+type reqMsgCacheEntry struct {
+	hash      string
+	timestamp time.Time
+}
+
+type reqMsgCacheData map[model.MsgCounterType]reqMsgCacheEntry
 
 type Sender struct {
 	msgNum uint64 // 64bit values need to be defined on top of the struct to make atomic commands work on 32bit systems
@@ -30,7 +43,16 @@ type Sender struct {
 
 	reqMsgCache reqMsgCacheData // cache for unanswered request messages, so we can filter duplicates and not send them
 
+	ski string // SKI of the remote device, only set if the sender was created by a local device
+
+	storage     api.StorageInterface     // optional storage for persisting the msgCounter
+	localDevice api.DeviceLocalInterface // optional local device providing the recorder for capturing sent datagrams
+
+	deviceInterceptors *interceptorChain // optional outbound interceptors of the local device
+
 	muxRequestSend sync.Mutex
+	msgNumReserved uint64 // the msgCounters up to this one are reserved in the storage
+	muxMsgCounter  sync.Mutex
 
 	muxNotifyCache sync.RWMutex
 	muxReadCache   sync.RWMutex
@@ -39,6 +61,10 @@ type Sender struct {
 var _ api.SenderInterface = (*Sender)(nil)
 
 func NewSender(writeI shipapi.ShipConnectionDataWriterInterface) api.SenderInterface {
+	return newSender(writeI)
+}
+
+func newSender(writeI shipapi.ShipConnectionDataWriterInterface) *Sender {
 	cache := lrucache.New[model.MsgCounterType, model.DatagramType](100, 0)
 	return &Sender{
 		datagramNotifyCache: &cache,
@@ -60,6 +86,42 @@ func (c *Sender) DatagramForMsgCounter(msgCounter model.MsgCounterType) (model.D
 }
 
 func (c *Sender) sendSpineMessage(datagram model.DatagramType) error {
+	if err := c.intercept(&datagram); err != nil {
+		return err
+	}
+
+	return c.writeDatagram(datagram)
+}
+
+// run the outbound interceptors of the local device
+//
+// returns ErrDatagramStopped or ErrDatagramRejected if the datagram must not be sent
+func (c *Sender) intercept(datagram *model.DatagramType) error {
+	if c.deviceInterceptors == nil {
+		return nil
+	}
+
+	switch action, err := c.deviceInterceptors.run(c.ski, datagram); action {
+	case api.InterceptorActionStop:
+		return ErrDatagramStopped
+	case api.InterceptorActionReject:
+		return fmt.Errorf("%w: %s", ErrDatagramRejected, err.String())
+	}
+
+	return nil
+}
+
+// a stopped response or forwarded datagram is no error, as no response is expected for it
+func ignoreStopped(err error) error {
+	if errors.Is(err, ErrDatagramStopped) {
+		return nil
+	}
+
+	return err
+}
+
+// marshal the datagram and write it to the SHIP connection
+func (c *Sender) writeDatagram(datagram model.DatagramType) error {
 	// pack into datagram
 	data := model.Datagram{
 		Datagram: datagram,
@@ -81,6 +143,13 @@ func (c *Sender) sendSpineMessage(datagram model.DatagramType) error {
 
 	logging.Log().Debug(datagram.PrintMessageOverview(true, "", ""))
 
+	// the recorder may be changed at any time
+	if c.localDevice != nil {
+		if recorder := c.localDevice.DatagramRecorder(); recorder != nil {
+			recorder.RecordDatagram(api.DatagramDirectionOutgoing, c.ski, msg)
+		}
+	}
+
 	// write to channel
 	c.writeHandler.WriteShipMessageWithPayload(msg)
 
@@ -103,8 +172,8 @@ func (c *Sender) msgCounterForHashFromCache(hash string) *model.MsgCounterType {
 	c.muxReadCache.RLock()
 	defer c.muxReadCache.RUnlock()
 
-	for msgCounter, h := range c.reqMsgCache {
-		if h == hash {
+	for msgCounter, entry := range c.reqMsgCache {
+		if entry.hash == hash && time.Since(entry.timestamp) < reqMsgCacheTimeout {
 			return &msgCounter
 		}
 	}
@@ -125,6 +194,13 @@ func (c *Sender) addMsgCounterHashToCache(msgCounter model.MsgCounterType, hash
 	c.muxReadCache.Lock()
 	defer c.muxReadCache.Unlock()
 
+	// remove requests which will not be answered anymore
+	for key, entry := range c.reqMsgCache {
+		if time.Since(entry.timestamp) >= reqMsgCacheTimeout {
+			delete(c.reqMsgCache, key)
+		}
+	}
+
 	// cleanup cache, keep only the last 20 messages
 	if len(c.reqMsgCache) > 20 {
 		keys := make([]uint64, 0, len(c.reqMsgCache))
@@ -138,7 +214,10 @@ func (c *Sender) addMsgCounterHashToCache(msgCounter model.MsgCounterType, hash
 		delete(c.reqMsgCache, model.MsgCounterType(oldestKey))
 	}
 
-	c.reqMsgCache[msgCounter] = hash
+	c.reqMsgCache[msgCounter] = reqMsgCacheEntry{
+		hash:      hash,
+		timestamp: time.Now(),
+	}
 }
 
 // we need to remove the msgCounter from the cache, if we have it cached
@@ -230,7 +309,7 @@ func (c *Sender) result(requestHeader *model.HeaderType, senderAddress *model.Fe
 		Header: model.HeaderType{
 			SpecificationVersion: &SpecificationVersion,
 			AddressSource:        &addressSource,
-			AddressDestination:   requestHeader.AddressSource,
+			AddressDestination:   responseDestination(requestHeader),
 			MsgCounter:           c.getMsgCounter(),
 			MsgCounterReference:  requestHeader.MsgCounter,
 			CmdClassifier:        &cmdClassifier,
@@ -240,7 +319,16 @@ func (c *Sender) result(requestHeader *model.HeaderType, senderAddress *model.Fe
 		},
 	}
 
-	return c.sendSpineMessage(datagram)
+	return ignoreStopped(c.sendSpineMessage(datagram))
+}
+
+// the destination of a reply or result, routed requests are answered to their originator
+func responseDestination(requestHeader *model.HeaderType) *model.FeatureAddressType {
+	if requestHeader.AddressOriginator != nil {
+		return requestHeader.AddressOriginator
+	}
+
+	return requestHeader.AddressSource
 }
 
 // Reply sends reply to original sender
@@ -254,7 +342,7 @@ func (c *Sender) Reply(requestHeader *model.HeaderType, senderAddress *model.Fea
 		Header: model.HeaderType{
 			SpecificationVersion: &SpecificationVersion,
 			AddressSource:        &addressSource,
-			AddressDestination:   requestHeader.AddressSource,
+			AddressDestination:   responseDestination(requestHeader),
 			MsgCounter:           c.getMsgCounter(),
 			MsgCounterReference:  requestHeader.MsgCounter,
 			CmdClassifier:        &cmdClassifier,
@@ -264,11 +352,15 @@ func (c *Sender) Reply(requestHeader *model.HeaderType, senderAddress *model.Fea
 		},
 	}
 
-	return c.sendSpineMessage(datagram)
+	return ignoreStopped(c.sendSpineMessage(datagram))
 }
 
-// Notify sends notification to destination
-func (c *Sender) Notify(senderAddress, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error) {
+// Notify sends a notification with one or more cmds to destination
+func (c *Sender) Notify(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error) {
+	if len(cmd) == 0 {
+		return nil, errors.New("no cmd provided")
+	}
+
 	msgCounter := c.getMsgCounter()
 
 	cmdClassifier := model.CmdClassifierTypeNotify
@@ -282,19 +374,28 @@ func (c *Sender) Notify(senderAddress, destinationAddress *model.FeatureAddressT
 			CmdClassifier:        &cmdClassifier,
 		},
 		Payload: model.PayloadType{
-			Cmd: []model.CmdType{cmd},
+			Cmd: cmd,
 		},
 	}
 
+	// only datagrams which are sent are cached
+	if err := c.intercept(&datagram); err != nil {
+		return nil, err
+	}
+
 	c.muxNotifyCache.Lock()
 	c.datagramNotifyCache.Put(*msgCounter, datagram)
 	c.muxNotifyCache.Unlock()
 
-	return msgCounter, c.sendSpineMessage(datagram)
+	return msgCounter, c.writeDatagram(datagram)
 }
 
-// Write sends notification to destination
-func (c *Sender) Write(senderAddress, destinationAddress *model.FeatureAddressType, cmd model.CmdType) (*model.MsgCounterType, error) {
+// Write sends a write with one or more cmds to destination
+func (c *Sender) Write(senderAddress, destinationAddress *model.FeatureAddressType, cmd ...model.CmdType) (*model.MsgCounterType, error) {
+	if len(cmd) == 0 {
+		return nil, errors.New("no cmd provided")
+	}
+
 	msgCounter := c.getMsgCounter()
 
 	cmdClassifier := model.CmdClassifierTypeWrite
@@ -310,13 +411,18 @@ func (c *Sender) Write(senderAddress, destinationAddress *model.FeatureAddressTy
 			AckRequest:           &ackRequest,
 		},
 		Payload: model.PayloadType{
-			Cmd: []model.CmdType{cmd},
+			Cmd: cmd,
 		},
 	}
 
 	return msgCounter, c.sendSpineMessage(datagram)
 }
 
+// Forward sends a datagram of another device unchanged
+func (c *Sender) Forward(datagram model.DatagramType) error {
+	return ignoreStopped(c.sendSpineMessage(datagram))
+}
+
 // Send a subscription request to a remote server feature
 func (c *Sender) Subscribe(senderAddress, destinationAddress *model.FeatureAddressType, serverFeatureType model.FeatureTypeType) (*model.MsgCounterType, error) {
 	cmd := model.CmdType{
@@ -369,8 +475,46 @@ func (c *Sender) Unbind(senderAddress, destinationAddress *model.FeatureAddressT
 	return c.Request(model.CmdClassifierTypeCall, localAddress, remoteAddress, true, []model.CmdType{cmd})
 }
 
+// Persist the msgCounter of the remote device with the given SKI in the storage
+// and continue counting from the last persisted msgCounter
+func (c *Sender) setStorage(storage api.StorageInterface) {
+	c.muxMsgCounter.Lock()
+	defer c.muxMsgCounter.Unlock()
+
+	c.storage = storage
+
+	msgCounter, err := storage.MsgCounter(c.ski)
+	logStorageError(err)
+	if err == nil && uint64(msgCounter) > atomic.LoadUint64(&c.msgNum) {
+		atomic.StoreUint64(&c.msgNum, uint64(msgCounter))
+	}
+
+	// the next msgCounter reserves a new block
+	c.msgNumReserved = atomic.LoadUint64(&c.msgNum)
+}
+
+// has to be called before any message is sent
+func (c *Sender) setLocalDevice(device api.DeviceLocalInterface) {
+	c.localDevice = device
+}
+
+// has to be called before any message is sent
+func (c *Sender) setDeviceInterceptors(interceptors *interceptorChain) {
+	c.deviceInterceptors = interceptors
+}
+
 func (c *Sender) getMsgCounter() *model.MsgCounterType {
-	// TODO:  persistence
+	c.muxMsgCounter.Lock()
+	defer c.muxMsgCounter.Unlock()
+
 	i := model.MsgCounterType(atomic.AddUint64(&c.msgNum, 1))
+
+	// persist the end of a block of msgCounters instead of every single one,
+	// after a restart counting continues after the reserved block
+	if c.storage != nil && uint64(i) > c.msgNumReserved {
+		c.msgNumReserved = uint64(i) + msgCounterReserveBlock - 1
+		logStorageError(c.storage.SaveMsgCounter(c.ski, model.MsgCounterType(c.msgNumReserved)))
+	}
+
 	return &i
 }
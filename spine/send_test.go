@@ -3,6 +3,7 @@ package spine
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/enbility/spine-go/model"
 	"github.com/enbility/spine-go/util"
@@ -44,7 +45,20 @@ func Test_Cache(t *testing.T) {
 	assert.NotNil(t, msgCounter2)
 	assert.Equal(t, *msgCounter, *msgCounter2)
 
-	sut.ProcessResponseForMsgCounterReference(msgCounter)
+	// unanswered requests expire
+	sender := sut.(*Sender)
+	entry := sender.reqMsgCache[*msgCounter]
+	entry.timestamp = time.Now().Add(-reqMsgCacheTimeout)
+	sender.reqMsgCache[*msgCounter] = entry
+
+	msgCounterExpired, err := sut.Request(cmdClassifier, senderAddress, destinationAddress, false, cmd)
+	assert.NoError(t, err)
+	assert.NotNil(t, msgCounterExpired)
+	assert.NotEqual(t, *msgCounter, *msgCounterExpired)
+	_, ok := sender.reqMsgCache[*msgCounter]
+	assert.False(t, ok)
+
+	sut.ProcessResponseForMsgCounterReference(msgCounterExpired)
 
 	msgCounter3, err := sut.Request(cmdClassifier, senderAddress, destinationAddress, false, cmd)
 	assert.NoError(t, err)
@@ -151,6 +165,44 @@ func TestSender_Write_MsgCounter(t *testing.T) {
 	assert.Equal(t, expectedMsgCounter, int(*sentDatagram.Datagram.Header.MsgCounter))
 }
 
+func TestSender_NotifyWrite_MultipleCmds(t *testing.T) {
+	temp := &WriteMessageHandler{}
+	sut := NewSender(temp)
+
+	senderAddress := featureAddressType(1, NewEntityAddressType("Sender", []uint{1}))
+	destinationAddress := featureAddressType(2, NewEntityAddressType("destination", []uint{1}))
+	cmd1 := model.CmdType{
+		MeasurementListData: &model.MeasurementListDataType{},
+	}
+	cmd2 := model.CmdType{
+		MeasurementDescriptionListData: &model.MeasurementDescriptionListDataType{},
+	}
+
+	_, err := sut.Notify(senderAddress, destinationAddress)
+	assert.Error(t, err)
+
+	_, err = sut.Write(senderAddress, destinationAddress)
+	assert.Error(t, err)
+
+	_, err = sut.Notify(senderAddress, destinationAddress, cmd1, cmd2)
+	assert.NoError(t, err)
+
+	sentBytes := temp.LastMessage()
+	var sentDatagram model.Datagram
+	assert.NoError(t, json.Unmarshal(sentBytes, &sentDatagram))
+	assert.Equal(t, 2, len(sentDatagram.Datagram.Payload.Cmd))
+	assert.NotNil(t, sentDatagram.Datagram.Payload.Cmd[0].MeasurementListData)
+	assert.NotNil(t, sentDatagram.Datagram.Payload.Cmd[1].MeasurementDescriptionListData)
+
+	_, err = sut.Write(senderAddress, destinationAddress, cmd1, cmd2)
+	assert.NoError(t, err)
+
+	sentBytes = temp.LastMessage()
+	assert.NoError(t, json.Unmarshal(sentBytes, &sentDatagram))
+	assert.Equal(t, model.CmdClassifierTypeWrite, *sentDatagram.Datagram.Header.CmdClassifier)
+	assert.Equal(t, 2, len(sentDatagram.Datagram.Payload.Cmd))
+}
+
 func TestSender_Subscribe_MsgCounter(t *testing.T) {
 	temp := &WriteMessageHandler{}
 	sut := NewSender(temp)
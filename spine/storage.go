@@ -0,0 +1,43 @@
+package spine
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/enbility/ship-go/logging"
+	"github.com/enbility/spine-go/api"
+)
+
+// create the storage entry of a subscription or binding
+func newStorageEntry(serverFeature api.FeatureLocalInterface, clientFeature api.FeatureRemoteInterface) api.StorageEntry {
+	return api.StorageEntry{
+		ClientAddress:     *clientFeature.Address(),
+		ServerAddress:     *serverFeature.Address(),
+		ServerFeatureType: serverFeature.Type(),
+	}
+}
+
+// log errors of storage operations, as they should not prevent the processing of messages
+func logStorageError(err error) {
+	if err != nil {
+		logging.Log().Debug("storage error:", err)
+	}
+}
+
+// entries are identified by their client and server feature addresses
+func storageEntryMatch(a, b api.StorageEntry) bool {
+	return reflect.DeepEqual(a.ClientAddress, b.ClientAddress) &&
+		reflect.DeepEqual(a.ServerAddress, b.ServerAddress)
+}
+
+func addStorageEntry(entries []api.StorageEntry, entry api.StorageEntry) []api.StorageEntry {
+	if slices.ContainsFunc(entries, func(item api.StorageEntry) bool { return storageEntryMatch(item, entry) }) {
+		return entries
+	}
+
+	return append(entries, entry)
+}
+
+func removeStorageEntry(entries []api.StorageEntry, entry api.StorageEntry) []api.StorageEntry {
+	return slices.DeleteFunc(entries, func(item api.StorageEntry) bool { return storageEntryMatch(item, entry) })
+}
@@ -0,0 +1,117 @@
+package spine
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// Storage persisting all entries as JSON in a file
+//
+// The file is rewritten on every change
+type FileStorage struct {
+	path   string
+	memory *MemoryStorage
+
+	mux sync.Mutex
+}
+
+var _ api.StorageInterface = (*FileStorage)(nil)
+
+// Create a file storage and load the entries of an existing file
+func NewFileStorage(path string) (*FileStorage, error) {
+	res := &FileStorage{
+		path:   path,
+		memory: NewMemoryStorage(),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return res, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &res.memory.devices); err != nil {
+		return nil, err
+	}
+	if res.memory.devices == nil {
+		res.memory.devices = make(map[string]*storageDevice)
+	}
+
+	return res, nil
+}
+
+/* StorageInterface */
+
+func (s *FileStorage) SaveSubscription(ski string, entry api.StorageEntry) error {
+	return s.update(func() error { return s.memory.SaveSubscription(ski, entry) })
+}
+
+func (s *FileStorage) RemoveSubscription(ski string, entry api.StorageEntry) error {
+	return s.update(func() error { return s.memory.RemoveSubscription(ski, entry) })
+}
+
+func (s *FileStorage) Subscriptions(ski string) ([]api.StorageEntry, error) {
+	return s.memory.Subscriptions(ski)
+}
+
+func (s *FileStorage) SaveBinding(ski string, entry api.StorageEntry) error {
+	return s.update(func() error { return s.memory.SaveBinding(ski, entry) })
+}
+
+func (s *FileStorage) RemoveBinding(ski string, entry api.StorageEntry) error {
+	return s.update(func() error { return s.memory.RemoveBinding(ski, entry) })
+}
+
+func (s *FileStorage) Bindings(ski string) ([]api.StorageEntry, error) {
+	return s.memory.Bindings(ski)
+}
+
+func (s *FileStorage) SaveMsgCounter(ski string, msgCounter model.MsgCounterType) error {
+	return s.update(func() error { return s.memory.SaveMsgCounter(ski, msgCounter) })
+}
+
+func (s *FileStorage) MsgCounter(ski string) (model.MsgCounterType, error) {
+	return s.memory.MsgCounter(ski)
+}
+
+// apply a change and write all entries to the file
+func (s *FileStorage) update(change func() error) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	s.memory.mux.Lock()
+	data, err := json.Marshal(s.memory.devices)
+	s.memory.mux.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so a crash does not leave a corrupt file behind
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile.Name(), s.path)
+}
@@ -0,0 +1,118 @@
+package spine
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+)
+
+// the persisted data of a remote device
+type storageDevice struct {
+	Subscriptions []api.StorageEntry   `json:"subscriptions,omitempty"`
+	Bindings      []api.StorageEntry   `json:"bindings,omitempty"`
+	MsgCounter    model.MsgCounterType `json:"msgCounter,omitempty"`
+}
+
+// Storage keeping all entries in memory only
+//
+// Useful for tests, or if the entries only need to survive reconnects
+type MemoryStorage struct {
+	devices map[string]*storageDevice
+
+	mux sync.Mutex
+}
+
+var _ api.StorageInterface = (*MemoryStorage)(nil)
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		devices: make(map[string]*storageDevice),
+	}
+}
+
+/* StorageInterface */
+
+func (s *MemoryStorage) SaveSubscription(ski string, entry api.StorageEntry) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	device := s.device(ski)
+	device.Subscriptions = addStorageEntry(device.Subscriptions, entry)
+
+	return nil
+}
+
+func (s *MemoryStorage) RemoveSubscription(ski string, entry api.StorageEntry) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	device := s.device(ski)
+	device.Subscriptions = removeStorageEntry(device.Subscriptions, entry)
+
+	return nil
+}
+
+func (s *MemoryStorage) Subscriptions(ski string) ([]api.StorageEntry, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return slices.Clone(s.device(ski).Subscriptions), nil
+}
+
+func (s *MemoryStorage) SaveBinding(ski string, entry api.StorageEntry) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	device := s.device(ski)
+	device.Bindings = addStorageEntry(device.Bindings, entry)
+
+	return nil
+}
+
+func (s *MemoryStorage) RemoveBinding(ski string, entry api.StorageEntry) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	device := s.device(ski)
+	device.Bindings = removeStorageEntry(device.Bindings, entry)
+
+	return nil
+}
+
+func (s *MemoryStorage) Bindings(ski string) ([]api.StorageEntry, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return slices.Clone(s.device(ski).Bindings), nil
+}
+
+func (s *MemoryStorage) SaveMsgCounter(ski string, msgCounter model.MsgCounterType) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.device(ski).MsgCounter = msgCounter
+
+	return nil
+}
+
+func (s *MemoryStorage) MsgCounter(ski string) (model.MsgCounterType, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.device(ski).MsgCounter, nil
+}
+
+// return the data of a remote device, create it if it does not exist
+//
+// mux has to be locked by the caller
+func (s *MemoryStorage) device(ski string) *storageDevice {
+	device, ok := s.devices[ski]
+	if !ok {
+		device = &storageDevice{}
+		s.devices[ski] = device
+	}
+
+	return device
+}
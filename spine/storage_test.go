@@ -0,0 +1,112 @@
+package spine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestStorageSuite(t *testing.T) {
+	suite.Run(t, new(StorageSuite))
+}
+
+type StorageSuite struct {
+	suite.Suite
+
+	entry, entry2 api.StorageEntry
+}
+
+func (s *StorageSuite) BeforeTest(suiteName, testName string) {
+	s.entry = api.StorageEntry{
+		ClientAddress: model.FeatureAddressType{
+			Device:  util.Ptr(model.AddressDeviceType("remote")),
+			Entity:  []model.AddressEntityType{1},
+			Feature: util.Ptr(model.AddressFeatureType(1)),
+		},
+		ServerAddress: model.FeatureAddressType{
+			Device:  util.Ptr(model.AddressDeviceType("local")),
+			Entity:  []model.AddressEntityType{1},
+			Feature: util.Ptr(model.AddressFeatureType(2)),
+		},
+		ServerFeatureType: model.FeatureTypeTypeLoadControl,
+	}
+	s.entry2 = s.entry
+	s.entry2.ServerAddress.Feature = util.Ptr(model.AddressFeatureType(3))
+}
+
+func (s *StorageSuite) testStorage(sut api.StorageInterface) {
+	subscriptions, err := sut.Subscriptions("ski")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, len(subscriptions))
+
+	assert.Nil(s.T(), sut.SaveSubscription("ski", s.entry))
+	assert.Nil(s.T(), sut.SaveSubscription("ski", s.entry))
+	assert.Nil(s.T(), sut.SaveSubscription("ski", s.entry2))
+	subscriptions, err = sut.Subscriptions("ski")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []api.StorageEntry{s.entry, s.entry2}, subscriptions)
+
+	subscriptions, _ = sut.Subscriptions("ski2")
+	assert.Equal(s.T(), 0, len(subscriptions))
+
+	// the feature type is not required to identify an entry
+	removeEntry := s.entry
+	removeEntry.ServerFeatureType = ""
+	assert.Nil(s.T(), sut.RemoveSubscription("ski", removeEntry))
+	subscriptions, _ = sut.Subscriptions("ski")
+	assert.Equal(s.T(), []api.StorageEntry{s.entry2}, subscriptions)
+
+	assert.Nil(s.T(), sut.SaveBinding("ski", s.entry))
+	bindings, err := sut.Bindings("ski")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []api.StorageEntry{s.entry}, bindings)
+
+	assert.Nil(s.T(), sut.SaveBinding("ski", s.entry2))
+	assert.Nil(s.T(), sut.RemoveBinding("ski", s.entry2))
+	bindings, _ = sut.Bindings("ski")
+	assert.Equal(s.T(), []api.StorageEntry{s.entry}, bindings)
+
+	msgCounter, err := sut.MsgCounter("ski")
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), model.MsgCounterType(0), msgCounter)
+
+	assert.Nil(s.T(), sut.SaveMsgCounter("ski", 42))
+	msgCounter, _ = sut.MsgCounter("ski")
+	assert.Equal(s.T(), model.MsgCounterType(42), msgCounter)
+}
+
+func (s *StorageSuite) Test_MemoryStorage() {
+	s.testStorage(NewMemoryStorage())
+}
+
+func (s *StorageSuite) Test_FileStorage() {
+	path := filepath.Join(s.T().TempDir(), "storage.json")
+
+	sut, err := NewFileStorage(path)
+	assert.Nil(s.T(), err)
+	s.testStorage(sut)
+
+	// all entries are loaded again
+	sut, err = NewFileStorage(path)
+	assert.Nil(s.T(), err)
+
+	subscriptions, _ := sut.Subscriptions("ski")
+	assert.Equal(s.T(), []api.StorageEntry{s.entry2}, subscriptions)
+	bindings, _ := sut.Bindings("ski")
+	assert.Equal(s.T(), []api.StorageEntry{s.entry}, bindings)
+	msgCounter, _ := sut.MsgCounter("ski")
+	assert.Equal(s.T(), model.MsgCounterType(42), msgCounter)
+
+	// invalid file content
+	err = os.WriteFile(path, []byte("invalid"), 0600)
+	assert.Nil(s.T(), err)
+	sut, err = NewFileStorage(path)
+	assert.NotNil(s.T(), err)
+	assert.Nil(s.T(), sut)
+}
@@ -0,0 +1,210 @@
+package spine
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/enbility/spine-go/api"
+	"github.com/enbility/spine-go/model"
+	"github.com/enbility/spine-go/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// Runs connect, disconnect, notify and write flows of several devices in parallel,
+// to be used with go test -race
+func TestStressSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping stress tests in short mode")
+	}
+
+	suite.Run(t, new(StressSuite))
+}
+
+const (
+	stressClients     = 6
+	stressConnections = 4
+)
+
+type StressSuite struct {
+	suite.Suite
+
+	server        *DeviceLocal
+	serverEntity  *EntityLocal
+	serverFeature api.FeatureLocalInterface
+
+	// closed when the test is done, stops the background load
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (s *StressSuite) BeforeTest(suiteName, testName string) {
+	s.server = NewDeviceLocal("Vendor", "Server", "SerialS", "CodeS", "AddressServer", model.DeviceTypeTypeChargingStation, model.NetworkManagementFeatureSetTypeSmart)
+	s.serverEntity = NewEntityLocal(s.server, model.EntityTypeTypeEVSE, []model.AddressEntityType{1}, time.Second*4)
+	s.server.AddEntity(s.serverEntity)
+	s.serverFeature = s.serverEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeServer)
+	s.serverFeature.AddFunctionType(model.FunctionTypeLoadControlLimitListData, true, true)
+	s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, stressLimitData(0))
+
+	s.done = make(chan struct{})
+}
+
+func (s *StressSuite) AfterTest(suiteName, testName string) {
+	close(s.done)
+	s.wg.Wait()
+}
+
+func stressLimitData(value float64) *model.LoadControlLimitListDataType {
+	return &model.LoadControlLimitListDataType{
+		LoadControlLimitData: []model.LoadControlLimitDataType{
+			{
+				LimitId:           util.Ptr(model.LoadControlLimitIdType(1)),
+				IsLimitChangeable: util.Ptr(true),
+				Value:             model.NewScaledNumberType(value),
+			},
+		},
+	}
+}
+
+// run a function repeatedly in the background until the test is done
+func (s *StressSuite) background(function func(i int)) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for i := 0; ; i++ {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+
+			function(i)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+}
+
+// notify the subscribers of the server feature about changing data
+func (s *StressSuite) notifyLoad() {
+	s.background(func(i int) {
+		s.serverFeature.SetData(model.FunctionTypeLoadControlLimitListData, stressLimitData(float64(i)))
+	})
+}
+
+// read the state of the server device, as applications do while devices connect and disconnect
+func (s *StressSuite) readLoad() {
+	s.background(func(i int) {
+		for _, remoteDevice := range s.server.RemoteDevices() {
+			_ = remoteDevice.Address()
+			_ = remoteDevice.UseCases()
+			for _, entity := range remoteDevice.Entities() {
+				_ = entity.Address()
+				_ = entity.Description()
+				for _, feature := range entity.Features() {
+					_ = feature.Description()
+					_ = feature.Operations()
+					_ = feature.DataCopy(model.FunctionTypeLoadControlLimitListData)
+				}
+			}
+		}
+
+		for _, entity := range s.server.Entities() {
+			for _, feature := range entity.Features() {
+				_ = feature.Information()
+			}
+		}
+
+		_ = s.server.RemoteDeviceForAddress("AddressClient0")
+		_ = s.server.Information()
+		_ = s.server.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address())
+		_ = s.server.NodeManagement().DataCopy(model.FunctionTypeNodeManagementDetailedDiscoveryData)
+	})
+}
+
+// connect a client device repeatedly to the server, subscribe, bind and write on every connection
+func (s *StressSuite) runClient(index int) {
+	ski := fmt.Sprintf("skiClient%d", index)
+	client := NewDeviceLocal("Vendor", "Client", "SerialC", "CodeC", fmt.Sprintf("AddressClient%d", index), model.DeviceTypeTypeEnergyManagementSystem, model.NetworkManagementFeatureSetTypeSmart)
+	clientEntity := NewEntityLocal(client, model.EntityTypeTypeCEM, []model.AddressEntityType{1}, time.Second*4)
+	client.AddEntity(clientEntity)
+	clientFeature := clientEntity.GetOrAddFeature(model.FeatureTypeTypeLoadControl, model.RoleTypeClient)
+
+	for connection := 0; connection < stressConnections; connection++ {
+		loopback := NewLoopback(client, ski, s.server, "skiServer", LoopbackOptions{})
+
+		var remoteFeature api.FeatureRemoteInterface
+		assert.Eventually(s.T(), func() bool {
+			if remoteDevice := client.RemoteDeviceForSki("skiServer"); remoteDevice != nil {
+				remoteFeature = remoteDevice.FeatureByAddress(s.serverFeature.Address())
+			}
+			return remoteFeature != nil
+		}, time.Second*5, time.Millisecond*5)
+
+		if remoteFeature != nil {
+			_, _ = clientFeature.SubscribeToRemote(remoteFeature.Address())
+			_, _ = clientFeature.BindToRemote(remoteFeature.Address())
+
+			cmd := model.CmdType{
+				LoadControlLimitListData: stressLimitData(float64(index)),
+			}
+			_, _ = remoteFeature.Device().Sender().Write(clientFeature.Address(), remoteFeature.Address(), cmd)
+
+			// give the requests some time to be processed before disconnecting
+			time.Sleep(time.Millisecond * time.Duration(5*connection))
+		}
+
+		loopback.Close()
+		clientFeature.RemoveAllRemoteSubscriptions()
+		clientFeature.RemoveAllRemoteBindings()
+	}
+}
+
+// run all clients in parallel and wait until they are done
+func (s *StressSuite) runClients() {
+	var wg sync.WaitGroup
+	for i := 0; i < stressClients; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			s.runClient(index)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func (s *StressSuite) Test_ConnectDisconnect() {
+	s.notifyLoad()
+	s.readLoad()
+
+	s.runClients()
+
+	// all connections are closed, so nothing may be left of the clients
+	assert.Eventually(s.T(), func() bool {
+		return len(s.server.RemoteDevices()) == 0
+	}, time.Second, time.Millisecond*10)
+	assert.Empty(s.T(), s.server.SubscriptionManager().SubscriptionsOnFeature(*s.serverFeature.Address()))
+}
+
+func (s *StressSuite) Test_EntityChanges() {
+	s.notifyLoad()
+	s.readLoad()
+
+	// entities are added and removed while clients connect and receive the detailed discovery notifies
+	s.background(func(i int) {
+		entity := NewEntityLocal(s.server, model.EntityTypeTypeEVSE, []model.AddressEntityType{model.AddressEntityType(2 + i%3)}, time.Second*4)
+		feature := entity.GetOrAddFeature(model.FeatureTypeTypeMeasurement, model.RoleTypeServer)
+		feature.AddFunctionType(model.FunctionTypeMeasurementListData, true, false)
+
+		s.server.AddEntity(entity)
+		s.server.RemoveEntity(entity)
+	})
+
+	s.runClients()
+
+	assert.Eventually(s.T(), func() bool {
+		return len(s.server.RemoteDevices()) == 0
+	}, time.Second, time.Millisecond*10)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -13,14 +14,18 @@ import (
 	"github.com/enbility/spine-go/util"
 )
 
+// returned if the requested subscription already exists
+var errSubscriptionExists = errors.New("requested subscription is already present")
+
 type SubscriptionManager struct {
 	localDevice api.DeviceLocalInterface
 
 	subscriptionNum     uint64
 	subscriptionEntries []*api.SubscriptionEntry
 
+	authorizationCallbacks []api.AuthorizationCallbackFunc
+
 	mux sync.Mutex
-	// TODO: add persistence
 }
 
 func NewSubscriptionManager(localDevice api.DeviceLocalInterface) *SubscriptionManager {
@@ -50,6 +55,10 @@ func (c *SubscriptionManager) AddSubscription(remoteDevice api.DeviceRemoteInter
 		return err
 	}
 
+	if err := authorizeRequest(c.authorizationCallbacksList(), remoteDevice.Ski(), clientFeature, serverFeature); err != nil {
+		return err
+	}
+
 	subscriptionEntry := &api.SubscriptionEntry{
 		Id:            c.subscriptionId(),
 		ServerFeature: serverFeature,
@@ -61,12 +70,16 @@ func (c *SubscriptionManager) AddSubscription(remoteDevice api.DeviceRemoteInter
 
 	for _, item := range c.subscriptionEntries {
 		if reflect.DeepEqual(item.ServerFeature, serverFeature) && reflect.DeepEqual(item.ClientFeature, clientFeature) {
-			return fmt.Errorf("requested subscription is already present")
+			return errSubscriptionExists
 		}
 	}
 
 	c.subscriptionEntries = append(c.subscriptionEntries, subscriptionEntry)
 
+	if storage := c.localDevice.Storage(); storage != nil {
+		logStorageError(storage.SaveSubscription(remoteDevice.Ski(), newStorageEntry(serverFeature, clientFeature)))
+	}
+
 	payload := api.EventPayload{
 		Ski:          remoteDevice.Ski(),
 		EventType:    api.EventTypeSubscriptionChange,
@@ -77,7 +90,7 @@ func (c *SubscriptionManager) AddSubscription(remoteDevice api.DeviceRemoteInter
 		Feature:      clientFeature,
 		LocalFeature: serverFeature,
 	}
-	Events.Publish(payload)
+	c.localDevice.Events().Publish(payload)
 
 	return nil
 }
@@ -128,6 +141,10 @@ func (c *SubscriptionManager) RemoveSubscription(data model.SubscriptionManageme
 
 	c.subscriptionEntries = newSubscriptionEntries
 
+	if storage := c.localDevice.Storage(); storage != nil {
+		logStorageError(storage.RemoveSubscription(remoteDevice.Ski(), newStorageEntry(serverFeature, clientFeature)))
+	}
+
 	payload := api.EventPayload{
 		Ski:          remoteDevice.Ski(),
 		EventType:    api.EventTypeSubscriptionChange,
@@ -138,12 +155,14 @@ func (c *SubscriptionManager) RemoveSubscription(data model.SubscriptionManageme
 		Feature:      clientFeature,
 		LocalFeature: serverFeature,
 	}
-	Events.Publish(payload)
+	c.localDevice.Events().Publish(payload)
 
 	return nil
 }
 
 // Remove all existing subscriptions for a given remote device
+//
+// Persisted subscriptions are kept, so they can be restored once the device reconnects
 func (c *SubscriptionManager) RemoveSubscriptionsForDevice(remoteDevice api.DeviceRemoteInterface) {
 	if remoteDevice == nil {
 		return
@@ -182,7 +201,7 @@ func (c *SubscriptionManager) RemoveSubscriptionsForEntity(remoteEntity api.Enti
 			Feature:      clientFeature,
 			LocalFeature: serverFeature,
 		}
-		Events.Publish(payload)
+		c.localDevice.Events().Publish(payload)
 	}
 
 	c.subscriptionEntries = newSubscriptionEntries
@@ -214,6 +233,20 @@ func (c *SubscriptionManager) SubscriptionsOnFeature(featureAddress model.Featur
 	return result
 }
 
+func (c *SubscriptionManager) AddAuthorizationCallback(callback api.AuthorizationCallbackFunc) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.authorizationCallbacks = append(c.authorizationCallbacks, callback)
+}
+
+func (c *SubscriptionManager) authorizationCallbacksList() []api.AuthorizationCallbackFunc {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return slices.Clone(c.authorizationCallbacks)
+}
+
 func (c *SubscriptionManager) subscriptionId() uint64 {
 	i := atomic.AddUint64(&c.subscriptionNum, 1)
 	return i
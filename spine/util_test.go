@@ -46,7 +46,7 @@ func (s *UtilsSuite) Test_DataCopyOfType() {
 	data := &model.ElectricalConnectionDescriptionListDataType{
 		ElectricalConnectionDescriptionData: []model.ElectricalConnectionDescriptionDataType{},
 	}
-	updatedData, err1 := localFeature.updateData(false, model.FunctionTypeElectricalConnectionDescriptionListData, data, nil, nil)
+	updatedData, _, err1 := localFeature.updateData(false, model.FunctionTypeElectricalConnectionDescriptionListData, data, nil, nil)
 	assert.NotNil(s.T(), updatedData)
 	assert.Nil(s.T(), err1)
 